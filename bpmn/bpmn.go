@@ -0,0 +1,415 @@
+// Package bpmn parses BPMN 2.0 models with Camunda extensions into a graph of processes, flow nodes and
+// sequence flows. Use it to inspect models returned by ProcessDefinition.GetXML or Deployment.GetResourceBinary:
+//
+//	res, err := client.ProcessDefinition.GetXML(camunda_client_go.QueryProcessDefinitionBy{Key: &key})
+//	definitions, err := bpmn.ParseString(res.Bpmn20Xml)
+//	for _, task := range definitions.ExternalTasks() {
+//		fmt.Println(task.Id, task.Topic)
+//	}
+package bpmn
+
+import (
+	"sort"
+)
+
+// CamundaNamespace the namespace of Camunda extension attributes and elements
+const CamundaNamespace = "http://camunda.org/schema/1.0/bpmn"
+
+// ElementType a type of a flow node, it is the local name of the BPMN element
+type ElementType string
+
+// Types of flow nodes
+const (
+	StartEvent             ElementType = "startEvent"
+	EndEvent               ElementType = "endEvent"
+	IntermediateCatchEvent ElementType = "intermediateCatchEvent"
+	IntermediateThrowEvent ElementType = "intermediateThrowEvent"
+	BoundaryEvent          ElementType = "boundaryEvent"
+	Task                   ElementType = "task"
+	ServiceTask            ElementType = "serviceTask"
+	SendTask               ElementType = "sendTask"
+	ReceiveTask            ElementType = "receiveTask"
+	UserTask               ElementType = "userTask"
+	ManualTask             ElementType = "manualTask"
+	ScriptTask             ElementType = "scriptTask"
+	BusinessRuleTask       ElementType = "businessRuleTask"
+	CallActivity           ElementType = "callActivity"
+	SubProcess             ElementType = "subProcess"
+	Transaction            ElementType = "transaction"
+	AdHocSubProcess        ElementType = "adHocSubProcess"
+	ExclusiveGateway       ElementType = "exclusiveGateway"
+	ParallelGateway        ElementType = "parallelGateway"
+	InclusiveGateway       ElementType = "inclusiveGateway"
+	EventBasedGateway      ElementType = "eventBasedGateway"
+	ComplexGateway         ElementType = "complexGateway"
+)
+
+// EventDefinitionType a type of an event definition
+type EventDefinitionType string
+
+// Types of event definitions
+const (
+	MessageEventDefinition     EventDefinitionType = "message"
+	TimerEventDefinition       EventDefinitionType = "timer"
+	ErrorEventDefinition       EventDefinitionType = "error"
+	SignalEventDefinition      EventDefinitionType = "signal"
+	EscalationEventDefinition  EventDefinitionType = "escalation"
+	TerminateEventDefinition   EventDefinitionType = "terminate"
+	ConditionalEventDefinition EventDefinitionType = "conditional"
+	CompensateEventDefinition  EventDefinitionType = "compensate"
+	LinkEventDefinition        EventDefinitionType = "link"
+)
+
+// ParameterType a type of a value of an input or output parameter
+type ParameterType string
+
+// Types of values of input and output parameters
+const (
+	ParameterText   ParameterType = "text"
+	ParameterScript ParameterType = "script"
+	ParameterList   ParameterType = "list"
+	ParameterMap    ParameterType = "map"
+)
+
+// Definitions a BPMN document
+type Definitions struct {
+	Id              string
+	Name            string
+	TargetNamespace string
+	Processes       []*Process
+	Messages        []*Message
+	Signals         []*Signal
+	Errors          []*Error
+	Escalations     []*Escalation
+}
+
+// Message a message of a document
+type Message struct {
+	Id   string
+	Name string
+}
+
+// Signal a signal of a document
+type Signal struct {
+	Id   string
+	Name string
+}
+
+// Error an error of a document
+type Error struct {
+	Id        string
+	Name      string
+	ErrorCode string
+	// ErrorMessage is the camunda:errorMessage of the error
+	ErrorMessage string
+}
+
+// Escalation an escalation of a document
+type Escalation struct {
+	Id             string
+	Name           string
+	EscalationCode string
+}
+
+// Scope a process or a subprocess, which contains flow nodes and sequence flows
+type Scope struct {
+	FlowNodes     []*FlowNode
+	SequenceFlows []*SequenceFlow
+}
+
+// Process a process of a document
+type Process struct {
+	Scope
+
+	Id   string
+	Name string
+	// IsExecutable is false only if isExecutable is false, the engine deploys processes without the attribute
+	IsExecutable bool
+	// Camunda extensions
+	VersionTag             string
+	HistoryTimeToLive      string
+	CandidateStarterGroups []string
+	CandidateStarterUsers  []string
+}
+
+// FlowNode an event, an activity or a gateway
+type FlowNode struct {
+	Id   string
+	Name string
+	Type ElementType
+	// Process is the process the node belongs to
+	Process *Process
+	// Parent is the subprocess the node belongs to, it is nil for nodes of the process
+	Parent   *FlowNode
+	Incoming []*SequenceFlow
+	Outgoing []*SequenceFlow
+	// Default is the default sequence flow of a gateway or an activity
+	Default *SequenceFlow
+	// Attributes are all attributes of the element by local name
+	Attributes map[string]string
+
+	// EventDefinitions are event definitions of an event
+	EventDefinitions []*EventDefinition
+	// AttachedTo is the activity a boundary event is attached to
+	AttachedTo *FlowNode
+	// CancelActivity is true if a boundary event interrupts the activity
+	CancelActivity bool
+	// BoundaryEvents are boundary events attached to an activity
+	BoundaryEvents []*FlowNode
+	// SubProcess contains flow nodes of a subprocess, a transaction or an ad-hoc subprocess
+	SubProcess *Scope
+	// TriggeredByEvent is true for an event subprocess
+	TriggeredByEvent bool
+	// Message is the message of a receive or a send task
+	Message *Message
+	// MultiInstance is the multi-instance loop of an activity
+	MultiInstance *MultiInstance
+
+	// Camunda extensions
+	TaskType           string
+	Topic              string
+	TaskPriority       string
+	AsyncBefore        bool
+	AsyncAfter         bool
+	Exclusive          bool
+	Class              string
+	Expression         string
+	DelegateExpression string
+	ResultVariable     string
+	FormKey            string
+	Assignee           string
+	CandidateUsers     []string
+	CandidateGroups    []string
+	DueDate            string
+	FollowUpDate       string
+	Priority           string
+	CalledElement      string
+	DecisionRef        string
+	InputOutput        *InputOutput
+	// Properties are camunda:properties of the element
+	Properties map[string]string
+}
+
+// SequenceFlow a sequence flow between flow nodes
+type SequenceFlow struct {
+	Id     string
+	Name   string
+	Source *FlowNode
+	Target *FlowNode
+	// Condition is the condition expression, it is empty for an unconditional flow
+	Condition string
+}
+
+// EventDefinition an event definition of an event
+type EventDefinition struct {
+	Type       EventDefinitionType
+	Message    *Message
+	Signal     *Signal
+	Error      *Error
+	Escalation *Escalation
+	// TimeDate, TimeDuration and TimeCycle define a timer
+	TimeDate     string
+	TimeDuration string
+	TimeCycle    string
+	// Condition is the condition of a conditional event
+	Condition string
+}
+
+// MultiInstance a multi-instance loop of an activity
+type MultiInstance struct {
+	IsSequential        bool
+	LoopCardinality     string
+	CompletionCondition string
+	// Collection and ElementVariable are Camunda extensions
+	Collection      string
+	ElementVariable string
+}
+
+// InputOutput camunda:inputOutput mappings of a flow node
+type InputOutput struct {
+	Inputs  []*Parameter
+	Outputs []*Parameter
+}
+
+// Parameter an input or an output parameter
+type Parameter struct {
+	Name string
+	Type ParameterType
+	// Value is the text or the expression of a text parameter and the source of an inline script
+	Value string
+	// ScriptFormat and ScriptResource define a script parameter
+	ScriptFormat   string
+	ScriptResource string
+	// List and Map are values of list and map parameters, nested values are not supported
+	List []string
+	Map  map[string]string
+}
+
+// Process returns a process by id
+func (d *Definitions) Process(id string) *Process {
+	for _, p := range d.Processes {
+		if p.Id == id {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// ExternalTasks returns external tasks of all processes
+func (d *Definitions) ExternalTasks() []*FlowNode {
+	var tasks []*FlowNode
+	for _, p := range d.Processes {
+		tasks = append(tasks, p.ExternalTasks()...)
+	}
+
+	return tasks
+}
+
+// Topics returns sorted unique topics of external tasks of all processes
+func (d *Definitions) Topics() []string {
+	return topics(d.ExternalTasks())
+}
+
+// FlowNode returns a flow node of the scope or its subprocesses by id
+func (s *Scope) FlowNode(id string) *FlowNode {
+	for _, n := range s.AllFlowNodes() {
+		if n.Id == id {
+			return n
+		}
+	}
+
+	return nil
+}
+
+// AllFlowNodes returns flow nodes of the scope and its subprocesses in document order
+func (s *Scope) AllFlowNodes() []*FlowNode {
+	var nodes []*FlowNode
+	for _, n := range s.FlowNodes {
+		nodes = append(nodes, n)
+		if n.SubProcess != nil {
+			nodes = append(nodes, n.SubProcess.AllFlowNodes()...)
+		}
+	}
+
+	return nodes
+}
+
+// SequenceFlow returns a sequence flow of the scope or its subprocesses by id
+func (s *Scope) SequenceFlow(id string) *SequenceFlow {
+	for _, f := range s.SequenceFlows {
+		if f.Id == id {
+			return f
+		}
+	}
+
+	for _, n := range s.FlowNodes {
+		if n.SubProcess != nil {
+			if f := n.SubProcess.SequenceFlow(id); f != nil {
+				return f
+			}
+		}
+	}
+
+	return nil
+}
+
+// StartEvents returns start events of the scope
+func (s *Scope) StartEvents() []*FlowNode {
+	var events []*FlowNode
+	for _, n := range s.FlowNodes {
+		if n.Type == StartEvent {
+			events = append(events, n)
+		}
+	}
+
+	return events
+}
+
+// ExternalTasks returns external tasks of the scope and its subprocesses
+func (s *Scope) ExternalTasks() []*FlowNode {
+	var tasks []*FlowNode
+	for _, n := range s.AllFlowNodes() {
+		if n.IsExternalTask() {
+			tasks = append(tasks, n)
+		}
+	}
+
+	return tasks
+}
+
+// Topics returns sorted unique topics of external tasks of the scope and its subprocesses
+func (s *Scope) Topics() []string {
+	return topics(s.ExternalTasks())
+}
+
+// IsExternalTask returns true if the node is a service, send or business rule task of camunda:type external,
+// or an event which throws a message with camunda:type external
+func (n *FlowNode) IsExternalTask() bool {
+	if n.TaskType != "external" {
+		return false
+	}
+
+	switch n.Type {
+	case ServiceTask, SendTask, BusinessRuleTask, IntermediateThrowEvent, EndEvent:
+		return true
+	}
+
+	return false
+}
+
+// IsActivity returns true if the node is a task, a subprocess or a call activity
+func (n *FlowNode) IsActivity() bool {
+	switch n.Type {
+	case Task, ServiceTask, SendTask, ReceiveTask, UserTask, ManualTask, ScriptTask, BusinessRuleTask,
+		CallActivity, SubProcess, Transaction, AdHocSubProcess:
+		return true
+	}
+
+	return false
+}
+
+// IsEvent returns true if the node is an event
+func (n *FlowNode) IsEvent() bool {
+	switch n.Type {
+	case StartEvent, EndEvent, IntermediateCatchEvent, IntermediateThrowEvent, BoundaryEvent:
+		return true
+	}
+
+	return false
+}
+
+// IsGateway returns true if the node is a gateway
+func (n *FlowNode) IsGateway() bool {
+	switch n.Type {
+	case ExclusiveGateway, ParallelGateway, InclusiveGateway, EventBasedGateway, ComplexGateway:
+		return true
+	}
+
+	return false
+}
+
+// EventDefinition returns the first event definition of the type, nil if the event has none
+func (n *FlowNode) EventDefinition(t EventDefinitionType) *EventDefinition {
+	for _, d := range n.EventDefinitions {
+		if d.Type == t {
+			return d
+		}
+	}
+
+	return nil
+}
+
+func topics(tasks []*FlowNode) []string {
+	seen := map[string]bool{}
+	var result []string
+	for _, t := range tasks {
+		if t.Topic != "" && !seen[t.Topic] {
+			seen[t.Topic] = true
+			result = append(result, t.Topic)
+		}
+	}
+	sort.Strings(result)
+
+	return result
+}
@@ -0,0 +1,430 @@
+package bpmn
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// flowNodeTypes types of elements parsed as flow nodes, other elements of processes are ignored
+var flowNodeTypes = map[string]ElementType{}
+
+func init() {
+	for _, t := range []ElementType{
+		StartEvent, EndEvent, IntermediateCatchEvent, IntermediateThrowEvent, BoundaryEvent, Task, ServiceTask,
+		SendTask, ReceiveTask, UserTask, ManualTask, ScriptTask, BusinessRuleTask, CallActivity, SubProcess,
+		Transaction, AdHocSubProcess, ExclusiveGateway, ParallelGateway, InclusiveGateway, EventBasedGateway,
+		ComplexGateway,
+	} {
+		flowNodeTypes[string(t)] = t
+	}
+}
+
+// element a generic element of a document
+type element struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Content  string     `xml:",chardata"`
+	Children []element  `xml:",any"`
+}
+
+// attr returns a BPMN attribute by local name
+func (e *element) attr(name string) string {
+	for _, a := range e.Attrs {
+		if a.Name.Local == name && !isCamunda(a.Name.Space) {
+			return a.Value
+		}
+	}
+
+	return ""
+}
+
+// camunda returns a Camunda extension attribute by local name
+func (e *element) camunda(name string) string {
+	for _, a := range e.Attrs {
+		if a.Name.Local == name && isCamunda(a.Name.Space) {
+			return a.Value
+		}
+	}
+
+	return ""
+}
+
+func (e *element) child(name string) *element {
+	for i := range e.Children {
+		if e.Children[i].XMLName.Local == name {
+			return &e.Children[i]
+		}
+	}
+
+	return nil
+}
+
+func (e *element) text() string {
+	return strings.TrimSpace(e.Content)
+}
+
+// isCamunda returns true for the namespace of Camunda extensions, the prefix is kept by the decoder
+// if the namespace is not declared
+func isCamunda(space string) bool {
+	return space == CamundaNamespace || space == "camunda"
+}
+
+// parser state of parsing of a document
+type parser struct {
+	definitions *Definitions
+	messages    map[string]*Message
+	signals     map[string]*Signal
+	errors      map[string]*Error
+	escalations map[string]*Escalation
+}
+
+// Parse parses a BPMN 2.0 document
+func Parse(r io.Reader) (*Definitions, error) {
+	root := element{}
+	if err := xml.NewDecoder(r).Decode(&root); err != nil {
+		return nil, fmt.Errorf("bpmn: %w", err)
+	}
+	if root.XMLName.Local != "definitions" {
+		return nil, fmt.Errorf("bpmn: root element is %s, expected definitions", root.XMLName.Local)
+	}
+
+	p := &parser{
+		definitions: &Definitions{
+			Id:              root.attr("id"),
+			Name:            root.attr("name"),
+			TargetNamespace: root.attr("targetNamespace"),
+		},
+		messages:    map[string]*Message{},
+		signals:     map[string]*Signal{},
+		errors:      map[string]*Error{},
+		escalations: map[string]*Escalation{},
+	}
+	p.parseRootElements(&root)
+
+	for i := range root.Children {
+		if e := &root.Children[i]; e.XMLName.Local == "process" {
+			if err := p.parseProcess(e); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return p.definitions, nil
+}
+
+// ParseString parses a BPMN 2.0 document, e.g. ResBPMNProcessDefinition.Bpmn20Xml
+func ParseString(s string) (*Definitions, error) {
+	return Parse(strings.NewReader(s))
+}
+
+func (p *parser) parseRootElements(root *element) {
+	d := p.definitions
+	for i := range root.Children {
+		e := &root.Children[i]
+		id := e.attr("id")
+		switch e.XMLName.Local {
+		case "message":
+			m := &Message{Id: id, Name: e.attr("name")}
+			d.Messages = append(d.Messages, m)
+			p.messages[id] = m
+		case "signal":
+			s := &Signal{Id: id, Name: e.attr("name")}
+			d.Signals = append(d.Signals, s)
+			p.signals[id] = s
+		case "error":
+			err := &Error{Id: id, Name: e.attr("name"), ErrorCode: e.attr("errorCode"), ErrorMessage: e.camunda("errorMessage")}
+			d.Errors = append(d.Errors, err)
+			p.errors[id] = err
+		case "escalation":
+			esc := &Escalation{Id: id, Name: e.attr("name"), EscalationCode: e.attr("escalationCode")}
+			d.Escalations = append(d.Escalations, esc)
+			p.escalations[id] = esc
+		}
+	}
+}
+
+func (p *parser) parseProcess(e *element) error {
+	process := &Process{
+		Id:                     e.attr("id"),
+		Name:                   e.attr("name"),
+		IsExecutable:           e.attr("isExecutable") != "false",
+		VersionTag:             e.camunda("versionTag"),
+		HistoryTimeToLive:      e.camunda("historyTimeToLive"),
+		CandidateStarterGroups: splitList(e.camunda("candidateStarterGroups")),
+		CandidateStarterUsers:  splitList(e.camunda("candidateStarterUsers")),
+	}
+	if err := p.parseScope(&process.Scope, e, process, nil); err != nil {
+		return err
+	}
+
+	for _, n := range process.AllFlowNodes() {
+		if n.Type != BoundaryEvent {
+			continue
+		}
+
+		ref := n.Attributes["attachedToRef"]
+		activity := process.FlowNode(ref)
+		if activity == nil {
+			return fmt.Errorf("bpmn: activity %s of boundary event %s does not exist", ref, n.Id)
+		}
+		n.AttachedTo = activity
+		activity.BoundaryEvents = append(activity.BoundaryEvents, n)
+	}
+
+	p.definitions.Processes = append(p.definitions.Processes, process)
+	return nil
+}
+
+// parseScope parses flow nodes and sequence flows of a process or a subprocess
+func (p *parser) parseScope(scope *Scope, e *element, process *Process, parent *FlowNode) error {
+	nodes := map[string]*FlowNode{}
+	var flows []*element
+	for i := range e.Children {
+		child := &e.Children[i]
+		if child.XMLName.Local == "sequenceFlow" {
+			flows = append(flows, child)
+			continue
+		}
+
+		t, ok := flowNodeTypes[child.XMLName.Local]
+		if !ok {
+			continue
+		}
+
+		n, err := p.parseFlowNode(child, t, process, parent)
+		if err != nil {
+			return err
+		}
+		scope.FlowNodes = append(scope.FlowNodes, n)
+		nodes[n.Id] = n
+	}
+
+	for _, f := range flows {
+		flow := &SequenceFlow{
+			Id:     f.attr("id"),
+			Name:   f.attr("name"),
+			Source: nodes[f.attr("sourceRef")],
+			Target: nodes[f.attr("targetRef")],
+		}
+		if flow.Source == nil || flow.Target == nil {
+			return fmt.Errorf("bpmn: sequence flow %s connects unknown flow nodes %s and %s", flow.Id, f.attr("sourceRef"), f.attr("targetRef"))
+		}
+		if condition := f.child("conditionExpression"); condition != nil {
+			flow.Condition = condition.text()
+		}
+
+		flow.Source.Outgoing = append(flow.Source.Outgoing, flow)
+		flow.Target.Incoming = append(flow.Target.Incoming, flow)
+		scope.SequenceFlows = append(scope.SequenceFlows, flow)
+	}
+
+	for _, n := range scope.FlowNodes {
+		if ref := n.Attributes["default"]; ref != "" {
+			for _, flow := range n.Outgoing {
+				if flow.Id == ref {
+					n.Default = flow
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *parser) parseFlowNode(e *element, t ElementType, process *Process, parent *FlowNode) (*FlowNode, error) {
+	n := &FlowNode{
+		Id:                 e.attr("id"),
+		Name:               e.attr("name"),
+		Type:               t,
+		Process:            process,
+		Parent:             parent,
+		Attributes:         map[string]string{},
+		CancelActivity:     e.attr("cancelActivity") != "false",
+		TriggeredByEvent:   e.attr("triggeredByEvent") == "true",
+		TaskType:           e.camunda("type"),
+		Topic:              e.camunda("topic"),
+		TaskPriority:       e.camunda("taskPriority"),
+		AsyncBefore:        e.camunda("asyncBefore") == "true" || e.camunda("async") == "true",
+		AsyncAfter:         e.camunda("asyncAfter") == "true",
+		Exclusive:          e.camunda("exclusive") != "false",
+		Class:              e.camunda("class"),
+		Expression:         e.camunda("expression"),
+		DelegateExpression: e.camunda("delegateExpression"),
+		ResultVariable:     e.camunda("resultVariable"),
+		FormKey:            e.camunda("formKey"),
+		Assignee:           e.camunda("assignee"),
+		CandidateUsers:     splitList(e.camunda("candidateUsers")),
+		CandidateGroups:    splitList(e.camunda("candidateGroups")),
+		DueDate:            e.camunda("dueDate"),
+		FollowUpDate:       e.camunda("followUpDate"),
+		Priority:           e.camunda("priority"),
+		CalledElement:      e.attr("calledElement"),
+		DecisionRef:        e.camunda("decisionRef"),
+	}
+	for _, a := range e.Attrs {
+		n.Attributes[a.Name.Local] = a.Value
+	}
+	if ref := e.attr("messageRef"); ref != "" {
+		n.Message = p.message(ref)
+	}
+
+	for i := range e.Children {
+		child := &e.Children[i]
+		switch name := child.XMLName.Local; {
+		case strings.HasSuffix(name, "EventDefinition"):
+			n.EventDefinitions = append(n.EventDefinitions, p.parseEventDefinition(child, n))
+		case name == "multiInstanceLoopCharacteristics":
+			n.MultiInstance = &MultiInstance{
+				IsSequential:    child.attr("isSequential") == "true",
+				Collection:      child.camunda("collection"),
+				ElementVariable: child.camunda("elementVariable"),
+			}
+			if cardinality := child.child("loopCardinality"); cardinality != nil {
+				n.MultiInstance.LoopCardinality = cardinality.text()
+			}
+			if condition := child.child("completionCondition"); condition != nil {
+				n.MultiInstance.CompletionCondition = condition.text()
+			}
+		case name == "extensionElements":
+			parseExtensionElements(child, n)
+		}
+	}
+
+	switch t {
+	case SubProcess, Transaction, AdHocSubProcess:
+		n.SubProcess = &Scope{}
+		if err := p.parseScope(n.SubProcess, e, process, n); err != nil {
+			return nil, err
+		}
+	}
+
+	return n, nil
+}
+
+func (p *parser) parseEventDefinition(e *element, n *FlowNode) *EventDefinition {
+	d := &EventDefinition{Type: EventDefinitionType(strings.TrimSuffix(e.XMLName.Local, "EventDefinition"))}
+	switch d.Type {
+	case MessageEventDefinition:
+		d.Message = p.message(e.attr("messageRef"))
+		// a message throw event may be implemented by an external task
+		if taskType := e.camunda("type"); taskType != "" {
+			n.TaskType = taskType
+			n.Topic = e.camunda("topic")
+			n.TaskPriority = e.camunda("taskPriority")
+		}
+	case SignalEventDefinition:
+		if ref := e.attr("signalRef"); ref != "" {
+			d.Signal = p.signals[ref]
+		}
+	case ErrorEventDefinition:
+		if ref := e.attr("errorRef"); ref != "" {
+			d.Error = p.errors[ref]
+		}
+	case EscalationEventDefinition:
+		if ref := e.attr("escalationRef"); ref != "" {
+			d.Escalation = p.escalations[ref]
+		}
+	case TimerEventDefinition:
+		if c := e.child("timeDate"); c != nil {
+			d.TimeDate = c.text()
+		}
+		if c := e.child("timeDuration"); c != nil {
+			d.TimeDuration = c.text()
+		}
+		if c := e.child("timeCycle"); c != nil {
+			d.TimeCycle = c.text()
+		}
+	case ConditionalEventDefinition:
+		if c := e.child("condition"); c != nil {
+			d.Condition = c.text()
+		}
+	}
+
+	return d
+}
+
+// message returns a message by id, a message is created for an unknown id
+func (p *parser) message(ref string) *Message {
+	if ref == "" {
+		return nil
+	}
+	if m, ok := p.messages[ref]; ok {
+		return m
+	}
+
+	return &Message{Id: ref, Name: ref}
+}
+
+func parseExtensionElements(e *element, n *FlowNode) {
+	for i := range e.Children {
+		child := &e.Children[i]
+		if !isCamunda(child.XMLName.Space) {
+			continue
+		}
+
+		switch child.XMLName.Local {
+		case "inputOutput":
+			n.InputOutput = &InputOutput{}
+			for j := range child.Children {
+				parameter := &child.Children[j]
+				switch parameter.XMLName.Local {
+				case "inputParameter":
+					n.InputOutput.Inputs = append(n.InputOutput.Inputs, parseParameter(parameter))
+				case "outputParameter":
+					n.InputOutput.Outputs = append(n.InputOutput.Outputs, parseParameter(parameter))
+				}
+			}
+		case "properties":
+			if n.Properties == nil {
+				n.Properties = map[string]string{}
+			}
+			for _, property := range child.Children {
+				if property.XMLName.Local == "property" {
+					n.Properties[property.attr("name")] = property.attr("value")
+				}
+			}
+		}
+	}
+}
+
+func parseParameter(e *element) *Parameter {
+	parameter := &Parameter{Name: e.attr("name"), Type: ParameterText, Value: e.text()}
+	if script := e.child("script"); script != nil {
+		parameter.Type = ParameterScript
+		parameter.Value = script.text()
+		parameter.ScriptFormat = script.attr("scriptFormat")
+		parameter.ScriptResource = script.attr("resource")
+	} else if list := e.child("list"); list != nil {
+		parameter.Type = ParameterList
+		parameter.Value = ""
+		for _, value := range list.Children {
+			if value.XMLName.Local == "value" {
+				parameter.List = append(parameter.List, value.text())
+			}
+		}
+	} else if m := e.child("map"); m != nil {
+		parameter.Type = ParameterMap
+		parameter.Value = ""
+		parameter.Map = map[string]string{}
+		for _, entry := range m.Children {
+			if entry.XMLName.Local == "entry" {
+				parameter.Map[entry.attr("key")] = entry.text()
+			}
+		}
+	}
+
+	return parameter
+}
+
+func splitList(list string) []string {
+	var values []string
+	for _, v := range strings.Split(list, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,190 @@
+package bpmn
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const orderBpmn = `<?xml version="1.0" encoding="UTF-8"?>
+<bpmn:definitions xmlns:bpmn="http://www.omg.org/spec/BPMN/20100524/MODEL" xmlns:camunda="http://camunda.org/schema/1.0/bpmn" id="Definitions_order" targetNamespace="http://bpmn.io/schema/bpmn">
+  <bpmn:message id="Message_paid" name="paid" />
+  <bpmn:signal id="Signal_cancel" name="cancel" />
+  <bpmn:error id="Error_rejected" name="Rejected" errorCode="REJECTED" camunda:errorMessage="Order rejected" />
+  <bpmn:process id="order" name="Order" isExecutable="true" camunda:versionTag="v2" camunda:historyTimeToLive="P30D" camunda:candidateStarterGroups="sales, support">
+    <bpmn:startEvent id="start" />
+    <bpmn:serviceTask id="check" name="Check order" camunda:type="external" camunda:topic="check-order" camunda:taskPriority="10" camunda:asyncBefore="true">
+      <bpmn:extensionElements>
+        <camunda:inputOutput>
+          <camunda:inputParameter name="orderId">${order.id}</camunda:inputParameter>
+          <camunda:inputParameter name="items">
+            <camunda:list>
+              <camunda:value>a</camunda:value>
+              <camunda:value>b</camunda:value>
+            </camunda:list>
+          </camunda:inputParameter>
+          <camunda:inputParameter name="limits">
+            <camunda:map>
+              <camunda:entry key="max">100</camunda:entry>
+            </camunda:map>
+          </camunda:inputParameter>
+          <camunda:outputParameter name="approved">
+            <camunda:script scriptFormat="javascript">approved == true</camunda:script>
+          </camunda:outputParameter>
+        </camunda:inputOutput>
+        <camunda:properties>
+          <camunda:property name="owner" value="sales" />
+        </camunda:properties>
+      </bpmn:extensionElements>
+    </bpmn:serviceTask>
+    <bpmn:boundaryEvent id="rejected" attachedToRef="check">
+      <bpmn:errorEventDefinition errorRef="Error_rejected" />
+    </bpmn:boundaryEvent>
+    <bpmn:boundaryEvent id="reminder" attachedToRef="check" cancelActivity="false">
+      <bpmn:timerEventDefinition>
+        <bpmn:timeDuration>PT1H</bpmn:timeDuration>
+      </bpmn:timerEventDefinition>
+    </bpmn:boundaryEvent>
+    <bpmn:exclusiveGateway id="approved" default="toReview" />
+    <bpmn:subProcess id="payment">
+      <bpmn:startEvent id="paymentStart" />
+      <bpmn:receiveTask id="waitPayment" messageRef="Message_paid" />
+      <bpmn:sendTask id="notify" camunda:type="external" camunda:topic="notify" />
+      <bpmn:endEvent id="paymentEnd" />
+      <bpmn:sequenceFlow id="p1" sourceRef="paymentStart" targetRef="waitPayment" />
+      <bpmn:sequenceFlow id="p2" sourceRef="waitPayment" targetRef="notify" />
+      <bpmn:sequenceFlow id="p3" sourceRef="notify" targetRef="paymentEnd" />
+    </bpmn:subProcess>
+    <bpmn:userTask id="review" name="Review" camunda:formKey="embedded:app:review.html" camunda:assignee="${manager}" camunda:candidateGroups="managers,auditors" />
+    <bpmn:intermediateCatchEvent id="cancelled">
+      <bpmn:signalEventDefinition signalRef="Signal_cancel" />
+    </bpmn:intermediateCatchEvent>
+    <bpmn:endEvent id="end">
+      <bpmn:messageEventDefinition messageRef="Message_paid" camunda:type="external" camunda:topic="send-receipt" />
+    </bpmn:endEvent>
+    <bpmn:sequenceFlow id="f1" sourceRef="start" targetRef="check" />
+    <bpmn:sequenceFlow id="f2" sourceRef="check" targetRef="approved" />
+    <bpmn:sequenceFlow id="toPayment" sourceRef="approved" targetRef="payment">
+      <bpmn:conditionExpression xsi:type="bpmn:tFormalExpression" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">${approved}</bpmn:conditionExpression>
+    </bpmn:sequenceFlow>
+    <bpmn:sequenceFlow id="toReview" sourceRef="approved" targetRef="review" />
+    <bpmn:sequenceFlow id="f3" sourceRef="payment" targetRef="end" />
+    <bpmn:sequenceFlow id="f4" sourceRef="review" targetRef="end" />
+    <bpmn:sequenceFlow id="f5" sourceRef="rejected" targetRef="review" />
+  </bpmn:process>
+  <bpmn:process id="archive" isExecutable="false" />
+</bpmn:definitions>`
+
+func TestParse_HelloWorld(t *testing.T) {
+	file, err := os.Open("../examples/deployment/HelloWorld.bpmn")
+	require.NoError(t, err)
+	defer file.Close()
+
+	definitions, err := Parse(file)
+	require.NoError(t, err)
+	require.Len(t, definitions.Processes, 1)
+
+	process := definitions.Process("hello-world-process")
+	require.NotNil(t, process)
+	assert.Equal(t, "Hello World Process", process.Name)
+	assert.True(t, process.IsExecutable)
+	assert.Equal(t, []string{"PrintHello", "PrintWorld"}, definitions.Topics())
+
+	starts := process.StartEvents()
+	require.Len(t, starts, 1)
+	gateway := starts[0].Outgoing[0].Target
+	assert.Equal(t, ExclusiveGateway, gateway.Type)
+	require.NotNil(t, gateway.Default)
+	assert.Equal(t, "PrintHello", gateway.Default.Target.Topic)
+	require.Len(t, gateway.Outgoing, 2)
+	assert.Equal(t, "${isWorld}", gateway.Outgoing[1].Condition)
+	assert.Equal(t, `Print "World"`, gateway.Outgoing[1].Target.Name)
+}
+
+func TestParse(t *testing.T) {
+	definitions, err := ParseString(orderBpmn)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Definitions_order", definitions.Id)
+	require.Len(t, definitions.Processes, 2)
+	assert.False(t, definitions.Process("archive").IsExecutable)
+	require.Len(t, definitions.Errors, 1)
+	assert.Equal(t, &Error{Id: "Error_rejected", Name: "Rejected", ErrorCode: "REJECTED", ErrorMessage: "Order rejected"}, definitions.Errors[0])
+
+	process := definitions.Process("order")
+	assert.Equal(t, "v2", process.VersionTag)
+	assert.Equal(t, "P30D", process.HistoryTimeToLive)
+	assert.Equal(t, []string{"sales", "support"}, process.CandidateStarterGroups)
+	assert.Equal(t, []string{"check-order", "notify", "send-receipt"}, definitions.Topics())
+
+	check := process.FlowNode("check")
+	require.NotNil(t, check)
+	assert.True(t, check.IsExternalTask())
+	assert.True(t, check.IsActivity())
+	assert.Equal(t, "check-order", check.Topic)
+	assert.Equal(t, "10", check.TaskPriority)
+	assert.True(t, check.AsyncBefore)
+	assert.Equal(t, map[string]string{"owner": "sales"}, check.Properties)
+
+	require.NotNil(t, check.InputOutput)
+	require.Len(t, check.InputOutput.Inputs, 3)
+	assert.Equal(t, &Parameter{Name: "orderId", Type: ParameterText, Value: "${order.id}"}, check.InputOutput.Inputs[0])
+	assert.Equal(t, []string{"a", "b"}, check.InputOutput.Inputs[1].List)
+	assert.Equal(t, map[string]string{"max": "100"}, check.InputOutput.Inputs[2].Map)
+	require.Len(t, check.InputOutput.Outputs, 1)
+	assert.Equal(t, ParameterScript, check.InputOutput.Outputs[0].Type)
+	assert.Equal(t, "javascript", check.InputOutput.Outputs[0].ScriptFormat)
+
+	require.Len(t, check.BoundaryEvents, 2)
+	rejected := check.BoundaryEvents[0]
+	assert.Equal(t, check, rejected.AttachedTo)
+	assert.True(t, rejected.CancelActivity)
+	assert.Equal(t, "REJECTED", rejected.EventDefinition(ErrorEventDefinition).Error.ErrorCode)
+	assert.Equal(t, "review", rejected.Outgoing[0].Target.Id)
+	reminder := check.BoundaryEvents[1]
+	assert.False(t, reminder.CancelActivity)
+	assert.Equal(t, "PT1H", reminder.EventDefinition(TimerEventDefinition).TimeDuration)
+
+	payment := process.FlowNode("payment")
+	require.NotNil(t, payment.SubProcess)
+	assert.Len(t, payment.SubProcess.FlowNodes, 4)
+	assert.Len(t, payment.SubProcess.SequenceFlows, 3)
+	wait := process.FlowNode("waitPayment")
+	require.NotNil(t, wait)
+	assert.Equal(t, payment, wait.Parent)
+	assert.Equal(t, "paid", wait.Message.Name)
+	assert.Equal(t, "notify", wait.Outgoing[0].Target.Topic)
+	assert.Equal(t, "${approved}", process.SequenceFlow("toPayment").Condition)
+	assert.NotNil(t, process.SequenceFlow("p2"))
+
+	review := process.FlowNode("review")
+	assert.Equal(t, UserTask, review.Type)
+	assert.Equal(t, "embedded:app:review.html", review.FormKey)
+	assert.Equal(t, "${manager}", review.Assignee)
+	assert.Equal(t, []string{"managers", "auditors"}, review.CandidateGroups)
+	assert.Len(t, review.Incoming, 2)
+
+	assert.Equal(t, "cancel", process.FlowNode("cancelled").EventDefinition(SignalEventDefinition).Signal.Name)
+	end := process.FlowNode("end")
+	assert.True(t, end.IsEvent())
+	assert.True(t, end.IsExternalTask())
+	assert.Equal(t, "paid", end.EventDefinition(MessageEventDefinition).Message.Name)
+	assert.True(t, process.FlowNode("approved").IsGateway())
+}
+
+func TestParse_Errors(t *testing.T) {
+	_, err := ParseString("<definitions")
+	assert.Error(t, err)
+
+	_, err = ParseString(`<process id="order" />`)
+	assert.EqualError(t, err, "bpmn: root element is process, expected definitions")
+
+	_, err = Parse(strings.NewReader(`<definitions><process id="order">
+		<startEvent id="start" />
+		<sequenceFlow id="f1" sourceRef="start" targetRef="missing" />
+	</process></definitions>`))
+	assert.EqualError(t, err, "bpmn: sequence flow f1 connects unknown flow nodes start and missing")
+}
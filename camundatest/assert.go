@@ -0,0 +1,187 @@
+package camundatest
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+// Wait blocks until condition returns true or ctx is done. The condition is checked on every change
+// of the state of the server, it must not block
+func (s *Server) Wait(ctx context.Context, condition func() bool) error {
+	for {
+		s.mu.Lock()
+		changed := s.changed
+		s.mu.Unlock()
+
+		if condition() {
+			return nil
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// AssertExternalTaskCompleted asserts that an external task was completed
+func (s *Server) AssertExternalTaskCompleted(t testing.TB, id string) bool {
+	t.Helper()
+
+	task, ok := s.ExternalTask(id)
+	if !ok {
+		t.Errorf("camundatest: external task %s does not exist", id)
+		return false
+	}
+	if task.State != TaskStateCompleted {
+		t.Errorf("camundatest: external task %s of topic %s is %s, expected %s", id, task.TopicName, task.State, TaskStateCompleted)
+		return false
+	}
+
+	return true
+}
+
+// AssertExternalTaskFailed asserts that a failure was reported for an external task
+func (s *Server) AssertExternalTaskFailed(t testing.TB, id string) bool {
+	t.Helper()
+
+	task, ok := s.ExternalTask(id)
+	if !ok {
+		t.Errorf("camundatest: external task %s does not exist", id)
+		return false
+	}
+	if task.Retries == nil {
+		t.Errorf("camundatest: no failure was reported for external task %s of topic %s", id, task.TopicName)
+		return false
+	}
+
+	return true
+}
+
+// AssertBPMNError asserts that a BPMN error with the code was reported for an external task
+func (s *Server) AssertBPMNError(t testing.TB, id, errorCode string) bool {
+	t.Helper()
+
+	task, ok := s.ExternalTask(id)
+	if !ok {
+		t.Errorf("camundatest: external task %s does not exist", id)
+		return false
+	}
+	if task.State != TaskStateBPMNError {
+		t.Errorf("camundatest: external task %s of topic %s is %s, expected %s", id, task.TopicName, task.State, TaskStateBPMNError)
+		return false
+	}
+	if task.ErrorCode != errorCode {
+		t.Errorf("camundatest: BPMN error of external task %s has code %q, expected %q", id, task.ErrorCode, errorCode)
+		return false
+	}
+
+	return true
+}
+
+// AssertUserTaskCompleted asserts that a user task was completed
+func (s *Server) AssertUserTaskCompleted(t testing.TB, id string) bool {
+	t.Helper()
+
+	task, ok := s.UserTask(id)
+	if !ok {
+		t.Errorf("camundatest: user task %s does not exist", id)
+		return false
+	}
+	if task.State != TaskStateCompleted {
+		t.Errorf("camundatest: user task %s is %s, expected %s", id, task.State, TaskStateCompleted)
+		return false
+	}
+
+	return true
+}
+
+// AssertProcessInstanceEnded asserts that a process instance is completed or terminated
+func (s *Server) AssertProcessInstanceEnded(t testing.TB, id string) bool {
+	t.Helper()
+
+	instance, ok := s.ProcessInstance(id)
+	if !ok {
+		t.Errorf("camundatest: process instance %s does not exist", id)
+		return false
+	}
+	if !instance.Ended() {
+		t.Errorf("camundatest: process instance %s is %s, expected it to be ended", id, instance.State)
+		return false
+	}
+
+	return true
+}
+
+// AssertProcessInstanceActive asserts that a process instance is active
+func (s *Server) AssertProcessInstanceActive(t testing.TB, id string) bool {
+	t.Helper()
+
+	instance, ok := s.ProcessInstance(id)
+	if !ok {
+		t.Errorf("camundatest: process instance %s does not exist", id)
+		return false
+	}
+	if instance.State != StateActive {
+		t.Errorf("camundatest: process instance %s is %s, expected %s", id, instance.State, StateActive)
+		return false
+	}
+
+	return true
+}
+
+// AssertVariable asserts that a process instance has a variable with the value. Values are compared
+// by their JSON representation, so 1 is equal to the float64 1 of a decoded JSON number
+func (s *Server) AssertVariable(t testing.TB, processInstanceId, name string, expected interface{}) bool {
+	t.Helper()
+
+	instance, ok := s.ProcessInstance(processInstanceId)
+	if !ok {
+		t.Errorf("camundatest: process instance %s does not exist", processInstanceId)
+		return false
+	}
+
+	v, ok := instance.Variables[name]
+	if !ok {
+		t.Errorf("camundatest: process instance %s has no variable %s", processInstanceId, name)
+		return false
+	}
+
+	actualJson, _ := json.Marshal(v.Value)
+	expectedJson, _ := json.Marshal(expected)
+	if string(actualJson) != string(expectedJson) {
+		t.Errorf("camundatest: variable %s of process instance %s is %s, expected %s", name, processInstanceId, actualJson, expectedJson)
+		return false
+	}
+
+	return true
+}
+
+// AssertMessageReceived asserts that a message with the name was received, the business key is checked
+// if it is not empty
+func (s *Server) AssertMessageReceived(t testing.TB, name, businessKey string) bool {
+	t.Helper()
+
+	for _, m := range s.Messages() {
+		if m.Name == name && (businessKey == "" || m.BusinessKey == businessKey) {
+			return true
+		}
+	}
+
+	t.Errorf("camundatest: message %s with business key %q was not received", name, businessKey)
+	return false
+}
+
+// AssertNoIncidents asserts that the server has no incidents
+func (s *Server) AssertNoIncidents(t testing.TB) bool {
+	t.Helper()
+
+	incidents := s.Incidents()
+	for _, incident := range incidents {
+		t.Errorf("camundatest: incident %s of type %s: %s", incident.Id, incident.Type, incident.Message)
+	}
+
+	return len(incidents) == 0
+}
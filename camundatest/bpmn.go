@@ -0,0 +1,75 @@
+package camundatest
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/citilinkru/camunda-client-go/v3/bpmn"
+)
+
+// parseBpmn parses processes of a BPMN document with the bpmn package, flow nodes of subprocesses are not
+// executed by the server
+func parseBpmn(content []byte) (*bpmn.Definitions, error) {
+	return bpmn.Parse(bytes.NewReader(content))
+}
+
+// noneStartEvent returns the first start event of the process without a message event definition
+func noneStartEvent(process *bpmn.Process) *bpmn.FlowNode {
+	for _, n := range process.StartEvents() {
+		if messageName(n) == "" {
+			return n
+		}
+	}
+
+	return nil
+}
+
+// messageStartEvent returns the start event of the process for the message, nil if the process has none
+func messageStartEvent(process *bpmn.Process, name string) *bpmn.FlowNode {
+	for _, n := range process.StartEvents() {
+		if messageName(n) == name {
+			return n
+		}
+	}
+
+	return nil
+}
+
+// messageName returns the name of the message of a message event or a receive task
+func messageName(n *bpmn.FlowNode) string {
+	if d := n.EventDefinition(bpmn.MessageEventDefinition); d != nil && d.Message != nil {
+		return d.Message.Name
+	}
+	if n.Message != nil {
+		return n.Message.Name
+	}
+
+	return ""
+}
+
+// timerDefinition returns the timer event definition of the node, nil if the node is not a timer event
+func timerDefinition(n *bpmn.FlowNode) *bpmn.EventDefinition {
+	d := n.EventDefinition(bpmn.TimerEventDefinition)
+	if d == nil || d.TimeDuration == "" && d.TimeDate == "" {
+		return nil
+	}
+
+	return d
+}
+
+// catchesError returns true if the node is an error event for the error code, an error event without an error
+// code catches all errors
+func catchesError(n *bpmn.FlowNode, errorCode string) bool {
+	d := n.EventDefinition(bpmn.ErrorEventDefinition)
+	if d == nil {
+		return false
+	}
+
+	return d.Error == nil || d.Error.ErrorCode == "" || d.Error.ErrorCode == errorCode
+}
+
+// priority returns the camunda:taskPriority of an external task
+func priority(n *bpmn.FlowNode) int {
+	priority, _ := strconv.Atoi(n.TaskPriority)
+	return priority
+}
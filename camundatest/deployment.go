@@ -0,0 +1,393 @@
+package camundatest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+)
+
+// deploymentFields fields of a deployment creation which are not resources
+var deploymentFields = []string{
+	"deployment-name",
+	"enable-duplicate-filtering",
+	"deploy-changed-only",
+	"deployment-source",
+	"tenant-id",
+	"deployment-activation-time",
+}
+
+func (s *Server) registerDeploymentRoutes() {
+	s.handle(http.MethodPost, "/deployment/create", s.createDeployment)
+	s.handle(http.MethodGet, "/deployment", s.getDeploymentList)
+	s.handle(http.MethodGet, "/deployment/count", s.getDeploymentCount)
+	s.handle(http.MethodGet, "/deployment/{id}", s.getDeployment)
+	s.handle(http.MethodDelete, "/deployment/{id}", s.deleteDeployment)
+	s.handle(http.MethodGet, "/deployment/{id}/resources", s.getDeploymentResources)
+	s.handle(http.MethodGet, "/deployment/{id}/resources/{resourceId}", s.getDeploymentResource)
+	s.handle(http.MethodGet, "/deployment/{id}/resources/{resourceId}/data", s.getDeploymentResourceData)
+}
+
+func (s *Server) createDeployment(w http.ResponseWriter, r *http.Request, _ params) {
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		writeBadRequest(w, "invalid deployment: %s", err)
+		return
+	}
+
+	form := r.MultipartForm
+	field := func(name string) string {
+		if values := form.Value[name]; len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+
+	resources := map[string][]byte{}
+	for name, values := range form.Value {
+		if !contains(deploymentFields, name) && len(values) > 0 {
+			resources[name] = []byte(values[0])
+		}
+	}
+	for _, files := range form.File {
+		for _, header := range files {
+			f, err := header.Open()
+			if err != nil {
+				writeBadRequest(w, "invalid resource %s: %s", header.Filename, err)
+				return
+			}
+			content, err := io.ReadAll(f)
+			f.Close()
+			if err != nil {
+				writeBadRequest(w, "invalid resource %s: %s", header.Filename, err)
+				return
+			}
+			resources[path.Base(header.Filename)] = content
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res, err := s.deploy(
+		field("deployment-name"),
+		field("deployment-source"),
+		field("tenant-id"),
+		resources,
+		field("enable-duplicate-filtering") == "true",
+		field("deploy-changed-only") == "true",
+	)
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	s.notify()
+	writeJson(w, http.StatusOK, res)
+}
+
+// deploy creates a deployment of resources and process definitions of BPMN resources, s.mu must be held
+func (s *Server) deploy(name, source, tenantId string, resources map[string][]byte, duplicateFiltering, changedOnly bool) (*camundaclientgo.ResDeploymentCreate, error) {
+	names := make([]string, 0, len(resources))
+	for resourceName := range resources {
+		names = append(names, resourceName)
+	}
+	sort.Strings(names)
+
+	if duplicateFiltering || changedOnly {
+		var changed []string
+		for _, resourceName := range names {
+			previous, ok := s.latestResource(name, tenantId, resourceName)
+			if !ok || !bytes.Equal(previous.Content, resources[resourceName]) {
+				changed = append(changed, resourceName)
+			}
+		}
+
+		if len(changed) == 0 {
+			if latest := s.latestDeployment(name, tenantId); latest != nil {
+				return toResDeploymentCreate(latest, nil), nil
+			}
+		} else if changedOnly {
+			names = changed
+		}
+	}
+
+	d := &Deployment{
+		Id:       s.newId(),
+		Name:     name,
+		Source:   source,
+		TenantId: tenantId,
+		Time:     s.now(),
+	}
+
+	var definitions []*processDefinition
+	for _, resourceName := range names {
+		content := resources[resourceName]
+		d.Resources = append(d.Resources, Resource{Id: s.newId(), Name: resourceName, Content: content})
+
+		if !strings.HasSuffix(resourceName, ".bpmn") && !strings.HasSuffix(resourceName, ".bpmn20.xml") {
+			continue
+		}
+
+		model, err := parseBpmn(content)
+		if err != nil {
+			return nil, fmt.Errorf("ENGINE-09005 Could not parse BPMN process. Errors: %s | %s", err, resourceName)
+		}
+
+		for _, process := range model.Processes {
+			if !process.IsExecutable {
+				continue
+			}
+
+			version := 1
+			if latest := s.latestDefinition(process.Id, tenantId); latest != nil {
+				version = latest.Version + 1
+			}
+
+			definitions = append(definitions, &processDefinition{
+				ProcessDefinition: ProcessDefinition{
+					Id:           fmt.Sprintf("%s:%d:%s", process.Id, version, s.newId()),
+					Key:          process.Id,
+					Name:         process.Name,
+					Version:      version,
+					VersionTag:   process.VersionTag,
+					Resource:     resourceName,
+					DeploymentId: d.Id,
+					TenantId:     tenantId,
+				},
+				xml:     string(content),
+				process: process,
+			})
+		}
+	}
+
+	s.deployments = append(s.deployments, d)
+	s.definitions = append(s.definitions, definitions...)
+	return toResDeploymentCreate(d, definitions), nil
+}
+
+// latestDeployment returns the latest deployment with the name, s.mu must be held
+func (s *Server) latestDeployment(name, tenantId string) *Deployment {
+	for i := len(s.deployments) - 1; i >= 0; i-- {
+		if d := s.deployments[i]; d.Name == name && d.TenantId == tenantId {
+			return d
+		}
+	}
+
+	return nil
+}
+
+// latestResource returns the resource of the latest deployment with the name which contains it, s.mu must be held
+func (s *Server) latestResource(deploymentName, tenantId, resourceName string) (Resource, bool) {
+	for i := len(s.deployments) - 1; i >= 0; i-- {
+		d := s.deployments[i]
+		if d.Name != deploymentName || d.TenantId != tenantId {
+			continue
+		}
+
+		for _, resource := range d.Resources {
+			if resource.Name == resourceName {
+				return resource, true
+			}
+		}
+	}
+
+	return Resource{}, false
+}
+
+func (s *Server) getDeploymentList(w http.ResponseWriter, r *http.Request, _ params) {
+	values, err := queryValues(r)
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	writeJson(w, http.StatusOK, pageList(s.deploymentList(values), values))
+}
+
+func (s *Server) getDeploymentCount(w http.ResponseWriter, r *http.Request, _ params) {
+	values, err := queryValues(r)
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	writeCount(w, len(s.deploymentList(values)))
+}
+
+func (s *Server) deploymentList(values map[string]string) []*camundaclientgo.ResDeployment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deployments := make([]*camundaclientgo.ResDeployment, 0, len(s.deployments))
+	for _, d := range s.deployments {
+		deployments = append(deployments, toResDeployment(d))
+	}
+
+	return filterList(deployments, values, filters[*camundaclientgo.ResDeployment]{
+		"after": func(d *camundaclientgo.ResDeployment, value string) bool {
+			return formatTime(d.DeploymentTime.Time) > value
+		},
+		"before": func(d *camundaclientgo.ResDeployment, value string) bool {
+			return formatTime(d.DeploymentTime.Time) < value
+		},
+	})
+}
+
+func (s *Server) getDeployment(w http.ResponseWriter, _ *http.Request, p params) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d := s.findDeployment(p["id"])
+	if d == nil {
+		writeNotFound(w, "Deployment with id '%s' does not exist", p["id"])
+		return
+	}
+
+	writeJson(w, http.StatusOK, toResDeployment(d))
+}
+
+func (s *Server) deleteDeployment(w http.ResponseWriter, r *http.Request, p params) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d := s.findDeployment(p["id"])
+	if d == nil {
+		writeNotFound(w, "Deployment with id '%s' do not exist", p["id"])
+		return
+	}
+
+	cascade := r.URL.Query().Get("cascade") == "true"
+	for _, instance := range s.instances {
+		definition := s.findDefinition(instance.ProcessDefinitionId)
+		if definition == nil || definition.DeploymentId != d.Id || instance.Ended() {
+			continue
+		}
+
+		if !cascade {
+			writeError(w, http.StatusInternalServerError, "ProcessEngineException",
+				fmt.Sprintf("Deletion of process definition without cascading failed. Process instance %s is running", instance.Id))
+			return
+		}
+
+		s.endInstance(instance, StateExternallyTerminated, "deployment deleted")
+	}
+
+	definitions := s.definitions[:0]
+	for _, definition := range s.definitions {
+		if definition.DeploymentId != d.Id {
+			definitions = append(definitions, definition)
+		}
+	}
+	s.definitions = definitions
+
+	deployments := s.deployments[:0]
+	for _, deployment := range s.deployments {
+		if deployment != d {
+			deployments = append(deployments, deployment)
+		}
+	}
+	s.deployments = deployments
+
+	s.notify()
+	writeNoContent(w)
+}
+
+func (s *Server) getDeploymentResources(w http.ResponseWriter, _ *http.Request, p params) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d := s.findDeployment(p["id"])
+	if d == nil {
+		writeNotFound(w, "Deployment resources for deployment id '%s' do not exist.", p["id"])
+		return
+	}
+
+	resources := make([]*camundaclientgo.ResDeploymentResource, 0, len(d.Resources))
+	for _, resource := range d.Resources {
+		resources = append(resources, &camundaclientgo.ResDeploymentResource{
+			Id:           resource.Id,
+			Name:         resource.Name,
+			DeploymentId: d.Id,
+		})
+	}
+
+	writeJson(w, http.StatusOK, resources)
+}
+
+func (s *Server) getDeploymentResource(w http.ResponseWriter, _ *http.Request, p params) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resource, ok := s.findResource(p["id"], p["resourceId"])
+	if !ok {
+		writeNotFound(w, "Deployment resource with resource id '%s' for deployment id '%s' does not exist.", p["resourceId"], p["id"])
+		return
+	}
+
+	writeJson(w, http.StatusOK, &camundaclientgo.ResDeploymentResource{
+		Id:           resource.Id,
+		Name:         resource.Name,
+		DeploymentId: p["id"],
+	})
+}
+
+func (s *Server) getDeploymentResourceData(w http.ResponseWriter, _ *http.Request, p params) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resource, ok := s.findResource(p["id"], p["resourceId"])
+	if !ok {
+		writeNotFound(w, "Deployment resource '%s' for deployment id '%s' does not exist.", p["resourceId"], p["id"])
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, path.Base(resource.Name)))
+	_, _ = w.Write(resource.Content)
+}
+
+func (s *Server) findResource(deploymentId, resourceId string) (Resource, bool) {
+	if d := s.findDeployment(deploymentId); d != nil {
+		for _, resource := range d.Resources {
+			if resource.Id == resourceId {
+				return resource, true
+			}
+		}
+	}
+
+	return Resource{}, false
+}
+
+func toResDeployment(d *Deployment) *camundaclientgo.ResDeployment {
+	return &camundaclientgo.ResDeployment{
+		Id:             d.Id,
+		Name:           d.Name,
+		Source:         d.Source,
+		TenantId:       d.TenantId,
+		DeploymentTime: camundaclientgo.Time{Time: d.Time},
+	}
+}
+
+func toResDeploymentCreate(d *Deployment, definitions []*processDefinition) *camundaclientgo.ResDeploymentCreate {
+	res := &camundaclientgo.ResDeploymentCreate{
+		Id:             d.Id,
+		Name:           d.Name,
+		Source:         d.Source,
+		TenantId:       d.TenantId,
+		DeploymentTime: camundaclientgo.Time{Time: d.Time},
+	}
+
+	if len(definitions) > 0 {
+		res.DeployedProcessDefinitions = map[string]camundaclientgo.ResProcessDefinition{}
+		for _, definition := range definitions {
+			res.DeployedProcessDefinitions[definition.Id] = *toResProcessDefinition(definition)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,626 @@
+package camundatest
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+	"github.com/citilinkru/camunda-client-go/v3/bpmn"
+)
+
+// token a position of the execution of a process instance. A token waits in a flow node until the node is
+// done, e.g. an external task is completed or a message is correlated, then it leaves the node through
+// its outgoing sequence flows
+type token struct {
+	// id is the id of the execution, the first token of an instance has the id of the instance
+	id   string
+	node string
+	// leaving is true if the node is done and the token must take outgoing sequence flows
+	leaving bool
+	// message is the name of the message the token waits for
+	message string
+	// due is the time the timer the token waits for fires
+	due time.Time
+	// boundaryTimers are due times of timer boundary events of the node by event id
+	boundaryTimers map[string]time.Time
+}
+
+// startInstance starts a process instance of the process definition, s.mu must be held
+func (s *Server) startInstance(d *processDefinition, businessKey string, variables map[string]camundaclientgo.Variable) *processInstance {
+	return s.startInstanceAt(d, noneStartEvent(d.process), businessKey, variables)
+}
+
+// startInstanceAt starts a process instance of the process definition at a start event, s.mu must be held
+func (s *Server) startInstanceAt(d *processDefinition, start *bpmn.FlowNode, businessKey string, variables map[string]camundaclientgo.Variable) *processInstance {
+	p := s.addInstance(ProcessInstance{
+		BusinessKey:          businessKey,
+		ProcessDefinitionId:  d.Id,
+		ProcessDefinitionKey: d.Key,
+		TenantId:             d.TenantId,
+		Variables:            variables,
+	})
+	p.process = d.process
+	if start == nil {
+		s.addIncident(p, "", p.Id, "process %s has no none start event", d.Key)
+		return p
+	}
+
+	s.execute(p, p.newToken(p.Id, start.Id))
+	return p
+}
+
+// continueExecution moves the token waiting in a task of a process instance out of the task, s.mu must be held
+func (s *Server) continueExecution(processInstanceId, executionId, activityId string) {
+	p := s.findInstance(processInstanceId)
+	if p == nil || p.process == nil || p.Ended() {
+		return
+	}
+
+	t := p.findToken(executionId, activityId)
+	if t == nil {
+		return
+	}
+
+	t.leaving = true
+	s.execute(p, t)
+}
+
+// throwError moves the token waiting in a task to a catching error boundary event of the task, the token is
+// consumed if no boundary event catches the error. s.mu must be held
+func (s *Server) throwError(processInstanceId, executionId, activityId, errorCode string) {
+	p := s.findInstance(processInstanceId)
+	if p == nil || p.process == nil || p.Ended() {
+		return
+	}
+
+	t := p.findToken(executionId, activityId)
+	if t == nil {
+		return
+	}
+
+	node := p.process.FlowNode(activityId)
+	if node == nil {
+		return
+	}
+
+	for _, boundary := range node.BoundaryEvents {
+		if catchesError(boundary, errorCode) {
+			s.moveToBoundary(p, t, boundary, true)
+			return
+		}
+	}
+
+	p.removeToken(t)
+	s.completeIfDone(p)
+}
+
+// execute moves tokens of a process instance until all of them wait, s.mu must be held
+func (s *Server) execute(p *processInstance, tokens ...*token) {
+	queue := tokens
+	for len(queue) > 0 {
+		t := queue[0]
+		queue = queue[1:]
+		if p.Ended() || !p.holds(t) {
+			continue
+		}
+
+		node := p.process.FlowNode(t.node)
+		if node == nil {
+			s.addIncident(p, t.node, t.id, "unknown flow node %s", t.node)
+			continue
+		}
+
+		if !t.leaving {
+			if !s.enter(p, t, node) {
+				continue
+			}
+			t.leaving = true
+		}
+
+		queue = append(queue, s.leave(p, t, node)...)
+	}
+}
+
+// enter executes the behavior of a flow node for the token entering it and returns true if the token must leave
+// the node at once
+func (s *Server) enter(p *processInstance, t *token, node *bpmn.FlowNode) bool {
+	variables := p.expressionVariables()
+
+	switch {
+	case node.Type == bpmn.EndEvent:
+		p.removeToken(t)
+		if node.EventDefinition(bpmn.TerminateEventDefinition) != nil {
+			s.endInstance(p, StateCompleted, "")
+		} else {
+			s.completeIfDone(p)
+		}
+		return false
+
+	case node.IsExternalTask():
+		s.addExternalTask(ExternalTask{
+			TopicName:            node.Topic,
+			ActivityId:           node.Id,
+			ActivityInstanceId:   node.Id + ":" + s.newId(),
+			ExecutionId:          t.id,
+			ProcessInstanceId:    p.Id,
+			ProcessDefinitionId:  p.ProcessDefinitionId,
+			ProcessDefinitionKey: p.ProcessDefinitionKey,
+			BusinessKey:          p.BusinessKey,
+			TenantId:             p.TenantId,
+			Priority:             priority(node),
+		})
+		return s.wait(p, t, node)
+
+	case node.Type == bpmn.UserTask:
+		task := UserTask{
+			Name:                 node.Name,
+			TaskDefinitionKey:    node.Id,
+			FormKey:              node.FormKey,
+			ExecutionId:          t.id,
+			ProcessInstanceId:    p.Id,
+			ProcessDefinitionId:  p.ProcessDefinitionId,
+			ProcessDefinitionKey: p.ProcessDefinitionKey,
+			TenantId:             p.TenantId,
+		}
+		var err error
+		if task.Assignee, err = evaluateText(node.Assignee, variables); err == nil {
+			if task.CandidateUsers, err = evaluateList(node.CandidateUsers, variables); err == nil {
+				task.CandidateGroups, err = evaluateList(node.CandidateGroups, variables)
+			}
+		}
+		if err != nil {
+			s.addIncident(p, node.Id, t.id, "%s", err)
+			return false
+		}
+		task.Priority, _ = strconv.Atoi(node.Priority)
+		s.addUserTask(task)
+		return s.wait(p, t, node)
+
+	case node.Type == bpmn.ReceiveTask || node.Type == bpmn.IntermediateCatchEvent && messageName(node) != "":
+		t.message = messageName(node)
+		return s.wait(p, t, node)
+
+	case node.Type == bpmn.IntermediateCatchEvent && timerDefinition(node) != nil:
+		due, err := s.timerDue(node, variables)
+		if err != nil {
+			s.addIncident(p, node.Id, t.id, "%s", err)
+			return false
+		}
+		t.due = due
+		return false
+
+	case node.Type == bpmn.ParallelGateway && len(node.Incoming) > 1:
+		if p.joins == nil {
+			p.joins = map[string]int{}
+		}
+		p.joins[node.Id]++
+		if p.joins[node.Id] < len(node.Incoming) {
+			p.removeToken(t)
+			return false
+		}
+		delete(p.joins, node.Id)
+		return true
+
+	case node.SubProcess != nil || node.Type == bpmn.InclusiveGateway || node.Type == bpmn.EventBasedGateway ||
+		node.Type == bpmn.ComplexGateway || node.Type == bpmn.CallActivity:
+		s.addIncident(p, node.Id, t.id, "flow node %s of type %s is not supported by camundatest", node.Id, node.Type)
+		return false
+	}
+
+	return true
+}
+
+// wait subscribes to boundary events of the activity the token waits in and returns false, s.mu must be held
+func (s *Server) wait(p *processInstance, t *token, node *bpmn.FlowNode) bool {
+	for _, boundary := range node.BoundaryEvents {
+		if timerDefinition(boundary) == nil {
+			continue
+		}
+
+		due, err := s.timerDue(boundary, p.expressionVariables())
+		if err != nil {
+			s.addIncident(p, boundary.Id, t.id, "%s", err)
+			continue
+		}
+		if t.boundaryTimers == nil {
+			t.boundaryTimers = map[string]time.Time{}
+		}
+		t.boundaryTimers[boundary.Id] = due
+	}
+
+	return false
+}
+
+// leave moves the token out of the flow node through outgoing sequence flows and returns tokens to execute
+func (s *Server) leave(p *processInstance, t *token, node *bpmn.FlowNode) []*token {
+	var flows []*bpmn.SequenceFlow
+	var err error
+	switch node.Type {
+	case bpmn.ParallelGateway:
+		flows = node.Outgoing
+	case bpmn.ExclusiveGateway:
+		var flow *bpmn.SequenceFlow
+		if flow, err = p.firstFlow(node); flow != nil {
+			flows = []*bpmn.SequenceFlow{flow}
+		}
+	default:
+		flows, err = p.takenFlows(node)
+	}
+	if err != nil {
+		s.addIncident(p, node.Id, t.id, "%s", err)
+		return nil
+	}
+
+	if len(flows) == 0 {
+		if node.Type == bpmn.ExclusiveGateway {
+			s.addIncident(p, node.Id, t.id, "ENGINE-02004 No outgoing sequence flow for the element with id '%s' could be selected for continuing the process.", node.Id)
+			return nil
+		}
+
+		p.removeToken(t)
+		s.completeIfDone(p)
+		return nil
+	}
+
+	tokens := make([]*token, 0, len(flows))
+	for i, flow := range flows {
+		next := t
+		if i == 0 {
+			t.node = flow.Target.Id
+			t.leaving = false
+			t.message = ""
+			t.due = time.Time{}
+			t.boundaryTimers = nil
+		} else {
+			next = p.newToken(s.newId(), flow.Target.Id)
+		}
+		tokens = append(tokens, next)
+	}
+
+	return tokens
+}
+
+// moveToBoundary moves the execution of the token to a boundary event of the activity it waits in. An interrupting
+// event cancels the activity, a non-interrupting event starts a new token. s.mu must be held
+func (s *Server) moveToBoundary(p *processInstance, t *token, boundary *bpmn.FlowNode, interrupting bool) {
+	if !interrupting {
+		next := p.newToken(s.newId(), boundary.Id)
+		next.leaving = true
+		s.execute(p, next)
+		return
+	}
+
+	s.cancelTasks(p, t)
+	t.node = boundary.Id
+	t.leaving = true
+	t.message = ""
+	t.boundaryTimers = nil
+	s.execute(p, t)
+}
+
+// cancelTasks deletes open tasks of the token, s.mu must be held
+func (s *Server) cancelTasks(p *processInstance, t *token) {
+	for _, task := range s.externalTasks {
+		if task.ProcessInstanceId == p.Id && task.ExecutionId == t.id && task.ActivityId == t.node && task.State == TaskStateCreated {
+			task.State = TaskStateDeleted
+		}
+	}
+
+	for _, task := range s.userTasks {
+		if task.ProcessInstanceId == p.Id && task.ExecutionId == t.id && task.TaskDefinitionKey == t.node && task.State == TaskStateCreated {
+			task.State = TaskStateDeleted
+			task.EndTime = s.now()
+		}
+	}
+}
+
+// completeIfDone completes the process instance if it has no tokens left, s.mu must be held
+func (s *Server) completeIfDone(p *processInstance) {
+	if len(p.tokens) == 0 && !p.Ended() {
+		s.endInstance(p, StateCompleted, "")
+	}
+}
+
+// fireDueTimers moves tokens waiting for due timers of active process instances and returns true if any timer
+// fired, s.mu must be held
+func (s *Server) fireDueTimers() bool {
+	now := s.now()
+	fired := false
+	for _, p := range s.instances {
+		if p.process == nil || p.State != StateActive {
+			continue
+		}
+
+		for _, t := range append([]*token(nil), p.tokens...) {
+			if p.Ended() {
+				break
+			}
+			if !p.holds(t) {
+				continue
+			}
+
+			if !t.due.IsZero() && !t.due.After(now) {
+				t.due = time.Time{}
+				t.leaving = true
+				s.execute(p, t)
+				fired = true
+				continue
+			}
+
+			for _, id := range sortedKeys(t.boundaryTimers) {
+				if due := t.boundaryTimers[id]; due.After(now) {
+					continue
+				}
+
+				delete(t.boundaryTimers, id)
+				boundary := p.process.FlowNode(id)
+				s.moveToBoundary(p, t, boundary, boundary.CancelActivity)
+				fired = true
+				if boundary.CancelActivity {
+					break
+				}
+			}
+		}
+	}
+
+	return fired
+}
+
+// correlate moves a token waiting for the message or starts a process instance with a message start event
+// and returns the process instance, s.mu must be held
+func (s *Server) correlate(name, businessKey string, variables map[string]camundaclientgo.Variable) *processInstance {
+	for _, p := range s.instances {
+		if p.process == nil || p.State != StateActive || businessKey != "" && p.BusinessKey != businessKey {
+			continue
+		}
+
+		for _, t := range p.tokens {
+			if t.message == name {
+				p.Variables = mergeVariables(p.Variables, variables)
+				t.message = ""
+				t.leaving = true
+				s.execute(p, t)
+				return p
+			}
+
+			node := p.process.FlowNode(t.node)
+			if node == nil {
+				continue
+			}
+			for _, boundary := range node.BoundaryEvents {
+				if messageName(boundary) == name {
+					p.Variables = mergeVariables(p.Variables, variables)
+					s.moveToBoundary(p, t, boundary, boundary.CancelActivity)
+					return p
+				}
+			}
+		}
+	}
+
+	for i := len(s.definitions) - 1; i >= 0; i-- {
+		d := s.definitions[i]
+		if d.process == nil || d.Suspended || s.latestDefinition(d.Key, d.TenantId) != d {
+			continue
+		}
+
+		if start := messageStartEvent(d.process, name); start != nil {
+			return s.startInstanceAt(d, start, businessKey, variables)
+		}
+	}
+
+	return nil
+}
+
+// addIncident records an incident of a flow node that cannot be executed, s.mu must be held
+func (s *Server) addIncident(p *processInstance, activityId, executionId, format string, args ...interface{}) {
+	s.incidents = append(s.incidents, &Incident{
+		Id:                  s.newId(),
+		Type:                IncidentTypeFailedJob,
+		Message:             fmt.Sprintf(format, args...),
+		ProcessInstanceId:   p.Id,
+		ProcessDefinitionId: p.ProcessDefinitionId,
+		ActivityId:          activityId,
+		Configuration:       executionId,
+		Time:                s.now(),
+	})
+}
+
+var isoDuration = regexp.MustCompile(`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// timerDue returns the due time of a timer event started now
+func (s *Server) timerDue(node *bpmn.FlowNode, variables map[string]interface{}) (time.Time, error) {
+	timer := timerDefinition(node)
+	if timer.TimeDate != "" {
+		text, err := evaluateText(timer.TimeDate, variables)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", camundaclientgo.DefaultDateTimeFormat} {
+			if due, err := time.Parse(layout, text); err == nil {
+				return due, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("invalid timer date '%s' of %s", text, node.Id)
+	}
+
+	text, err := evaluateText(timer.TimeDuration, variables)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	m := isoDuration.FindStringSubmatch(text)
+	if m == nil || text == "P" || strings.HasSuffix(text, "T") {
+		return time.Time{}, fmt.Errorf("invalid timer duration '%s' of %s", text, node.Id)
+	}
+
+	number := func(i int) int {
+		n, _ := strconv.Atoi(m[i])
+		return n
+	}
+	seconds, _ := strconv.ParseFloat(m[7], 64)
+
+	due := s.now().AddDate(number(1), number(2), number(3)*7+number(4))
+	return due.Add(time.Duration(number(5))*time.Hour + time.Duration(number(6))*time.Minute +
+		time.Duration(seconds*float64(time.Second))), nil
+}
+
+// evaluateText returns the text or the result of an expression as a string
+func evaluateText(text string, variables map[string]interface{}) (string, error) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "${") && !strings.HasPrefix(text, "#{") {
+		return text, nil
+	}
+
+	v, err := evaluateExpression(text, variables)
+	if err != nil || v == nil {
+		return "", err
+	}
+	if f, ok := v.(float64); ok && f == float64(int64(f)) {
+		return strconv.FormatInt(int64(f), 10), nil
+	}
+
+	return fmt.Sprint(v), nil
+}
+
+// evaluateList returns the values of a list, an expression in the list may return several comma separated values
+func evaluateList(list []string, variables map[string]interface{}) ([]string, error) {
+	var values []string
+	for _, item := range list {
+		text, err := evaluateText(item, variables)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, splitList(text)...)
+	}
+
+	return values, nil
+}
+
+func splitList(list string) []string {
+	var values []string
+	for _, v := range strings.Split(list, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
+
+func sortedKeys(times map[string]time.Time) []string {
+	keys := make([]string, 0, len(times))
+	for key := range times {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (p *processInstance) newToken(id, node string) *token {
+	t := &token{id: id, node: node}
+	p.tokens = append(p.tokens, t)
+	return t
+}
+
+func (p *processInstance) holds(t *token) bool {
+	for _, held := range p.tokens {
+		if held == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *processInstance) findToken(executionId, node string) *token {
+	for _, t := range p.tokens {
+		if t.id == executionId && t.node == node {
+			return t
+		}
+	}
+
+	return nil
+}
+
+func (p *processInstance) removeToken(t *token) {
+	for i, held := range p.tokens {
+		if held == t {
+			p.tokens = append(p.tokens[:i], p.tokens[i+1:]...)
+			return
+		}
+	}
+}
+
+// expressionVariables returns values of variables of the process instance for expressions
+func (p *processInstance) expressionVariables() map[string]interface{} {
+	variables := make(map[string]interface{}, len(p.Variables))
+	for name, v := range p.Variables {
+		variables[name] = v.Value
+	}
+
+	return variables
+}
+
+// firstFlow returns the first outgoing sequence flow of an exclusive gateway with a true or no condition,
+// the default flow is taken if no other flow can be taken
+func (p *processInstance) firstFlow(node *bpmn.FlowNode) (*bpmn.SequenceFlow, error) {
+	variables := p.expressionVariables()
+	var defaultFlow *bpmn.SequenceFlow
+	for _, flow := range node.Outgoing {
+		if flow == node.Default {
+			defaultFlow = flow
+			continue
+		}
+
+		if flow.Condition == "" {
+			return flow, nil
+		}
+
+		ok, err := evaluateCondition(flow.Condition, variables)
+		if err != nil {
+			return nil, fmt.Errorf("cannot evaluate condition of sequence flow %s: %s", flow.Id, err)
+		}
+		if ok {
+			return flow, nil
+		}
+	}
+
+	return defaultFlow, nil
+}
+
+// takenFlows returns outgoing sequence flows of a flow node with a true or no condition, the default flow
+// is taken if no other flow can be taken
+func (p *processInstance) takenFlows(node *bpmn.FlowNode) ([]*bpmn.SequenceFlow, error) {
+	variables := p.expressionVariables()
+	var flows []*bpmn.SequenceFlow
+	var defaultFlow *bpmn.SequenceFlow
+	for _, flow := range node.Outgoing {
+		if flow == node.Default {
+			defaultFlow = flow
+			continue
+		}
+
+		if flow.Condition != "" {
+			ok, err := evaluateCondition(flow.Condition, variables)
+			if err != nil {
+				return nil, fmt.Errorf("cannot evaluate condition of sequence flow %s: %s", flow.Id, err)
+			}
+			if !ok {
+				continue
+			}
+		}
+		flows = append(flows, flow)
+	}
+
+	if len(flows) == 0 && defaultFlow != nil {
+		flows = append(flows, defaultFlow)
+	}
+
+	return flows, nil
+}
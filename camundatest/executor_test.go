@@ -0,0 +1,288 @@
+package camundatest
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+	"github.com/citilinkru/camunda-client-go/v3/processor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const orderBpmn = `<?xml version="1.0" encoding="UTF-8"?>
+<bpmn:definitions xmlns:bpmn="http://www.omg.org/spec/BPMN/20100524/MODEL" id="order">
+  <bpmn:message id="Message_ordered" name="ordered" />
+  <bpmn:message id="Message_paid" name="paid" />
+  <bpmn:process id="order" isExecutable="true">
+    <bpmn:startEvent id="ordered">
+      <bpmn:messageEventDefinition messageRef="Message_ordered" />
+    </bpmn:startEvent>
+    <bpmn:intermediateCatchEvent id="paid">
+      <bpmn:messageEventDefinition messageRef="Message_paid" />
+    </bpmn:intermediateCatchEvent>
+    <bpmn:endEvent id="end" />
+    <bpmn:sequenceFlow id="f1" sourceRef="ordered" targetRef="paid" />
+    <bpmn:sequenceFlow id="f2" sourceRef="paid" targetRef="end" />
+  </bpmn:process>
+</bpmn:definitions>`
+
+const approvalBpmn = `<?xml version="1.0" encoding="UTF-8"?>
+<bpmn:definitions xmlns:bpmn="http://www.omg.org/spec/BPMN/20100524/MODEL" xmlns:camunda="http://camunda.org/schema/1.0/bpmn" id="approval">
+  <bpmn:process id="approval" isExecutable="true">
+    <bpmn:startEvent id="start" />
+    <bpmn:parallelGateway id="fork" />
+    <bpmn:serviceTask id="check" camunda:type="external" camunda:topic="check" />
+    <bpmn:serviceTask id="score" camunda:type="external" camunda:topic="score" camunda:taskPriority="10" />
+    <bpmn:parallelGateway id="join" />
+    <bpmn:userTask id="approve" name="Approve" camunda:candidateGroups="${group}" camunda:formKey="embedded:app:forms/approve.html" />
+    <bpmn:exclusiveGateway id="approved" default="rejected" />
+    <bpmn:endEvent id="done" />
+    <bpmn:endEvent id="cancelled" />
+    <bpmn:sequenceFlow id="f1" sourceRef="start" targetRef="fork" />
+    <bpmn:sequenceFlow id="f2" sourceRef="fork" targetRef="check" />
+    <bpmn:sequenceFlow id="f3" sourceRef="fork" targetRef="score" />
+    <bpmn:sequenceFlow id="f4" sourceRef="check" targetRef="join" />
+    <bpmn:sequenceFlow id="f5" sourceRef="score" targetRef="join" />
+    <bpmn:sequenceFlow id="f6" sourceRef="join" targetRef="approve" />
+    <bpmn:sequenceFlow id="f7" sourceRef="approve" targetRef="approved" />
+    <bpmn:sequenceFlow id="f8" sourceRef="approved" targetRef="done">
+      <bpmn:conditionExpression>${approved &amp;&amp; score &gt;= 50}</bpmn:conditionExpression>
+    </bpmn:sequenceFlow>
+    <bpmn:sequenceFlow id="rejected" sourceRef="approved" targetRef="cancelled" />
+  </bpmn:process>
+</bpmn:definitions>`
+
+const timerBpmn = `<?xml version="1.0" encoding="UTF-8"?>
+<bpmn:definitions xmlns:bpmn="http://www.omg.org/spec/BPMN/20100524/MODEL" xmlns:camunda="http://camunda.org/schema/1.0/bpmn" id="timer">
+  <bpmn:error id="Error_rejected" errorCode="REJECTED" />
+  <bpmn:process id="timer" isExecutable="true">
+    <bpmn:startEvent id="start" />
+    <bpmn:serviceTask id="work" camunda:type="external" camunda:topic="work" />
+    <bpmn:boundaryEvent id="timeout" attachedToRef="work">
+      <bpmn:timerEventDefinition>
+        <bpmn:timeDuration>PT30M</bpmn:timeDuration>
+      </bpmn:timerEventDefinition>
+    </bpmn:boundaryEvent>
+    <bpmn:boundaryEvent id="rejected" attachedToRef="work">
+      <bpmn:errorEventDefinition errorRef="Error_rejected" />
+    </bpmn:boundaryEvent>
+    <bpmn:serviceTask id="escalate" camunda:type="external" camunda:topic="escalate" />
+    <bpmn:intermediateCatchEvent id="wait">
+      <bpmn:timerEventDefinition>
+        <bpmn:timeDuration>P1D</bpmn:timeDuration>
+      </bpmn:timerEventDefinition>
+    </bpmn:intermediateCatchEvent>
+    <bpmn:userTask id="review" name="Review" camunda:assignee="${reviewer}" />
+    <bpmn:endEvent id="end" />
+    <bpmn:sequenceFlow id="f1" sourceRef="start" targetRef="work" />
+    <bpmn:sequenceFlow id="f2" sourceRef="work" targetRef="wait" />
+    <bpmn:sequenceFlow id="f3" sourceRef="wait" targetRef="end" />
+    <bpmn:sequenceFlow id="f4" sourceRef="timeout" targetRef="escalate" />
+    <bpmn:sequenceFlow id="f5" sourceRef="escalate" targetRef="end" />
+    <bpmn:sequenceFlow id="f6" sourceRef="rejected" targetRef="review" />
+    <bpmn:sequenceFlow id="f7" sourceRef="review" targetRef="end" />
+  </bpmn:process>
+</bpmn:definitions>`
+
+func deploy(t *testing.T, client *camundaclientgo.Client, name, bpmn string) {
+	t.Helper()
+
+	_, err := client.Deployment.Create(camundaclientgo.ReqDeploymentCreate{
+		DeploymentName: name,
+		Resources: map[string]interface{}{
+			name: strings.NewReader(bpmn),
+		},
+	})
+	require.NoError(t, err)
+}
+
+func startInstance(t *testing.T, client *camundaclientgo.Client, key string, variables map[string]camundaclientgo.Variable) string {
+	t.Helper()
+
+	started, err := client.ProcessDefinition.StartInstance(camundaclientgo.QueryProcessDefinitionBy{Key: &key}, camundaclientgo.ReqStartInstance{
+		Variables: &variables,
+	})
+	require.NoError(t, err)
+	return started.Id
+}
+
+func completeExternalTask(t *testing.T, client *camundaclientgo.Client, topic string, variables map[string]camundaclientgo.Variable) {
+	t.Helper()
+
+	tasks, err := client.ExternalTask.FetchAndLock(camundaclientgo.QueryFetchAndLock{
+		WorkerId: "worker",
+		MaxTasks: 1,
+		Topics:   []*camundaclientgo.QueryFetchAndLockTopic{{TopicName: topic, LockDuration: 60000}},
+	})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+
+	worker := "worker"
+	require.NoError(t, client.ExternalTask.Complete(tasks[0].Id, camundaclientgo.QueryComplete{WorkerId: &worker, Variables: &variables}))
+}
+
+func TestServer_ExecuteHelloWorld(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	deployHelloWorld(t, client)
+
+	p := processor.NewProcessor(client, &processor.Options{
+		WorkerId:           "test",
+		LockDuration:       time.Minute,
+		MaxTasks:           10,
+		LongPollingTimeout: time.Second,
+	}, func(err error) {
+		t.Log(err)
+	})
+	defer p.Shutdown()
+
+	printed := make(chan string, 2)
+	for _, topic := range []string{"PrintHello", "PrintWorld"} {
+		topic := topic
+		p.AddHandler([]*camundaclientgo.QueryFetchAndLockTopic{{TopicName: topic}}, func(ctx *processor.Context) error {
+			printed <- topic
+			return ctx.Complete(processor.QueryComplete{})
+		})
+	}
+
+	hello := startInstance(t, client, "hello-world-process", map[string]camundaclientgo.Variable{
+		"isWorld": {Value: false, Type: camundaclientgo.VariableTypeBoolean},
+	})
+	world := startInstance(t, client, "hello-world-process", map[string]camundaclientgo.Variable{
+		"isWorld": {Value: true, Type: camundaclientgo.VariableTypeBoolean},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, server.Wait(ctx, func() bool {
+		first, _ := server.ProcessInstance(hello)
+		second, _ := server.ProcessInstance(world)
+		return first.Ended() && second.Ended()
+	}))
+
+	assert.ElementsMatch(t, []string{"PrintHello", "PrintWorld"}, []string{<-printed, <-printed})
+	for _, id := range []string{hello, world} {
+		instance, _ := server.ProcessInstance(id)
+		assert.Equal(t, StateCompleted, instance.State)
+	}
+	server.AssertNoIncidents(t)
+}
+
+func TestServer_ExecuteGatewaysAndUserTask(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	deploy(t, client, "approval.bpmn", approvalBpmn)
+
+	for _, approved := range []bool{true, false} {
+		id := startInstance(t, client, "approval", map[string]camundaclientgo.Variable{
+			"group": {Value: "managers", Type: camundaclientgo.VariableTypeString},
+		})
+
+		completeExternalTask(t, client, "check", nil)
+		tasks, err := client.UserTask.GetList(&camundaclientgo.UserTaskGetListQuery{ProcessInstanceId: id})
+		require.NoError(t, err)
+		assert.Empty(t, tasks, "the join waits for both branches")
+
+		completeExternalTask(t, client, "score", map[string]camundaclientgo.Variable{
+			"score": {Value: 70, Type: camundaclientgo.VariableTypeInteger},
+		})
+		tasks, err = client.UserTask.GetList(&camundaclientgo.UserTaskGetListQuery{CandidateGroup: "managers", ProcessInstanceId: id})
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		assert.Equal(t, "approve", tasks[0].TaskDefinitionKey)
+		require.NotNil(t, tasks[0].FormKey)
+		assert.Equal(t, "embedded:app:forms/approve.html", *tasks[0].FormKey)
+
+		require.NoError(t, client.UserTask.Complete(tasks[0].Id, camundaclientgo.QueryUserTaskComplete{
+			Variables: map[string]camundaclientgo.Variable{"approved": {Value: approved, Type: camundaclientgo.VariableTypeBoolean}},
+		}))
+		server.AssertProcessInstanceEnded(t, id)
+	}
+
+	server.AssertNoIncidents(t)
+	tasks := server.ExternalTasks("score")
+	require.Len(t, tasks, 2)
+	assert.Equal(t, 10, tasks[0].Priority)
+}
+
+func TestServer_ExecuteTimers(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	deploy(t, client, "timer.bpmn", timerBpmn)
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	server.SetTime(now)
+
+	// the timer boundary event interrupts the task
+	escalated := startInstance(t, client, "timer", nil)
+	server.Advance(30 * time.Minute)
+	work := server.ExternalTasks("work")
+	require.Len(t, work, 1)
+	assert.Equal(t, TaskStateDeleted, work[0].State)
+	completeExternalTask(t, client, "escalate", nil)
+	server.AssertProcessInstanceEnded(t, escalated)
+
+	// the intermediate timer event waits a day
+	waiting := startInstance(t, client, "timer", nil)
+	completeExternalTask(t, client, "work", nil)
+	server.Advance(23 * time.Hour)
+	server.AssertProcessInstanceActive(t, waiting)
+	server.SetTime(server.Now().Add(time.Hour))
+	server.AssertProcessInstanceEnded(t, waiting)
+
+	server.AssertNoIncidents(t)
+}
+
+func TestServer_ExecuteErrorBoundaryEvent(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	deploy(t, client, "timer.bpmn", timerBpmn)
+
+	id := startInstance(t, client, "timer", map[string]camundaclientgo.Variable{
+		"reviewer": {Value: "john", Type: camundaclientgo.VariableTypeString},
+	})
+	tasks, err := client.ExternalTask.FetchAndLock(camundaclientgo.QueryFetchAndLock{
+		WorkerId: "worker",
+		MaxTasks: 1,
+		Topics:   []*camundaclientgo.QueryFetchAndLockTopic{{TopicName: "work", LockDuration: 60000}},
+	})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+
+	worker, code := "worker", "REJECTED"
+	require.NoError(t, client.ExternalTask.HandleBPMNError(tasks[0].Id, camundaclientgo.QueryHandleBPMNError{WorkerId: &worker, ErrorCode: &code}))
+	server.AssertBPMNError(t, tasks[0].Id, "REJECTED")
+
+	userTasks := server.UserTasks()
+	require.Len(t, userTasks, 1)
+	assert.Equal(t, "review", userTasks[0].TaskDefinitionKey)
+	assert.Equal(t, "john", userTasks[0].Assignee)
+	server.AssertProcessInstanceActive(t, id)
+
+	// the timer of the interrupted task does not fire
+	server.Advance(time.Hour)
+	assert.Empty(t, server.ExternalTasks("escalate"))
+}
+
+func TestServer_ExecuteConditionIncident(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	deployHelloWorld(t, client)
+
+	id := startInstance(t, client, "hello-world-process", nil)
+	server.AssertProcessInstanceActive(t, id)
+
+	incidents := server.Incidents()
+	require.Len(t, incidents, 1)
+	assert.Equal(t, IncidentTypeFailedJob, incidents[0].Type)
+	assert.Equal(t, "ExclusiveGateway_1hu6ot9", incidents[0].ActivityId)
+	assert.Contains(t, incidents[0].Message, "isWorld")
+}
@@ -0,0 +1,578 @@
+package camundatest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+)
+
+// lockRecheckInterval an interval of checks of expired locks and retry timeouts while a fetch and lock request
+// waits for tasks
+const lockRecheckInterval = 50 * time.Millisecond
+
+func (s *Server) registerExternalTaskRoutes() {
+	s.handle(http.MethodPost, "/external-task/fetchAndLock", s.fetchAndLock)
+	s.handle(http.MethodGet, "/external-task", s.getExternalTaskList)
+	s.handle(http.MethodPost, "/external-task", s.getExternalTaskList)
+	s.handle(http.MethodGet, "/external-task/count", s.getExternalTaskCount)
+	s.handle(http.MethodPost, "/external-task/count", s.getExternalTaskCount)
+	s.handle(http.MethodGet, "/external-task/{id}", s.getExternalTask)
+	s.handle(http.MethodPost, "/external-task/{id}/complete", s.completeExternalTask)
+	s.handle(http.MethodPost, "/external-task/{id}/failure", s.handleExternalTaskFailure)
+	s.handle(http.MethodPost, "/external-task/{id}/bpmnError", s.handleExternalTaskBPMNError)
+	s.handle(http.MethodPost, "/external-task/{id}/extendLock", s.extendExternalTaskLock)
+	s.handle(http.MethodPost, "/external-task/{id}/unlock", s.unlockExternalTask)
+	s.handle(http.MethodPut, "/external-task/{id}/retries", s.setExternalTaskRetries)
+	s.handle(http.MethodPut, "/external-task/{id}/priority", s.setExternalTaskPriority)
+}
+
+// fetchAndLock locks available tasks, if there are none it waits up to asyncResponseTimeout for new ones
+func (s *Server) fetchAndLock(w http.ResponseWriter, r *http.Request, _ params) {
+	req := camundaclientgo.QueryFetchAndLock{}
+	if err := decodeBody(r, &req); err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	var timeout <-chan time.Time
+	if req.AsyncResponseTimeout != nil && *req.AsyncResponseTimeout > 0 {
+		timer := time.NewTimer(time.Duration(*req.AsyncResponseTimeout) * time.Millisecond)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	ticker := time.NewTicker(lockRecheckInterval)
+	defer ticker.Stop()
+
+	for {
+		s.mu.Lock()
+		if s.fireDueTimers() {
+			s.notify()
+		}
+		tasks := s.lockExternalTasks(req)
+		changed := s.changed
+		s.mu.Unlock()
+
+		if len(tasks) > 0 || timeout == nil {
+			writeJson(w, http.StatusOK, tasks)
+			return
+		}
+
+		select {
+		case <-changed:
+		case <-ticker.C:
+		case <-timeout:
+			writeJson(w, http.StatusOK, tasks)
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// lockExternalTasks locks available tasks of topics of the request, s.mu must be held
+func (s *Server) lockExternalTasks(req camundaclientgo.QueryFetchAndLock) []*camundaclientgo.ResLockedExternalTask {
+	candidates := append([]*ExternalTask(nil), s.externalTasks...)
+	if req.UsePriority != nil && *req.UsePriority {
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].Priority > candidates[j].Priority
+		})
+	}
+
+	now := s.now()
+	locked := []*camundaclientgo.ResLockedExternalTask{}
+	for _, t := range candidates {
+		if len(locked) >= req.MaxTasks {
+			break
+		}
+
+		if !s.externalTaskAvailable(t, now) {
+			continue
+		}
+
+		for _, topic := range req.Topics {
+			if !s.externalTaskMatches(t, topic) {
+				continue
+			}
+
+			t.WorkerId = req.WorkerId
+			t.LockExpirationTime = now.Add(time.Duration(topic.LockDuration) * time.Millisecond)
+			locked = append(locked, s.toResLockedExternalTask(t, topic))
+			break
+		}
+	}
+
+	return locked
+}
+
+// externalTaskAvailable returns true if the task can be locked, s.mu must be held
+func (s *Server) externalTaskAvailable(t *ExternalTask, now time.Time) bool {
+	if t.State != TaskStateCreated || (t.Retries != nil && *t.Retries <= 0) || t.LockExpirationTime.After(now) {
+		return false
+	}
+
+	instance := s.findInstance(t.ProcessInstanceId)
+	return instance != nil && instance.State == StateActive
+}
+
+// externalTaskMatches returns true if the task matches the topic of a fetch and lock request, s.mu must be held
+func (s *Server) externalTaskMatches(t *ExternalTask, topic *camundaclientgo.QueryFetchAndLockTopic) bool {
+	switch {
+	case topic.TopicName != t.TopicName:
+		return false
+	case topic.BusinessKey != nil && *topic.BusinessKey != t.BusinessKey:
+		return false
+	case topic.ProcessDefinitionId != nil && *topic.ProcessDefinitionId != t.ProcessDefinitionId:
+		return false
+	case len(topic.ProcessDefinitionIdIn) > 0 && !contains(topic.ProcessDefinitionIdIn, t.ProcessDefinitionId):
+		return false
+	case topic.ProcessDefinitionKey != nil && *topic.ProcessDefinitionKey != t.ProcessDefinitionKey:
+		return false
+	case topic.ProcessDefinitionKeyIn != nil && !contains(strings.Split(*topic.ProcessDefinitionKeyIn, ","), t.ProcessDefinitionKey):
+		return false
+	case len(topic.TenantIdIn) > 0 && !contains(topic.TenantIdIn, t.TenantId):
+		return false
+	case topic.WithoutTenantId != nil && *topic.WithoutTenantId == "true" && t.TenantId != "":
+		return false
+	}
+
+	if len(topic.ProcessVariables) > 0 {
+		instance := s.findInstance(t.ProcessInstanceId)
+		for name, value := range topic.ProcessVariables {
+			v, ok := instance.Variables[name]
+			if !ok || fmt.Sprint(v.Value) != fmt.Sprint(value) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (s *Server) toResLockedExternalTask(t *ExternalTask, topic *camundaclientgo.QueryFetchAndLockTopic) *camundaclientgo.ResLockedExternalTask {
+	variables := s.findInstance(t.ProcessInstanceId).Variables
+	if topic.LocalVariables != nil && *topic.LocalVariables {
+		variables = t.LocalVariables
+	}
+
+	res := &camundaclientgo.ResLockedExternalTask{
+		ActivityId:           t.ActivityId,
+		ActivityInstanceId:   t.ActivityInstanceId,
+		ErrorMessage:         t.ErrorMessage,
+		ErrorDetails:         t.ErrorDetails,
+		ExecutionId:          t.ExecutionId,
+		Id:                   t.Id,
+		LockExpirationTime:   formatTime(t.LockExpirationTime),
+		ProcessDefinitionId:  t.ProcessDefinitionId,
+		ProcessDefinitionKey: t.ProcessDefinitionKey,
+		ProcessInstanceId:    t.ProcessInstanceId,
+		TenantId:             t.TenantId,
+		Retries:              t.copy().Retries,
+		WorkerId:             t.WorkerId,
+		Priority:             t.Priority,
+		TopicName:            t.TopicName,
+		BusinessKey:          t.BusinessKey,
+		Variables:            map[string]camundaclientgo.Variable{},
+	}
+
+	for name, v := range variables {
+		if topic.Variables == nil || contains(topic.Variables, name) {
+			res.Variables[name] = v
+		}
+	}
+
+	return res
+}
+
+func (s *Server) getExternalTaskList(w http.ResponseWriter, r *http.Request, _ params) {
+	values, err := queryValues(r)
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	writeJson(w, http.StatusOK, pageList(s.externalTaskList(values), values))
+}
+
+func (s *Server) getExternalTaskCount(w http.ResponseWriter, r *http.Request, _ params) {
+	values, err := queryValues(r)
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	writeCount(w, len(s.externalTaskList(values)))
+}
+
+func (s *Server) externalTaskList(values map[string]string) []*camundaclientgo.ResExternalTask {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	var tasks []*camundaclientgo.ResExternalTask
+	for _, t := range s.externalTasks {
+		if t.State == TaskStateCreated {
+			tasks = append(tasks, s.toResExternalTask(t))
+		}
+	}
+
+	locked := func(t *camundaclientgo.ResExternalTask) bool {
+		return s.findExternalTask(t.Id).LockExpirationTime.After(now)
+	}
+	priority := func(value string) int {
+		priority, _ := strconv.Atoi(value)
+		return priority
+	}
+
+	return filterList(tasks, values, filters[*camundaclientgo.ResExternalTask]{
+		"externalTaskId": func(t *camundaclientgo.ResExternalTask, value string) bool {
+			return t.Id == value
+		},
+		"locked": boolFilter(locked),
+		"notLocked": boolFilter(func(t *camundaclientgo.ResExternalTask) bool {
+			return !locked(t)
+		}),
+		"withRetriesLeft": boolFilter(func(t *camundaclientgo.ResExternalTask) bool {
+			return t.Retries == nil || *t.Retries > 0
+		}),
+		"noRetriesLeft": boolFilter(func(t *camundaclientgo.ResExternalTask) bool {
+			return t.Retries != nil && *t.Retries <= 0
+		}),
+		"active": boolFilter(func(t *camundaclientgo.ResExternalTask) bool {
+			return !t.Suspended
+		}),
+		"suspended": boolFilter(func(t *camundaclientgo.ResExternalTask) bool {
+			return t.Suspended
+		}),
+		"priorityHigherThanOrEquals": func(t *camundaclientgo.ResExternalTask, value string) bool {
+			return t.Priority >= priority(value)
+		},
+		"priorityLowerThanOrEquals": func(t *camundaclientgo.ResExternalTask, value string) bool {
+			return t.Priority <= priority(value)
+		},
+	})
+}
+
+func (s *Server) getExternalTask(w http.ResponseWriter, _ *http.Request, p params) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.openExternalTask(p["id"])
+	if t == nil {
+		writeNotFound(w, "External task with id %s does not exist", p["id"])
+		return
+	}
+
+	writeJson(w, http.StatusOK, s.toResExternalTask(t))
+}
+
+func (s *Server) completeExternalTask(w http.ResponseWriter, r *http.Request, p params) {
+	req := camundaclientgo.QueryComplete{}
+	if err := decodeBody(r, &req); err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.lockedExternalTask(w, p["id"], req.WorkerId, "completed")
+	if !ok {
+		return
+	}
+
+	var variables, localVariables map[string]camundaclientgo.Variable
+	if req.Variables != nil {
+		variables = *req.Variables
+	}
+	if req.LocalVariables != nil {
+		localVariables = *req.LocalVariables
+	}
+
+	s.completeTask(t, variables, localVariables)
+	s.notify()
+	writeNoContent(w)
+}
+
+// completeTask completes an external task, s.mu must be held
+func (s *Server) completeTask(t *ExternalTask, variables, localVariables map[string]camundaclientgo.Variable) {
+	t.State = TaskStateCompleted
+	t.Variables = copyVariables(variables)
+	t.LocalVariables = mergeVariables(t.LocalVariables, localVariables)
+
+	instance := s.findInstance(t.ProcessInstanceId)
+	instance.Variables = mergeVariables(instance.Variables, variables)
+	s.continueExecution(t.ProcessInstanceId, t.ExecutionId, t.ActivityId)
+}
+
+func (s *Server) handleExternalTaskFailure(w http.ResponseWriter, r *http.Request, p params) {
+	req := camundaclientgo.QueryHandleFailure{}
+	if err := decodeBody(r, &req); err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.lockedExternalTask(w, p["id"], req.WorkerId, "failed")
+	if !ok {
+		return
+	}
+
+	retries := 0
+	if req.Retries != nil {
+		retries = *req.Retries
+	}
+	retryTimeout := 0
+	if req.RetryTimeout != nil {
+		retryTimeout = *req.RetryTimeout
+	}
+
+	t.Retries = &retries
+	t.ErrorMessage = ""
+	if req.ErrorMessage != nil {
+		t.ErrorMessage = *req.ErrorMessage
+	}
+	t.ErrorDetails = ""
+	if req.ErrorDetails != nil {
+		t.ErrorDetails = *req.ErrorDetails
+	}
+	t.LockExpirationTime = s.now().Add(time.Duration(retryTimeout) * time.Millisecond)
+
+	if retries <= 0 {
+		s.incidents = append(s.incidents, &Incident{
+			Id:                  s.newId(),
+			Type:                IncidentTypeFailedExternalTask,
+			Message:             t.ErrorMessage,
+			ProcessInstanceId:   t.ProcessInstanceId,
+			ProcessDefinitionId: t.ProcessDefinitionId,
+			ActivityId:          t.ActivityId,
+			Configuration:       t.Id,
+			Time:                s.now(),
+		})
+	}
+
+	s.notify()
+	writeNoContent(w)
+}
+
+func (s *Server) handleExternalTaskBPMNError(w http.ResponseWriter, r *http.Request, p params) {
+	req := camundaclientgo.QueryHandleBPMNError{}
+	if err := decodeBody(r, &req); err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.lockedExternalTask(w, p["id"], req.WorkerId, "reported a BPMN error")
+	if !ok {
+		return
+	}
+
+	var variables map[string]camundaclientgo.Variable
+	if req.Variables != nil {
+		variables = *req.Variables
+	}
+
+	t.State = TaskStateBPMNError
+	t.ErrorCode = ""
+	if req.ErrorCode != nil {
+		t.ErrorCode = *req.ErrorCode
+	}
+	if req.ErrorMessage != nil {
+		t.ErrorMessage = *req.ErrorMessage
+	}
+	t.Variables = copyVariables(variables)
+
+	instance := s.findInstance(t.ProcessInstanceId)
+	instance.Variables = mergeVariables(instance.Variables, variables)
+	s.throwError(t.ProcessInstanceId, t.ExecutionId, t.ActivityId, t.ErrorCode)
+
+	s.notify()
+	writeNoContent(w)
+}
+
+func (s *Server) extendExternalTaskLock(w http.ResponseWriter, r *http.Request, p params) {
+	req := camundaclientgo.QueryExtendLock{}
+	if err := decodeBody(r, &req); err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.lockedExternalTask(w, p["id"], req.WorkerId, "extended")
+	if !ok {
+		return
+	}
+
+	now := s.now()
+	if !t.LockExpirationTime.After(now) {
+		writeError(w, http.StatusInternalServerError, "BadUserRequestException",
+			fmt.Sprintf("Cannot extend a lock that expired: the lock of external task %s expired at %s", t.Id, formatTime(t.LockExpirationTime)))
+		return
+	}
+
+	newDuration := 0
+	if req.NewDuration != nil {
+		newDuration = *req.NewDuration
+	}
+	t.LockExpirationTime = now.Add(time.Duration(newDuration) * time.Millisecond)
+
+	s.notify()
+	writeNoContent(w)
+}
+
+func (s *Server) unlockExternalTask(w http.ResponseWriter, _ *http.Request, p params) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.openExternalTask(p["id"])
+	if t == nil {
+		writeNotFound(w, "External task with id %s does not exist", p["id"])
+		return
+	}
+
+	t.WorkerId = ""
+	t.LockExpirationTime = time.Time{}
+
+	s.notify()
+	writeNoContent(w)
+}
+
+func (s *Server) setExternalTaskRetries(w http.ResponseWriter, r *http.Request, p params) {
+	req := struct {
+		Retries int `json:"retries"`
+	}{}
+	if err := decodeBody(r, &req); err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.openExternalTask(p["id"])
+	if t == nil {
+		writeNotFound(w, "External task with id %s does not exist", p["id"])
+		return
+	}
+
+	t.Retries = &req.Retries
+	if req.Retries > 0 {
+		s.resolveIncidents(t.Id)
+	}
+
+	s.notify()
+	writeNoContent(w)
+}
+
+func (s *Server) setExternalTaskPriority(w http.ResponseWriter, r *http.Request, p params) {
+	req := struct {
+		Priority int `json:"priority"`
+	}{}
+	if err := decodeBody(r, &req); err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.openExternalTask(p["id"])
+	if t == nil {
+		writeNotFound(w, "External task with id %s does not exist", p["id"])
+		return
+	}
+
+	t.Priority = req.Priority
+	s.notify()
+	writeNoContent(w)
+}
+
+// openExternalTask returns a not completed external task by id, s.mu must be held
+func (s *Server) openExternalTask(id string) *ExternalTask {
+	t := s.findExternalTask(id)
+	if t == nil || t.State != TaskStateCreated {
+		return nil
+	}
+
+	return t
+}
+
+// lockedExternalTask returns an external task locked by the worker or writes an error, s.mu must be held
+func (s *Server) lockedExternalTask(w http.ResponseWriter, id string, workerId *string, action string) (*ExternalTask, bool) {
+	t := s.openExternalTask(id)
+	if t == nil {
+		writeNotFound(w, "External task with id %s does not exist", id)
+		return nil, false
+	}
+
+	worker := ""
+	if workerId != nil {
+		worker = *workerId
+	}
+
+	if t.WorkerId == "" || t.WorkerId != worker {
+		writeBadRequest(w, "External Task %s cannot be %s by worker '%s'. It is locked by worker '%s'.", id, action, worker, t.WorkerId)
+		return nil, false
+	}
+
+	return t, true
+}
+
+// resolveIncidents removes incidents of an external task, s.mu must be held
+func (s *Server) resolveIncidents(externalTaskId string) {
+	incidents := s.incidents[:0]
+	for _, incident := range s.incidents {
+		if incident.Configuration != externalTaskId {
+			incidents = append(incidents, incident)
+		}
+	}
+	s.incidents = incidents
+}
+
+func (s *Server) toResExternalTask(t *ExternalTask) *camundaclientgo.ResExternalTask {
+	instance := s.findInstance(t.ProcessInstanceId)
+	return &camundaclientgo.ResExternalTask{
+		ActivityId:           t.ActivityId,
+		ActivityInstanceId:   t.ActivityInstanceId,
+		ErrorMessage:         t.ErrorMessage,
+		ErrorDetails:         t.ErrorDetails,
+		ExecutionId:          t.ExecutionId,
+		Id:                   t.Id,
+		LockExpirationTime:   formatTime(t.LockExpirationTime),
+		ProcessDefinitionId:  t.ProcessDefinitionId,
+		ProcessDefinitionKey: t.ProcessDefinitionKey,
+		ProcessInstanceId:    t.ProcessInstanceId,
+		TenantId:             t.TenantId,
+		Retries:              t.copy().Retries,
+		Suspended:            instance != nil && instance.State == StateSuspended,
+		WorkerId:             t.WorkerId,
+		Priority:             t.Priority,
+		TopicName:            t.TopicName,
+		BusinessKey:          t.BusinessKey,
+	}
+}
+
+// mergeVariables sets variables to dst, which is created if it is nil
+func mergeVariables(dst, variables map[string]camundaclientgo.Variable) map[string]camundaclientgo.Variable {
+	if dst == nil {
+		dst = map[string]camundaclientgo.Variable{}
+	}
+
+	for name, v := range variables {
+		dst[name] = v
+	}
+
+	return dst
+}
@@ -0,0 +1,305 @@
+// Package fixture records HTTP interactions of the client with a live engine to golden files and replays
+// them offline, so contract tests of the REST API can run without Camunda:
+//
+//	recorder := fixture.NewRecorder("testdata/fixtures/external-task.json", nil, fixture.Options{})
+//	client.SetCustomTransport(recorder)
+//	// ... run the test against the engine
+//	err := recorder.Save()
+//
+//	replayer, err := fixture.NewReplayer("testdata/fixtures/external-task.json", fixture.Options{})
+//	client.SetCustomTransport(replayer)
+//
+// A request is served by the first unused interaction with the same method, path, query and body. JSON bodies
+// are compared after normalization, so the order of fields and formatting do not matter, multipart bodies
+// are compared by their parts
+package fixture
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Redacted a value of redacted headers and fields
+const Redacted = "REDACTED"
+
+// DefaultRedactHeaders headers with credentials which are always redacted
+var DefaultRedactHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// DefaultRedactFields fields of JSON bodies with credentials which are always redacted
+var DefaultRedactFields = []string{"password", "apiPassword"}
+
+// Options options of recording and replaying
+type Options struct {
+	// RedactHeaders headers redacted in addition to DefaultRedactHeaders
+	RedactHeaders []string
+	// RedactFields fields of JSON bodies at any depth redacted in addition to DefaultRedactFields.
+	// Use it for values which differ between runs, e.g. generated business keys
+	RedactFields []string
+}
+
+// Fixture a golden file
+type Fixture struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction a recorded request and its response
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request a recorded request
+type Request struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	// Query is the query of the request with sorted parameters
+	Query  string      `json:"query,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body"`
+}
+
+// Response a recorded response
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       Body        `json:"body"`
+}
+
+// Body a recorded body. Exactly one of the fields is set for a non-empty body
+type Body struct {
+	// JSON is a normalized JSON body, the parts of a multipart body are recorded as a JSON object
+	JSON json.RawMessage `json:"json,omitempty"`
+	// Text is a body of valid UTF-8 which is not JSON
+	Text string `json:"text,omitempty"`
+	// Base64 is a binary body
+	Base64 string `json:"base64,omitempty"`
+}
+
+// Load reads a golden file
+func Load(path string) (*Fixture, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &Fixture{}
+	if err := json.Unmarshal(content, f); err != nil {
+		return nil, fmt.Errorf("invalid fixture %s: %w", path, err)
+	}
+
+	return f, nil
+}
+
+// Save writes the fixture to a golden file, missing directories are created
+func (f *Fixture) Save(path string) error {
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(f); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+// Bytes returns the content of the body
+func (b Body) Bytes() ([]byte, error) {
+	switch {
+	case b.JSON != nil:
+		return b.JSON, nil
+	case b.Base64 != "":
+		return base64.StdEncoding.DecodeString(b.Base64)
+	}
+
+	return []byte(b.Text), nil
+}
+
+// newBody returns a body of the content normalized for comparison
+func (o Options) newBody(content []byte, contentType string) Body {
+	if len(content) == 0 {
+		return Body{}
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(contentType)
+	if mediaType == "multipart/form-data" {
+		if parts, err := readParts(content, params["boundary"]); err == nil {
+			return Body{JSON: o.normalize(parts)}
+		}
+	}
+
+	var v interface{}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); err == nil && !decoder.More() {
+		return Body{JSON: o.normalize(v)}
+	}
+
+	if utf8.Valid(content) {
+		return Body{Text: string(content)}
+	}
+
+	return Body{Base64: base64.StdEncoding.EncodeToString(content)}
+}
+
+// normalize returns v as JSON with redacted fields and sorted keys
+func (o Options) normalize(v interface{}) json.RawMessage {
+	fields := append(append([]string(nil), DefaultRedactFields...), o.RedactFields...)
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(redact(v, fields))
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
+
+func redact(v interface{}, fields []string) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			if contains(fields, key) {
+				redacted[key] = Redacted
+			} else {
+				redacted[key] = redact(value, fields)
+			}
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, value := range v {
+			redacted[i] = redact(value, fields)
+		}
+		return redacted
+	}
+
+	return v
+}
+
+// readParts returns parts of a multipart body by form name, a content of a part is kept as JSON if it is JSON
+func readParts(content []byte, boundary string) (map[string]interface{}, error) {
+	parts := map[string]interface{}{}
+	reader := multipart.NewReader(bytes.NewReader(content), boundary)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return parts, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := io.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+
+		var v interface{}
+		if err := json.Unmarshal(data, &v); err != nil || part.FileName() != "" {
+			v = string(data)
+		}
+		parts[part.FormName()] = v
+	}
+}
+
+// redactHeader returns a copy of the header with redacted credentials
+func (o Options) redactHeader(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+
+	redacted := header.Clone()
+	for _, name := range append(append([]string(nil), DefaultRedactHeaders...), o.RedactHeaders...) {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, Redacted)
+		}
+	}
+
+	return redacted
+}
+
+// newRequest records a request, the body of the request is replaced to be read again
+func (o Options) newRequest(req *http.Request) (Request, error) {
+	var content []byte
+	if req.Body != nil {
+		var err error
+		if content, err = io.ReadAll(req.Body); err != nil {
+			return Request{}, err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(content))
+	}
+
+	return Request{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  sortedQuery(req.URL.RawQuery),
+		Header: o.redactHeader(req.Header),
+		Body:   o.newBody(content, req.Header.Get("Content-Type")),
+	}, nil
+}
+
+// matches returns true if the recorded request has the method, path, query and body of the other request
+func (r Request) matches(other Request) bool {
+	return r.Method == other.Method && r.Path == other.Path && r.Query == other.Query &&
+		bytes.Equal(compact(r.Body.JSON), compact(other.Body.JSON)) && r.Body.Text == other.Body.Text &&
+		r.Body.Base64 == other.Body.Base64
+}
+
+// compact returns JSON without insignificant spaces, golden files are indented
+func compact(content json.RawMessage) []byte {
+	buf := &bytes.Buffer{}
+	if err := json.Compact(buf, content); err != nil {
+		return content
+	}
+
+	return buf.Bytes()
+}
+
+// String returns the request line
+func (r Request) String() string {
+	if r.Query == "" {
+		return r.Method + " " + r.Path
+	}
+
+	return r.Method + " " + r.Path + "?" + r.Query
+}
+
+func sortedQuery(rawQuery string) string {
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return rawQuery
+	}
+
+	for _, v := range values {
+		sort.Strings(v)
+	}
+
+	// Encode sorts values by key
+	return strings.ReplaceAll(values.Encode(), "+", "%20")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
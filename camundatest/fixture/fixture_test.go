@@ -0,0 +1,108 @@
+package fixture
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+	"github.com/citilinkru/camunda-client-go/v3/camundatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	server := camundatest.NewServer()
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "fixtures", "process.json")
+	options := camundaclientgo.ClientOptions{
+		EndpointUrl: server.URL + camundatest.BasePath,
+		ApiUser:     "demo",
+		ApiPassword: "secret",
+	}
+
+	recorder := NewRecorder(path, nil, Options{})
+	client := camundaclientgo.NewClient(options)
+	client.SetCustomTransport(recorder)
+
+	bpmn, err := os.ReadFile("../../examples/deployment/HelloWorld.bpmn")
+	require.NoError(t, err)
+	_, err = client.Deployment.Create(camundaclientgo.ReqDeploymentCreate{
+		DeploymentName: "HelloWorld",
+		Resources:      map[string]interface{}{"HelloWorld.bpmn": strings.NewReader(string(bpmn))},
+	})
+	require.NoError(t, err)
+
+	key := "hello-world-process"
+	started, err := client.ProcessDefinition.StartInstance(camundaclientgo.QueryProcessDefinitionBy{Key: &key}, camundaclientgo.ReqStartInstance{
+		Variables: &map[string]camundaclientgo.Variable{
+			"isWorld": {Value: true, Type: camundaclientgo.VariableTypeBoolean},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = client.ProcessInstance.Get("unknown")
+	assert.True(t, errors.Is(err, camundaclientgo.ErrorNotFound))
+	require.NoError(t, recorder.Save())
+
+	golden, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(golden), "secret")
+	assert.Contains(t, string(golden), Redacted)
+
+	replayer, err := NewReplayer(path, Options{})
+	require.NoError(t, err)
+	client = camundaclientgo.NewClient(options)
+	client.SetCustomTransport(replayer)
+	server.Close()
+
+	// the multipart boundary of the deployment is random
+	_, err = client.Deployment.Create(camundaclientgo.ReqDeploymentCreate{
+		DeploymentName: "HelloWorld",
+		Resources:      map[string]interface{}{"HelloWorld.bpmn": strings.NewReader(string(bpmn))},
+	})
+	require.NoError(t, err)
+
+	replayed, err := client.ProcessDefinition.StartInstance(camundaclientgo.QueryProcessDefinitionBy{Key: &key}, camundaclientgo.ReqStartInstance{
+		Variables: &map[string]camundaclientgo.Variable{
+			"isWorld": {Type: camundaclientgo.VariableTypeBoolean, Value: true},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, started.Id, replayed.Id)
+
+	_, err = client.ProcessInstance.Get("unknown")
+	assert.True(t, errors.Is(err, camundaclientgo.ErrorNotFound))
+	assert.Empty(t, replayer.Unused())
+
+	// every interaction serves one request
+	_, err = client.ProcessInstance.Get("unknown")
+	assert.ErrorContains(t, err, "no recorded interaction matches GET /engine-rest/process-instance/unknown")
+}
+
+func TestReplayer_MatchesNormalizedJSON(t *testing.T) {
+	options := Options{RedactFields: []string{"businessKey"}}
+	replayer := NewFixtureReplayer(&Fixture{Interactions: []Interaction{{
+		Request: Request{
+			Method: "POST",
+			Path:   "/engine-rest/message",
+			Body:   Body{JSON: []byte(`{"businessKey":"REDACTED","messageName":"paid"}`)},
+		},
+		Response: Response{StatusCode: 204},
+	}}}, options)
+
+	client := camundaclientgo.NewClient(camundaclientgo.ClientOptions{EndpointUrl: "http://camunda.invalid/engine-rest"})
+	client.SetCustomTransport(replayer)
+
+	err := client.Message.SendMessage(&camundaclientgo.ReqMessage{MessageName: "other", BusinessKey: "order-1"})
+	assert.Error(t, err)
+	require.NoError(t, client.Message.SendMessage(&camundaclientgo.ReqMessage{MessageName: "paid", BusinessKey: "order-1"}))
+}
+
+func TestSortedQuery(t *testing.T) {
+	assert.Equal(t, "a=1&b=2&b=3&c=x%20y", sortedQuery("c=x+y&b=3&a=1&b=2"))
+	assert.Equal(t, "", sortedQuery(""))
+}
@@ -0,0 +1,80 @@
+package fixture
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Recorder an http.RoundTripper which sends requests with a transport and records them with their responses
+type Recorder struct {
+	path      string
+	transport http.RoundTripper
+	options   Options
+
+	mu           sync.Mutex
+	interactions []Interaction
+}
+
+// NewRecorder returns a Recorder of a golden file at path. Requests are sent with transport,
+// http.DefaultTransport is used if it is nil
+func NewRecorder(path string, transport http.RoundTripper, options Options) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &Recorder{path: path, transport: transport, options: options}
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := r.options.newRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(content))
+
+	// the recorded body is normalized, so its length may differ
+	header := res.Header.Clone()
+	header.Del("Content-Length")
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.interactions = append(r.interactions, Interaction{
+		Request: recorded,
+		Response: Response{
+			StatusCode: res.StatusCode,
+			Header:     r.options.redactHeader(header),
+			Body:       r.options.newBody(content, res.Header.Get("Content-Type")),
+		},
+	})
+
+	return res, nil
+}
+
+// Interactions returns recorded interactions in order of requests
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Interaction(nil), r.interactions...)
+}
+
+// Save writes recorded interactions to the golden file
+func (r *Recorder) Save() error {
+	f := &Fixture{Interactions: r.Interactions()}
+	return f.Save(r.path)
+}
@@ -0,0 +1,109 @@
+package fixture
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Replayer an http.RoundTripper which serves recorded responses without sending requests
+type Replayer struct {
+	options Options
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewReplayer returns a Replayer of a golden file at path. Options must redact the fields redacted on
+// recording, otherwise requests with these fields do not match
+func NewReplayer(path string, options Options) (*Replayer, error) {
+	f, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFixtureReplayer(f, options), nil
+}
+
+// NewFixtureReplayer returns a Replayer of interactions of the fixture
+func NewFixtureReplayer(f *Fixture, options Options) *Replayer {
+	return &Replayer{
+		options:      options,
+		interactions: append([]Interaction(nil), f.Interactions...),
+		used:         make([]bool, len(f.Interactions)),
+	}
+}
+
+// RoundTrip implements http.RoundTripper. A request is served by the first unused interaction
+// with a matching request, an error is returned if there is no such interaction
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := r.options.newRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.interactions {
+		if r.used[i] || !interaction.Request.matches(recorded) {
+			continue
+		}
+
+		content, err := interaction.Response.Body.Bytes()
+		if err != nil {
+			return nil, fmt.Errorf("fixture: invalid body of the response to %s: %w", recorded, err)
+		}
+
+		r.used[i] = true
+		header := interaction.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(content)),
+			ContentLength: int64(len(content)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("fixture: no recorded interaction matches %s %s", recorded, recorded.Body.describe())
+}
+
+// Unused returns interactions which did not serve a request
+func (r *Replayer) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []Interaction
+	for i, interaction := range r.interactions {
+		if !r.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+
+	return unused
+}
+
+// describe returns the body for error messages
+func (b Body) describe() string {
+	switch {
+	case b.JSON != nil:
+		return string(b.JSON)
+	case b.Base64 != "":
+		return fmt.Sprintf("(%d bytes of base64)", len(b.Base64))
+	case b.Text != "":
+		return fmt.Sprintf("%q", b.Text)
+	}
+
+	return "without body"
+}
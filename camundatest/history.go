@@ -0,0 +1,338 @@
+package camundatest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+)
+
+func (s *Server) registerHistoryRoutes() {
+	s.handle(http.MethodGet, "/history/process-instance", s.getHistoryProcessInstanceList)
+	s.handle(http.MethodPost, "/history/process-instance", s.getHistoryProcessInstanceList)
+	s.handle(http.MethodGet, "/history/process-instance/count", s.getHistoryProcessInstanceCount)
+	s.handle(http.MethodPost, "/history/process-instance/count", s.getHistoryProcessInstanceCount)
+	s.handle(http.MethodGet, "/history/process-instance/{id}", s.getHistoryProcessInstance)
+
+	s.handle(http.MethodGet, "/history/task", s.getHistoryTaskList)
+	s.handle(http.MethodPost, "/history/task", s.getHistoryTaskList)
+	s.handle(http.MethodGet, "/history/task/count", s.getHistoryTaskCount)
+	s.handle(http.MethodPost, "/history/task/count", s.getHistoryTaskCount)
+
+	s.handle(http.MethodGet, "/history/variable-instance", s.getHistoryVariableInstanceList)
+	s.handle(http.MethodPost, "/history/variable-instance", s.getHistoryVariableInstanceList)
+	s.handle(http.MethodGet, "/history/variable-instance/count", s.getHistoryVariableInstanceCount)
+	s.handle(http.MethodPost, "/history/variable-instance/count", s.getHistoryVariableInstanceCount)
+	s.handle(http.MethodGet, "/history/variable-instance/{id}", s.getHistoryVariableInstance)
+	s.handle(http.MethodGet, "/history/variable-instance/{id}/data", s.getHistoryVariableInstanceData)
+}
+
+func (s *Server) getHistoryProcessInstanceList(w http.ResponseWriter, r *http.Request, _ params) {
+	values, err := queryValues(r)
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	writeJson(w, http.StatusOK, pageList(s.historyProcessInstanceList(values), values))
+}
+
+func (s *Server) getHistoryProcessInstanceCount(w http.ResponseWriter, r *http.Request, _ params) {
+	values, err := queryValues(r)
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	writeCount(w, len(s.historyProcessInstanceList(values)))
+}
+
+func (s *Server) historyProcessInstanceList(values map[string]string) []*camundaclientgo.ResHistoryProcessInstance {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	instances := make([]*camundaclientgo.ResHistoryProcessInstance, 0, len(s.instances))
+	for _, p := range s.instances {
+		instances = append(instances, s.toResHistoryProcessInstance(p))
+	}
+
+	state := func(states ...string) func(p *camundaclientgo.ResHistoryProcessInstance) bool {
+		return func(p *camundaclientgo.ResHistoryProcessInstance) bool {
+			return contains(states, p.State)
+		}
+	}
+
+	return filterList(instances, values, filters[*camundaclientgo.ResHistoryProcessInstance]{
+		"processInstanceId": func(p *camundaclientgo.ResHistoryProcessInstance, value string) bool {
+			return p.Id == value
+		},
+		"processInstanceIds": func(p *camundaclientgo.ResHistoryProcessInstance, value string) bool {
+			return contains(strings.Split(value, ","), p.Id)
+		},
+		"processInstanceBusinessKey": func(p *camundaclientgo.ResHistoryProcessInstance, value string) bool {
+			return p.BusinessKey == value
+		},
+		"processInstanceBusinessKeyLike": func(p *camundaclientgo.ResHistoryProcessInstance, value string) bool {
+			return strings.Contains(p.BusinessKey, strings.Trim(value, "%"))
+		},
+		"processDefinitionKeyNotIn": func(p *camundaclientgo.ResHistoryProcessInstance, value string) bool {
+			return !contains(strings.Split(value, ","), p.ProcessDefinitionKey)
+		},
+		"finished":             boolFilter(func(p *camundaclientgo.ResHistoryProcessInstance) bool { return p.EndTime != "" }),
+		"unfinished":           boolFilter(func(p *camundaclientgo.ResHistoryProcessInstance) bool { return p.EndTime == "" }),
+		"active":               boolFilter(state(StateActive)),
+		"suspended":            boolFilter(state(StateSuspended)),
+		"completed":            boolFilter(state(StateCompleted)),
+		"externallyTerminated": boolFilter(state(StateExternallyTerminated)),
+		"internallyTerminated": boolFilter(state(StateInternallyTerminated)),
+	})
+}
+
+func (s *Server) getHistoryProcessInstance(w http.ResponseWriter, _ *http.Request, p params) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	instance := s.findInstance(p["id"])
+	if instance == nil {
+		writeNotFound(w, "Historic process instance with id %s does not exist", p["id"])
+		return
+	}
+
+	writeJson(w, http.StatusOK, s.toResHistoryProcessInstance(instance))
+}
+
+func (s *Server) toResHistoryProcessInstance(p *processInstance) *camundaclientgo.ResHistoryProcessInstance {
+	res := &camundaclientgo.ResHistoryProcessInstance{
+		Id:                    p.Id,
+		RootProcessInstanceId: p.Id,
+		ProcessDefinitionKey:  p.ProcessDefinitionKey,
+		ProcessDefinitionId:   p.ProcessDefinitionId,
+		BusinessKey:           p.BusinessKey,
+		StartTime:             formatTime(p.StartTime),
+		EndTime:               formatTime(p.EndTime),
+		DeleteReason:          p.DeleteReason,
+		TenantId:              p.TenantId,
+		State:                 p.State,
+	}
+
+	if d := s.findDefinition(p.ProcessDefinitionId); d != nil {
+		res.ProcessDefinitionName = d.Name
+		res.ProcessDefinitionVersion = d.Version
+	}
+	if !p.EndTime.IsZero() {
+		res.DurationInMillis = float32(p.EndTime.Sub(p.StartTime).Milliseconds())
+	}
+
+	return res
+}
+
+func (s *Server) getHistoryTaskList(w http.ResponseWriter, r *http.Request, _ params) {
+	values, err := queryValues(r)
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	writeJson(w, http.StatusOK, pageList(s.historyTaskList(values), values))
+}
+
+func (s *Server) getHistoryTaskCount(w http.ResponseWriter, r *http.Request, _ params) {
+	values, err := queryValues(r)
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	writeCount(w, len(s.historyTaskList(values)))
+}
+
+func (s *Server) historyTaskList(values map[string]string) []*camundaclientgo.ResHistoryTaskInstance {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tasks := make([]*camundaclientgo.ResHistoryTaskInstance, 0, len(s.userTasks))
+	for _, t := range s.userTasks {
+		tasks = append(tasks, s.toResHistoryTaskInstance(t))
+	}
+
+	instance := func(t *camundaclientgo.ResHistoryTaskInstance) *processInstance {
+		return s.findInstance(t.ProcessInstanceId)
+	}
+	field := func(get func(t *camundaclientgo.ResHistoryTaskInstance) string) func(t *camundaclientgo.ResHistoryTaskInstance, value string) bool {
+		return func(t *camundaclientgo.ResHistoryTaskInstance, value string) bool {
+			return get(t) == value
+		}
+	}
+
+	return filterList(tasks, values, filters[*camundaclientgo.ResHistoryTaskInstance]{
+		"taskId":           field(func(t *camundaclientgo.ResHistoryTaskInstance) string { return t.Id }),
+		"taskName":         field(func(t *camundaclientgo.ResHistoryTaskInstance) string { return t.Name }),
+		"taskDescription":  field(func(t *camundaclientgo.ResHistoryTaskInstance) string { return t.Description }),
+		"taskAssignee":     field(func(t *camundaclientgo.ResHistoryTaskInstance) string { return t.Assignee }),
+		"taskOwner":        field(func(t *camundaclientgo.ResHistoryTaskInstance) string { return t.Owner }),
+		"taskDeleteReason": field(func(t *camundaclientgo.ResHistoryTaskInstance) string { return t.DeleteReason }),
+		"taskParentTaskId": field(func(t *camundaclientgo.ResHistoryTaskInstance) string { return t.ParentTaskId }),
+		"taskNameLike": func(t *camundaclientgo.ResHistoryTaskInstance, value string) bool {
+			return strings.Contains(t.Name, strings.Trim(value, "%"))
+		},
+		"processInstanceBusinessKey": func(t *camundaclientgo.ResHistoryTaskInstance, value string) bool {
+			return instance(t).BusinessKey == value
+		},
+		"processInstanceBusinessKeyIn": func(t *camundaclientgo.ResHistoryTaskInstance, value string) bool {
+			return contains(strings.Split(value, ","), instance(t).BusinessKey)
+		},
+		"finished":          boolFilter(func(t *camundaclientgo.ResHistoryTaskInstance) bool { return t.EndTime != "" }),
+		"unfinished":        boolFilter(func(t *camundaclientgo.ResHistoryTaskInstance) bool { return t.EndTime == "" }),
+		"assigned":          boolFilter(func(t *camundaclientgo.ResHistoryTaskInstance) bool { return t.Assignee != "" }),
+		"unassigned":        boolFilter(func(t *camundaclientgo.ResHistoryTaskInstance) bool { return t.Assignee == "" }),
+		"processFinished":   boolFilter(func(t *camundaclientgo.ResHistoryTaskInstance) bool { return instance(t).Ended() }),
+		"processUnfinished": boolFilter(func(t *camundaclientgo.ResHistoryTaskInstance) bool { return !instance(t).Ended() }),
+	})
+}
+
+func (s *Server) toResHistoryTaskInstance(t *UserTask) *camundaclientgo.ResHistoryTaskInstance {
+	res := &camundaclientgo.ResHistoryTaskInstance{
+		Id:                    t.Id,
+		ProcessDefinitionKey:  t.ProcessDefinitionKey,
+		ProcessDefinitionId:   t.ProcessDefinitionId,
+		ProcessInstanceId:     t.ProcessInstanceId,
+		ExecutionId:           t.ExecutionId,
+		Name:                  t.Name,
+		Description:           t.Description,
+		Owner:                 t.Owner,
+		Assignee:              t.Assignee,
+		StartTime:             formatTime(t.Created),
+		EndTime:               formatTime(t.EndTime),
+		TaskDefinitionKey:     t.TaskDefinitionKey,
+		Priority:              int64(t.Priority),
+		Created:               formatTime(t.Created),
+		TenantId:              t.TenantId,
+		RootProcessInstanceId: t.ProcessInstanceId,
+	}
+
+	switch t.State {
+	case TaskStateCompleted:
+		res.DeleteReason = "completed"
+	case TaskStateDeleted:
+		res.DeleteReason = "deleted"
+	}
+	if !t.EndTime.IsZero() {
+		res.Duration = t.EndTime.Sub(t.Created).Milliseconds()
+	}
+
+	return res
+}
+
+func (s *Server) getHistoryVariableInstanceList(w http.ResponseWriter, r *http.Request, _ params) {
+	values, err := queryValues(r)
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	writeJson(w, http.StatusOK, pageList(s.historyVariableInstanceList(values), values))
+}
+
+func (s *Server) getHistoryVariableInstanceCount(w http.ResponseWriter, r *http.Request, _ params) {
+	values, err := queryValues(r)
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	writeCount(w, len(s.historyVariableInstanceList(values)))
+}
+
+func (s *Server) historyVariableInstanceList(values map[string]string) []*camundaclientgo.ResHistoryVariableInstance {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var variables []*camundaclientgo.ResHistoryVariableInstance
+	for _, p := range s.instances {
+		for _, name := range sortedNames(p.Variables) {
+			variables = append(variables, toResHistoryVariableInstance(p, name, p.Variables[name]))
+		}
+	}
+
+	return filterList(variables, values, filters[*camundaclientgo.ResHistoryVariableInstance]{
+		"variableName": func(v *camundaclientgo.ResHistoryVariableInstance, value string) bool {
+			return v.Name == value
+		},
+		"variableNameLike": func(v *camundaclientgo.ResHistoryVariableInstance, value string) bool {
+			return strings.Contains(v.Name, strings.Trim(value, "%"))
+		},
+		"variableValue": func(v *camundaclientgo.ResHistoryVariableInstance, value string) bool {
+			return fmt.Sprint(v.Value) == value
+		},
+		"processInstanceIdIn": func(v *camundaclientgo.ResHistoryVariableInstance, value string) bool {
+			return contains(strings.Split(value, ","), v.ProcessInstanceId)
+		},
+	})
+}
+
+func (s *Server) getHistoryVariableInstance(w http.ResponseWriter, _ *http.Request, p params) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	instance, name, v, ok := s.historyVariable(p["id"])
+	if !ok {
+		writeNotFound(w, "Historic variable instance with Id '%s' does not exist.", p["id"])
+		return
+	}
+
+	writeJson(w, http.StatusOK, toResHistoryVariableInstance(instance, name, v))
+}
+
+func (s *Server) getHistoryVariableInstanceData(w http.ResponseWriter, _ *http.Request, p params) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, _, v, ok := s.historyVariable(p["id"])
+	if !ok {
+		writeNotFound(w, "Historic variable instance with Id '%s' does not exist.", p["id"])
+		return
+	}
+
+	writeVariableData(w, v)
+}
+
+// historyVariable returns a variable by the id of a historic variable instance, which consists of the id
+// of the process instance and the variable name, s.mu must be held
+func (s *Server) historyVariable(id string) (*processInstance, string, camundaclientgo.Variable, bool) {
+	instanceId, name, ok := strings.Cut(id, ":")
+	if !ok {
+		return nil, "", camundaclientgo.Variable{}, false
+	}
+
+	instance := s.findInstance(instanceId)
+	if instance == nil {
+		return nil, "", camundaclientgo.Variable{}, false
+	}
+
+	v, ok := instance.Variables[name]
+	return instance, name, v, ok
+}
+
+func toResHistoryVariableInstance(p *processInstance, name string, v camundaclientgo.Variable) *camundaclientgo.ResHistoryVariableInstance {
+	res := &camundaclientgo.ResHistoryVariableInstance{
+		Id:                   p.Id + ":" + name,
+		Name:                 name,
+		Type:                 v.Type,
+		Value:                v.Value,
+		ProcessDefinitionKey: p.ProcessDefinitionKey,
+		ProcessDefinitionId:  p.ProcessDefinitionId,
+		ProcessInstanceId:    p.Id,
+		ExecutionId:          p.Id,
+		ActivityInstanceId:   p.Id,
+		TenantId:             p.TenantId,
+	}
+	if v.ValueInfo.ObjectTypeName != nil {
+		res.ValueInfo.ObjectTypeName = *v.ValueInfo.ObjectTypeName
+	}
+	if v.ValueInfo.SerializationDataFormat != nil {
+		res.ValueInfo.SerializationDataFormat = *v.ValueInfo.SerializationDataFormat
+	}
+
+	return res
+}
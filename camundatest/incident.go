@@ -0,0 +1,104 @@
+package camundatest
+
+import (
+	"net/http"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+)
+
+func (s *Server) registerIncidentRoutes() {
+	s.handle(http.MethodGet, "/incident", s.getIncidentList)
+	s.handle(http.MethodGet, "/incident/count", s.getIncidentCount)
+	s.handle(http.MethodGet, "/incident/{id}", s.getIncident)
+	s.handle(http.MethodDelete, "/incident/{id}", s.resolveIncident)
+}
+
+func (s *Server) getIncidentList(w http.ResponseWriter, r *http.Request, _ params) {
+	values, err := queryValues(r)
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	writeJson(w, http.StatusOK, pageList(s.incidentList(values), values))
+}
+
+func (s *Server) getIncidentCount(w http.ResponseWriter, r *http.Request, _ params) {
+	values, err := queryValues(r)
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	writeCount(w, len(s.incidentList(values)))
+}
+
+func (s *Server) incidentList(values map[string]string) []*camundaclientgo.ResIncident {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	incidents := make([]*camundaclientgo.ResIncident, 0, len(s.incidents))
+	for _, i := range s.incidents {
+		incidents = append(incidents, s.toResIncident(i))
+	}
+
+	return filterList(incidents, values, filters[*camundaclientgo.ResIncident]{
+		"processDefinitionKeyIn": func(i *camundaclientgo.ResIncident, value string) bool {
+			d := s.findDefinition(i.ProcessDefinitionId)
+			return d != nil && contains(splitList(value), d.Key)
+		},
+	})
+}
+
+func (s *Server) getIncident(w http.ResponseWriter, _ *http.Request, p params) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, i := range s.incidents {
+		if i.Id == p["id"] {
+			writeJson(w, http.StatusOK, s.toResIncident(i))
+			return
+		}
+	}
+
+	writeNotFound(w, "No matching incident with id %s", p["id"])
+}
+
+// resolveIncident removes a custom incident, incidents of failed jobs and external tasks are resolved by retries
+func (s *Server) resolveIncident(w http.ResponseWriter, _ *http.Request, p params) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for n, i := range s.incidents {
+		if i.Id != p["id"] {
+			continue
+		}
+
+		if i.Type == IncidentTypeFailedJob || i.Type == IncidentTypeFailedExternalTask {
+			writeBadRequest(w, "Cannot resolve an incident of type %s", i.Type)
+			return
+		}
+
+		s.incidents = append(s.incidents[:n], s.incidents[n+1:]...)
+		s.notify()
+		writeNoContent(w)
+		return
+	}
+
+	writeNotFound(w, "Cannot find an incident with id '%s'", p["id"])
+}
+
+func (s *Server) toResIncident(i *Incident) *camundaclientgo.ResIncident {
+	return &camundaclientgo.ResIncident{
+		Id:                  i.Id,
+		ProcessDefinitionId: i.ProcessDefinitionId,
+		ProcessInstanceId:   i.ProcessInstanceId,
+		IncidentTimestamp:   camundaclientgo.Time{Time: i.Time},
+		IncidentType:        i.Type,
+		ActivityId:          i.ActivityId,
+		FailedActivityId:    i.ActivityId,
+		RootCauseIncidentId: i.Id,
+		Configuration:       i.Configuration,
+		IncidentMessage:     i.Message,
+	}
+}
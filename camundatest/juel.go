@@ -0,0 +1,551 @@
+package camundatest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// evaluateExpression evaluates a JUEL expression like `${amount > 100 && approved}` with variables.
+// A subset of JUEL is supported: literals, variables with property access to JSON values, parentheses,
+// logical, relational, equality, arithmetic and empty operators, in symbolic and keyword forms
+func evaluateExpression(expression string, variables map[string]interface{}) (interface{}, error) {
+	expression = strings.TrimSpace(expression)
+	if !(strings.HasPrefix(expression, "${") || strings.HasPrefix(expression, "#{")) || !strings.HasSuffix(expression, "}") {
+		return nil, fmt.Errorf("expression %q must be enclosed in ${}", expression)
+	}
+
+	tokens, err := tokenizeExpression(expression[2 : len(expression)-1])
+	if err != nil {
+		return nil, fmt.Errorf("expression %q: %w", expression, err)
+	}
+
+	p := &expressionParser{tokens: tokens, variables: variables}
+	value, err := p.parseOr()
+	if err == nil && p.pos < len(p.tokens) {
+		err = fmt.Errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("expression %q: %w", expression, err)
+	}
+
+	return value, nil
+}
+
+// evaluateCondition evaluates a JUEL expression to a boolean
+func evaluateCondition(expression string, variables map[string]interface{}) (bool, error) {
+	value, err := evaluateExpression(expression, variables)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := toBool(value)
+	if err != nil {
+		return false, fmt.Errorf("expression %q: %w", expression, err)
+	}
+
+	return result, nil
+}
+
+const (
+	tokenOperator = iota
+	tokenIdentifier
+	tokenNumber
+	tokenString
+)
+
+type expressionToken struct {
+	kind int
+	text string
+}
+
+// keywordOperators operators in keyword form by their symbolic form
+var keywordOperators = map[string]string{
+	"and": "&&",
+	"or":  "||",
+	"not": "!",
+	"eq":  "==",
+	"ne":  "!=",
+	"lt":  "<",
+	"gt":  ">",
+	"le":  "<=",
+	"ge":  ">=",
+	"div": "/",
+	"mod": "%",
+}
+
+func tokenizeExpression(s string) ([]expressionToken, error) {
+	var tokens []expressionToken
+	for i := 0; i < len(s); {
+		c := rune(s[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '\'' || c == '"':
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(s) && rune(s[j]) != c; j++ {
+				if s[j] == '\\' && j+1 < len(s) {
+					j++
+				}
+				sb.WriteByte(s[j])
+			}
+			if j >= len(s) {
+				return nil, fmt.Errorf("unterminated string at %d", i)
+			}
+			tokens = append(tokens, expressionToken{kind: tokenString, text: sb.String()})
+			i = j + 1
+		case unicode.IsDigit(c):
+			j := i
+			for j < len(s) && (unicode.IsDigit(rune(s[j])) || s[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, expressionToken{kind: tokenNumber, text: s[i:j]})
+			i = j
+		case unicode.IsLetter(c) || c == '_' || c == '$':
+			j := i
+			for j < len(s) && (unicode.IsLetter(rune(s[j])) || unicode.IsDigit(rune(s[j])) || s[j] == '_' || s[j] == '$') {
+				j++
+			}
+			word := s[i:j]
+			if operator, ok := keywordOperators[word]; ok {
+				tokens = append(tokens, expressionToken{kind: tokenOperator, text: operator})
+			} else if word == "empty" {
+				tokens = append(tokens, expressionToken{kind: tokenOperator, text: word})
+			} else {
+				tokens = append(tokens, expressionToken{kind: tokenIdentifier, text: word})
+			}
+			i = j
+		default:
+			operator := ""
+			for _, op := range []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "(", ")", "+", "-", "*", "/", "%", "."} {
+				if strings.HasPrefix(s[i:], op) {
+					operator = op
+					break
+				}
+			}
+			if operator == "" {
+				return nil, fmt.Errorf("unexpected character %q at %d", c, i)
+			}
+			tokens = append(tokens, expressionToken{kind: tokenOperator, text: operator})
+			i += len(operator)
+		}
+	}
+
+	return tokens, nil
+}
+
+type expressionParser struct {
+	tokens    []expressionToken
+	pos       int
+	variables map[string]interface{}
+	// skip is positive while the right operand of a short-circuited && or || is parsed,
+	// unresolved identifiers are not errors then
+	skip int
+}
+
+// accept consumes the next token if it is one of operators
+func (p *expressionParser) accept(operators ...string) (string, bool) {
+	if p.pos < len(p.tokens) && p.tokens[p.pos].kind == tokenOperator && contains(operators, p.tokens[p.pos].text) {
+		p.pos++
+		return p.tokens[p.pos-1].text, true
+	}
+
+	return "", false
+}
+
+func (p *expressionParser) parseOr() (interface{}, error) {
+	left, err := p.parseAnd()
+	for err == nil {
+		if _, ok := p.accept("||"); !ok {
+			break
+		}
+
+		short, _ := toBool(left)
+		var right interface{}
+		if right, err = p.parseShortCircuit(short, p.parseAnd); err == nil {
+			left, err = logical(left, right, false)
+		}
+	}
+
+	return left, err
+}
+
+func (p *expressionParser) parseAnd() (interface{}, error) {
+	left, err := p.parseEquality()
+	for err == nil {
+		if _, ok := p.accept("&&"); !ok {
+			break
+		}
+
+		left, _ = toBool(left)
+		short := left == false
+		var right interface{}
+		if right, err = p.parseShortCircuit(short, p.parseEquality); err == nil {
+			left, err = logical(left, right, true)
+		}
+	}
+
+	return left, err
+}
+
+// parseShortCircuit parses the right operand of a logical operator, which is not evaluated if short is true
+func (p *expressionParser) parseShortCircuit(short bool, parse func() (interface{}, error)) (interface{}, error) {
+	if !short {
+		return parse()
+	}
+
+	p.skip++
+	defer func() { p.skip-- }()
+	_, err := parse()
+	return false, err
+}
+
+func (p *expressionParser) parseEquality() (interface{}, error) {
+	left, err := p.parseRelational()
+	for err == nil {
+		operator, ok := p.accept("==", "!=")
+		if !ok {
+			break
+		}
+
+		var right interface{}
+		if right, err = p.parseRelational(); err == nil {
+			equal := equals(left, right)
+			left = equal == (operator == "==")
+		}
+	}
+
+	return left, err
+}
+
+func (p *expressionParser) parseRelational() (interface{}, error) {
+	left, err := p.parseAdditive()
+	for err == nil {
+		operator, ok := p.accept("<", ">", "<=", ">=")
+		if !ok {
+			break
+		}
+
+		var right interface{}
+		if right, err = p.parseAdditive(); err == nil {
+			left, err = compare(operator, left, right)
+		}
+	}
+
+	return left, err
+}
+
+func (p *expressionParser) parseAdditive() (interface{}, error) {
+	left, err := p.parseMultiplicative()
+	for err == nil {
+		operator, ok := p.accept("+", "-")
+		if !ok {
+			break
+		}
+
+		var right interface{}
+		if right, err = p.parseMultiplicative(); err == nil {
+			left, err = arithmetic(operator, left, right)
+		}
+	}
+
+	return left, err
+}
+
+func (p *expressionParser) parseMultiplicative() (interface{}, error) {
+	left, err := p.parseUnary()
+	for err == nil {
+		operator, ok := p.accept("*", "/", "%")
+		if !ok {
+			break
+		}
+
+		var right interface{}
+		if right, err = p.parseUnary(); err == nil {
+			left, err = arithmetic(operator, left, right)
+		}
+	}
+
+	return left, err
+}
+
+func (p *expressionParser) parseUnary() (interface{}, error) {
+	operator, ok := p.accept("!", "-", "empty")
+	if !ok {
+		return p.parsePrimary()
+	}
+
+	value, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	switch operator {
+	case "!":
+		b, err := toBool(value)
+		return !b, err
+	case "-":
+		return arithmetic("-", 0.0, value)
+	}
+
+	return isEmpty(value), nil
+}
+
+func (p *expressionParser) parsePrimary() (interface{}, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("unexpected end")
+	}
+
+	token := p.tokens[p.pos]
+	p.pos++
+	switch token.kind {
+	case tokenNumber:
+		return strconv.ParseFloat(token.text, 64)
+	case tokenString:
+		return token.text, nil
+	case tokenIdentifier:
+		return p.parseIdentifier(token.text)
+	}
+
+	if token.text == "(" {
+		value, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := p.accept(")"); !ok {
+			return nil, fmt.Errorf("missing )")
+		}
+		return value, nil
+	}
+
+	return nil, fmt.Errorf("unexpected %q", token.text)
+}
+
+func (p *expressionParser) parseIdentifier(name string) (interface{}, error) {
+	var value interface{}
+	switch name {
+	case "true":
+		value = true
+	case "false":
+		value = false
+	case "null":
+		value = nil
+	default:
+		v, ok := p.variables[name]
+		if !ok && p.skip == 0 {
+			return nil, fmt.Errorf("cannot resolve identifier '%s'", name)
+		}
+		value = normalize(v)
+	}
+
+	for {
+		if _, ok := p.accept("."); !ok {
+			return value, nil
+		}
+		if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != tokenIdentifier {
+			return nil, fmt.Errorf("property name expected after %s", name)
+		}
+
+		property := p.tokens[p.pos].text
+		p.pos++
+		if p.pos < len(p.tokens) && p.tokens[p.pos].text == "(" {
+			return nil, fmt.Errorf("method calls are not supported: %s", property)
+		}
+
+		object, ok := value.(map[string]interface{})
+		if !ok && p.skip == 0 {
+			return nil, fmt.Errorf("cannot read property '%s' of %v", property, value)
+		}
+		value = normalize(object[property])
+		name = property
+	}
+}
+
+// normalize converts numbers to float64 and JSON objects in strings to maps
+func normalize(v interface{}) interface{} {
+	switch value := v.(type) {
+	case nil, bool, float64, map[string]interface{}:
+		return v
+	case string:
+		if strings.HasPrefix(strings.TrimSpace(value), "{") {
+			object := map[string]interface{}{}
+			if json.Unmarshal([]byte(value), &object) == nil {
+				return object
+			}
+		}
+		return v
+	case json.Number:
+		f, _ := value.Float64()
+		return f
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint())
+	case reflect.Float32:
+		return rv.Float()
+	}
+
+	return v
+}
+
+func toBool(v interface{}) (bool, error) {
+	switch value := v.(type) {
+	case bool:
+		return value, nil
+	case nil:
+		return false, nil
+	case string:
+		if value == "" {
+			return false, nil
+		}
+		return strconv.ParseBool(value)
+	}
+
+	return false, fmt.Errorf("cannot coerce %v to boolean", v)
+}
+
+func toNumber(v interface{}) (float64, error) {
+	switch value := v.(type) {
+	case float64:
+		return value, nil
+	case nil:
+		return 0, nil
+	case string:
+		if value == "" {
+			return 0, nil
+		}
+		return strconv.ParseFloat(value, 64)
+	}
+
+	return 0, fmt.Errorf("cannot coerce %v to number", v)
+}
+
+func logical(left, right interface{}, and bool) (interface{}, error) {
+	l, err := toBool(left)
+	if err != nil {
+		return nil, err
+	}
+	r, err := toBool(right)
+	if err != nil {
+		return nil, err
+	}
+
+	if and {
+		return l && r, nil
+	}
+
+	return l || r, nil
+}
+
+func equals(left, right interface{}) bool {
+	if left == nil || right == nil {
+		return left == right
+	}
+
+	if _, ok := left.(bool); ok {
+		r, err := toBool(right)
+		return err == nil && r == left
+	}
+	if _, ok := right.(bool); ok {
+		l, err := toBool(left)
+		return err == nil && l == right
+	}
+
+	_, leftNumber := left.(float64)
+	_, rightNumber := right.(float64)
+	if leftNumber || rightNumber {
+		l, errLeft := toNumber(left)
+		r, errRight := toNumber(right)
+		return errLeft == nil && errRight == nil && l == r
+	}
+
+	return fmt.Sprint(left) == fmt.Sprint(right)
+}
+
+func compare(operator string, left, right interface{}) (bool, error) {
+	ls, leftString := left.(string)
+	rs, rightString := right.(string)
+	if leftString && rightString {
+		switch operator {
+		case "<":
+			return ls < rs, nil
+		case ">":
+			return ls > rs, nil
+		case "<=":
+			return ls <= rs, nil
+		}
+		return ls >= rs, nil
+	}
+
+	l, err := toNumber(left)
+	if err != nil {
+		return false, err
+	}
+	r, err := toNumber(right)
+	if err != nil {
+		return false, err
+	}
+
+	switch operator {
+	case "<":
+		return l < r, nil
+	case ">":
+		return l > r, nil
+	case "<=":
+		return l <= r, nil
+	}
+	return l >= r, nil
+}
+
+func arithmetic(operator string, left, right interface{}) (interface{}, error) {
+	l, err := toNumber(left)
+	if err != nil {
+		return nil, err
+	}
+	r, err := toNumber(right)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operator {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "/":
+		if r == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		return l / r, nil
+	}
+
+	if r == 0 {
+		return nil, fmt.Errorf("division by zero")
+	}
+	return float64(int64(l) % int64(r)), nil
+}
+
+func isEmpty(v interface{}) bool {
+	switch value := v.(type) {
+	case nil:
+		return true
+	case string:
+		return value == ""
+	case map[string]interface{}:
+		return len(value) == 0
+	case []interface{}:
+		return len(value) == 0
+	}
+
+	return false
+}
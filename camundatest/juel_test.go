@@ -0,0 +1,70 @@
+package camundatest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateCondition(t *testing.T) {
+	variables := map[string]interface{}{
+		"isWorld":  true,
+		"amount":   150,
+		"rate":     0.5,
+		"name":     "John",
+		"blank":    "",
+		"nothing":  nil,
+		"customer": `{"vip": true, "address": {"city": "Berlin"}}`,
+	}
+
+	tests := []struct {
+		expression string
+		expected   bool
+	}{
+		{"${isWorld}", true},
+		{"${!isWorld}", false},
+		{"${not isWorld}", false},
+		{"${amount > 100}", true},
+		{"${amount gt 100 and amount lt 200}", true},
+		{"${amount >= 150 && rate < 1}", true},
+		{"${amount * rate == 75}", true},
+		{"${amount / 2 eq 75}", true},
+		{"${amount % 7 == 3}", true},
+		{"${-amount < 0}", true},
+		{"${(amount + 50) / 100 == 2}", true},
+		{"${name == 'John'}", true},
+		{`${name != "Jane"}`, true},
+		{"${name < 'Kate'}", true},
+		{"${empty blank}", true},
+		{"${empty name}", false},
+		{"${nothing == null}", true},
+		{"${customer.vip}", true},
+		{"${customer.address.city == 'Berlin'}", true},
+		{"#{isWorld || unknown}", true},
+		{"${!isWorld && unknown.property}", false},
+	}
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			actual, err := evaluateCondition(test.expression, variables)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestEvaluateCondition_Errors(t *testing.T) {
+	for _, expression := range []string{
+		"isWorld",
+		"${unknown}",
+		"${amount >}",
+		"${amount.value}",
+		"${name.length()}",
+		"${'unterminated}",
+	} {
+		t.Run(expression, func(t *testing.T) {
+			_, err := evaluateCondition(expression, map[string]interface{}{"amount": 1, "name": "John"})
+			assert.Error(t, err)
+		})
+	}
+}
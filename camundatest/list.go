@@ -0,0 +1,267 @@
+package camundatest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// filters of a list query by parameter name. Parameters without a filter are matched against the field
+// of the same name of the JSON representation of an item, a parameter with the In suffix against one of
+// comma separated values and a parameter with the Like suffix against a substring. Other parameters are ignored
+type filters[T any] map[string]func(item T, value string) bool
+
+// sortAliases names of fields of JSON representations by values of sortBy which differ from them
+var sortAliases = map[string]string{
+	"instanceId":          "id",
+	"taskId":              "id",
+	"definitionKey":       "processDefinitionKey",
+	"definitionId":        "processDefinitionId",
+	"nameCaseInsensitive": "name",
+	"taskPriority":        "priority",
+	"version":             "Version",
+}
+
+// readBody reads the body of the request and replaces it, so it can be read again
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, err
+}
+
+// queryValues returns parameters of a list query: the URL query merged with scalars and arrays of scalars
+// of a JSON body. Empty strings are omitted and the sorting of a body is returned as sortBy and sortOrder
+func queryValues(r *http.Request) (map[string]string, error) {
+	values := map[string]string{}
+	for name, v := range r.URL.Query() {
+		values[name] = strings.Join(v, ",")
+	}
+
+	body, err := readBody(r)
+	if err != nil || len(bytes.TrimSpace(body)) == 0 || r.Method == http.MethodGet {
+		return values, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	fields := map[string]interface{}{}
+	if err = decoder.Decode(&fields); err != nil {
+		return nil, fmt.Errorf("invalid request body: %w", err)
+	}
+
+	addValues(values, fields)
+	return values, nil
+}
+
+// addValues adds scalars and arrays of scalars of a JSON object to parameters of a list query
+func addValues(values map[string]string, fields map[string]interface{}) {
+	for name, v := range fields {
+		if name == "sorting" {
+			addSorting(values, v)
+			continue
+		}
+
+		if s, ok := scalar(v); ok {
+			if s != "" {
+				values[name] = s
+			}
+			continue
+		}
+
+		if list, ok := v.([]interface{}); ok && len(list) > 0 {
+			items := make([]string, 0, len(list))
+			for _, item := range list {
+				if s, ok := scalar(item); ok {
+					items = append(items, s)
+				}
+			}
+			if len(items) == len(list) {
+				values[name] = strings.Join(items, ",")
+			}
+		}
+	}
+}
+
+func addSorting(values map[string]string, sorting interface{}) {
+	if list, ok := sorting.([]interface{}); ok {
+		if len(list) == 0 {
+			return
+		}
+		sorting = list[0]
+	}
+
+	if s, ok := sorting.(map[string]interface{}); ok {
+		values["sortBy"], _ = scalar(s["sortBy"])
+		values["sortOrder"], _ = scalar(s["sortOrder"])
+	}
+}
+
+func scalar(v interface{}) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case bool:
+		return strconv.FormatBool(v), true
+	case json.Number:
+		return v.String(), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	}
+
+	return "", false
+}
+
+// filterList returns items matching parameters of a list query sorted by the sortBy parameter
+func filterList[T any](items []T, values map[string]string, f filters[T]) []T {
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		if matches(item, values, f) {
+			result = append(result, item)
+		}
+	}
+
+	if sortBy := values["sortBy"]; sortBy != "" {
+		if alias, ok := sortAliases[sortBy]; ok {
+			sortBy = alias
+		}
+
+		desc := values["sortOrder"] == "desc"
+		keys := make([]interface{}, len(result))
+		for i, item := range result {
+			keys[i] = fields(item)[sortBy]
+		}
+
+		sort.Stable(byKeys[T]{items: result, keys: keys, desc: desc})
+	}
+
+	return result
+}
+
+// pageList returns a page of items by the firstResult and maxResults parameters
+func pageList[T any](items []T, values map[string]string) []T {
+	if first, err := strconv.Atoi(values["firstResult"]); err == nil && first > 0 {
+		if first > len(items) {
+			first = len(items)
+		}
+		items = items[first:]
+	}
+
+	if max, err := strconv.Atoi(values["maxResults"]); err == nil && max >= 0 && max < len(items) {
+		items = items[:max]
+	}
+
+	return items
+}
+
+func matches[T any](item T, values map[string]string, f filters[T]) bool {
+	var itemFields map[string]interface{}
+	for name, value := range values {
+		if filter, ok := f[name]; ok {
+			if !filter(item, value) {
+				return false
+			}
+			continue
+		}
+
+		if itemFields == nil {
+			itemFields = fields(item)
+		}
+
+		if field, ok := scalar(itemFields[name]); ok {
+			if field != value {
+				return false
+			}
+			continue
+		}
+
+		if strings.HasSuffix(name, "In") {
+			if field, ok := scalar(itemFields[strings.TrimSuffix(name, "In")]); ok {
+				if !contains(strings.Split(value, ","), field) {
+					return false
+				}
+			}
+			continue
+		}
+
+		if strings.HasSuffix(name, "Like") {
+			if field, ok := scalar(itemFields[strings.TrimSuffix(name, "Like")]); ok {
+				if !strings.Contains(field, strings.Trim(value, "%")) {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// fields returns fields of the JSON representation of v
+func fields(v interface{}) map[string]interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	result := map[string]interface{}{}
+	_ = decoder.Decode(&result)
+	return result
+}
+
+type byKeys[T any] struct {
+	items []T
+	keys  []interface{}
+	desc  bool
+}
+
+func (b byKeys[T]) Len() int {
+	return len(b.items)
+}
+
+func (b byKeys[T]) Less(i, j int) bool {
+	if b.desc {
+		i, j = j, i
+	}
+
+	if x, ok := b.keys[i].(json.Number); ok {
+		if y, ok := b.keys[j].(json.Number); ok {
+			xf, _ := x.Float64()
+			yf, _ := y.Float64()
+			return xf < yf
+		}
+	}
+
+	x, _ := scalar(b.keys[i])
+	y, _ := scalar(b.keys[j])
+	// generated ids are numbers
+	if xi, err := strconv.Atoi(x); err == nil {
+		if yi, err := strconv.Atoi(y); err == nil {
+			return xi < yi
+		}
+	}
+
+	return x < y
+}
+
+func (b byKeys[T]) Swap(i, j int) {
+	b.items[i], b.items[j] = b.items[j], b.items[i]
+	b.keys[i], b.keys[j] = b.keys[j], b.keys[i]
+}
+
+// boolFilter returns a filter matching items for which f returns true when the parameter is true
+func boolFilter[T any](f func(item T) bool) func(item T, value string) bool {
+	return func(item T, value string) bool {
+		return value != "true" || f(item)
+	}
+}
@@ -0,0 +1,49 @@
+package camundatest
+
+import (
+	"net/http"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+)
+
+func (s *Server) registerMessageRoutes() {
+	s.handle(http.MethodPost, "/message", s.correlateMessage)
+}
+
+func (s *Server) correlateMessage(w http.ResponseWriter, r *http.Request, _ params) {
+	req := camundaclientgo.ReqMessage{}
+	if err := decodeBody(r, &req); err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+	if req.MessageName == "" {
+		writeBadRequest(w, "No message name supplied")
+		return
+	}
+
+	var variables map[string]camundaclientgo.Variable
+	if req.ProcessVariables != nil {
+		variables = *req.ProcessVariables
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	message := &Message{
+		Name:        req.MessageName,
+		BusinessKey: req.BusinessKey,
+		Variables:   copyVariables(variables),
+		Time:        s.now(),
+	}
+	instance := s.correlate(req.MessageName, req.BusinessKey, variables)
+	if instance == nil {
+		writeBadRequest(w, "org.camunda.bpm.engine.MismatchingMessageCorrelationException: Cannot correlate message '%s': No process definition or execution matches the parameters", req.MessageName)
+		return
+	}
+
+	message.ProcessInstanceId = instance.Id
+	s.messages = append(s.messages, message)
+
+	s.notify()
+	writeNoContent(w)
+}
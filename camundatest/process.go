@@ -0,0 +1,712 @@
+package camundatest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+)
+
+func (s *Server) registerProcessRoutes() {
+	s.handle(http.MethodGet, "/process-definition", s.getProcessDefinitionList)
+	s.handle(http.MethodGet, "/process-definition/count", s.getProcessDefinitionCount)
+	s.handle(http.MethodPut, "/process-definition/suspended", s.suspendProcessDefinitionByKey)
+	for _, by := range []string{"/process-definition/key/{key}/tenant-id/{tenantId}", "/process-definition/key/{key}", "/process-definition/{id}"} {
+		s.handle(http.MethodGet, by, s.getProcessDefinition)
+		s.handle(http.MethodGet, by+"/xml", s.getProcessDefinitionXml)
+		s.handle(http.MethodPost, by+"/start", s.startProcessInstance)
+		s.handle(http.MethodPut, by+"/suspended", s.suspendProcessDefinition)
+	}
+
+	s.handle(http.MethodGet, "/process-instance", s.getProcessInstanceList)
+	s.handle(http.MethodPost, "/process-instance", s.getProcessInstanceList)
+	s.handle(http.MethodGet, "/process-instance/count", s.getProcessInstanceCount)
+	s.handle(http.MethodPost, "/process-instance/count", s.getProcessInstanceCount)
+	s.handle(http.MethodGet, "/process-instance/{id}", s.getProcessInstance)
+	s.handle(http.MethodDelete, "/process-instance/{id}", s.deleteProcessInstance)
+	s.handle(http.MethodPost, "/process-instance/delete", s.deleteProcessInstancesAsync)
+	s.handle(http.MethodPut, "/process-instance/{id}/suspended", s.suspendProcessInstance)
+	s.handle(http.MethodGet, "/process-instance/{id}/variables", s.getProcessVariableList)
+	s.handle(http.MethodPost, "/process-instance/{id}/variables", s.modifyProcessVariables)
+	s.handle(http.MethodGet, "/process-instance/{id}/variables/{varName}", s.getProcessVariable)
+	s.handle(http.MethodPut, "/process-instance/{id}/variables/{varName}", s.putProcessVariable)
+	s.handle(http.MethodDelete, "/process-instance/{id}/variables/{varName}", s.deleteProcessVariable)
+	s.handle(http.MethodGet, "/process-instance/{id}/variables/{varName}/data", s.getProcessVariableData)
+	s.handle(http.MethodPost, "/process-instance/{id}/variables/{varName}/data", s.postProcessVariableData)
+}
+
+func (s *Server) getProcessDefinitionList(w http.ResponseWriter, r *http.Request, _ params) {
+	values, err := queryValues(r)
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	writeJson(w, http.StatusOK, pageList(s.processDefinitionList(values), values))
+}
+
+func (s *Server) getProcessDefinitionCount(w http.ResponseWriter, r *http.Request, _ params) {
+	values, err := queryValues(r)
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	writeCount(w, len(s.processDefinitionList(values)))
+}
+
+func (s *Server) processDefinitionList(values map[string]string) []*camundaclientgo.ResProcessDefinition {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	definitions := make([]*camundaclientgo.ResProcessDefinition, 0, len(s.definitions))
+	for _, d := range s.definitions {
+		definitions = append(definitions, toResProcessDefinition(d))
+	}
+
+	return filterList(definitions, values, filters[*camundaclientgo.ResProcessDefinition]{
+		"processDefinitionId": func(d *camundaclientgo.ResProcessDefinition, value string) bool {
+			return d.Id == value
+		},
+		"processDefinitionIdIn": func(d *camundaclientgo.ResProcessDefinition, value string) bool {
+			return contains(strings.Split(value, ","), d.Id)
+		},
+		"keysIn": func(d *camundaclientgo.ResProcessDefinition, value string) bool {
+			return contains(strings.Split(value, ","), d.Key)
+		},
+		"version": func(d *camundaclientgo.ResProcessDefinition, value string) bool {
+			return strconv.Itoa(d.Version) == value
+		},
+		"latestVersion": boolFilter(func(d *camundaclientgo.ResProcessDefinition) bool {
+			latest := s.latestDefinition(d.Key, d.TenantId)
+			return latest != nil && latest.Id == d.Id
+		}),
+		"active": boolFilter(func(d *camundaclientgo.ResProcessDefinition) bool {
+			return !d.Suspended
+		}),
+		"suspended": boolFilter(func(d *camundaclientgo.ResProcessDefinition) bool {
+			return d.Suspended
+		}),
+		"withoutTenantId": boolFilter(func(d *camundaclientgo.ResProcessDefinition) bool {
+			return d.TenantId == ""
+		}),
+	})
+}
+
+func (s *Server) getProcessDefinition(w http.ResponseWriter, _ *http.Request, p params) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d := s.definitionBy(p)
+	if d == nil {
+		writeDefinitionNotFound(w, p)
+		return
+	}
+
+	writeJson(w, http.StatusOK, toResProcessDefinition(d))
+}
+
+func (s *Server) getProcessDefinitionXml(w http.ResponseWriter, _ *http.Request, p params) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d := s.definitionBy(p)
+	if d == nil {
+		writeDefinitionNotFound(w, p)
+		return
+	}
+
+	writeJson(w, http.StatusOK, &camundaclientgo.ResBPMNProcessDefinition{Id: d.Id, Bpmn20Xml: d.xml})
+}
+
+func (s *Server) startProcessInstance(w http.ResponseWriter, r *http.Request, p params) {
+	req := camundaclientgo.ReqStartInstance{}
+	if err := decodeBody(r, &req); err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d := s.definitionBy(p)
+	if d == nil {
+		writeDefinitionNotFound(w, p)
+		return
+	}
+	if d.Suspended {
+		writeBadRequest(w, "Cannot start process instance. Process definition %s is suspended", d.Id)
+		return
+	}
+
+	var variables map[string]camundaclientgo.Variable
+	if req.Variables != nil {
+		variables = *req.Variables
+	}
+	businessKey := ""
+	if req.BusinessKey != nil {
+		businessKey = *req.BusinessKey
+	}
+
+	instance := s.startInstance(d, businessKey, variables)
+	s.notify()
+
+	res := &camundaclientgo.ResStartedProcessDefinition{
+		Id:           instance.Id,
+		DefinitionId: instance.ProcessDefinitionId,
+		BusinessKey:  instance.BusinessKey,
+		TenantId:     instance.TenantId,
+		Ended:        instance.Ended(),
+		Suspended:    instance.State == StateSuspended,
+	}
+	if req.WithVariablesInReturn != nil && *req.WithVariablesInReturn {
+		res.Variables = copyVariables(instance.Variables)
+	}
+
+	writeJson(w, http.StatusOK, res)
+}
+
+func (s *Server) suspendProcessDefinition(w http.ResponseWriter, r *http.Request, p params) {
+	req := camundaclientgo.ReqActivateOrSuspendById{}
+	if err := decodeBody(r, &req); err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d := s.definitionBy(p)
+	if d == nil {
+		writeDefinitionNotFound(w, p)
+		return
+	}
+
+	includeInstances := req.IncludeProcessInstances != nil && *req.IncludeProcessInstances
+	s.suspendDefinitions(d.Key, d.Id, req.Suspended != nil && *req.Suspended, includeInstances)
+	writeNoContent(w)
+}
+
+func (s *Server) suspendProcessDefinitionByKey(w http.ResponseWriter, r *http.Request, _ params) {
+	req := camundaclientgo.ReqActivateOrSuspendByKey{}
+	if err := decodeBody(r, &req); err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	includeInstances := req.IncludeProcessInstances != nil && *req.IncludeProcessInstances
+	s.suspendDefinitions(req.ProcessDefinitionKey, "", req.Suspended != nil && *req.Suspended, includeInstances)
+	writeNoContent(w)
+}
+
+// suspendDefinitions suspends or activates process definitions with the key or the id when it is not empty,
+// s.mu must be held
+func (s *Server) suspendDefinitions(key, id string, suspended, includeInstances bool) {
+	for _, d := range s.definitions {
+		if d.Key != key || (id != "" && d.Id != id) {
+			continue
+		}
+
+		d.Suspended = suspended
+		if !includeInstances {
+			continue
+		}
+
+		for _, instance := range s.instances {
+			if instance.ProcessDefinitionId == d.Id {
+				instance.suspend(suspended)
+			}
+		}
+	}
+
+	s.notify()
+}
+
+// definitionBy returns a process definition by the id, key or key and tenant id of path parameters,
+// s.mu must be held
+func (s *Server) definitionBy(p params) *processDefinition {
+	if id, ok := p["id"]; ok {
+		return s.findDefinition(id)
+	}
+
+	return s.latestDefinition(p["key"], p["tenantId"])
+}
+
+func (s *Server) findDefinition(id string) *processDefinition {
+	for _, d := range s.definitions {
+		if d.Id == id {
+			return d
+		}
+	}
+
+	return nil
+}
+
+// latestDefinition returns the latest version of a process definition, s.mu must be held
+func (s *Server) latestDefinition(key, tenantId string) *processDefinition {
+	var latest *processDefinition
+	for _, d := range s.definitions {
+		if d.Key == key && d.TenantId == tenantId && (latest == nil || d.Version > latest.Version) {
+			latest = d
+		}
+	}
+
+	return latest
+}
+
+func writeDefinitionNotFound(w http.ResponseWriter, p params) {
+	if id, ok := p["id"]; ok {
+		writeNotFound(w, "No matching definition with id %s", id)
+		return
+	}
+
+	writeNotFound(w, "No matching process definition with key: %s and tenant-id: %s", p["key"], p["tenantId"])
+}
+
+func toResProcessDefinition(d *processDefinition) *camundaclientgo.ResProcessDefinition {
+	return &camundaclientgo.ResProcessDefinition{
+		Id:                  d.Id,
+		Key:                 d.Key,
+		Name:                d.Name,
+		Version:             d.Version,
+		VersionTag:          d.VersionTag,
+		Resource:            d.Resource,
+		DeploymentId:        d.DeploymentId,
+		TenantId:            d.TenantId,
+		Suspended:           d.Suspended,
+		StartableInTasklist: true,
+	}
+}
+
+func (s *Server) getProcessInstanceList(w http.ResponseWriter, r *http.Request, _ params) {
+	values, err := queryValues(r)
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	writeJson(w, http.StatusOK, pageList(s.processInstanceList(values), values))
+}
+
+func (s *Server) getProcessInstanceCount(w http.ResponseWriter, r *http.Request, _ params) {
+	values, err := queryValues(r)
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	writeCount(w, len(s.processInstanceList(values)))
+}
+
+func (s *Server) processInstanceList(values map[string]string) []*camundaclientgo.ResProcessInstance {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var instances []*camundaclientgo.ResProcessInstance
+	for _, p := range s.instances {
+		if !p.Ended() {
+			instances = append(instances, toResProcessInstance(p))
+		}
+	}
+
+	key := func(p *camundaclientgo.ResProcessInstance) string {
+		return s.findInstance(p.Id).ProcessDefinitionKey
+	}
+
+	return filterList(instances, values, filters[*camundaclientgo.ResProcessInstance]{
+		"processInstanceIds": func(p *camundaclientgo.ResProcessInstance, value string) bool {
+			return contains(strings.Split(value, ","), p.Id)
+		},
+		"processDefinitionId": func(p *camundaclientgo.ResProcessInstance, value string) bool {
+			return p.DefinitionId == value
+		},
+		"processDefinitionKey": func(p *camundaclientgo.ResProcessInstance, value string) bool {
+			return key(p) == value
+		},
+		"processDefinitionKeyIn": func(p *camundaclientgo.ResProcessInstance, value string) bool {
+			return contains(strings.Split(value, ","), key(p))
+		},
+		"processDefinitionKeyNotIn": func(p *camundaclientgo.ResProcessInstance, value string) bool {
+			return !contains(strings.Split(value, ","), key(p))
+		},
+		"active": boolFilter(func(p *camundaclientgo.ResProcessInstance) bool {
+			return !p.Suspended
+		}),
+		"suspended": boolFilter(func(p *camundaclientgo.ResProcessInstance) bool {
+			return p.Suspended
+		}),
+	})
+}
+
+func (s *Server) getProcessInstance(w http.ResponseWriter, _ *http.Request, p params) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	instance := s.findInstance(p["id"])
+	if instance == nil || instance.Ended() {
+		writeNotFound(w, "Process instance with id %s does not exist", p["id"])
+		return
+	}
+
+	writeJson(w, http.StatusOK, toResProcessInstance(instance))
+}
+
+func (s *Server) deleteProcessInstance(w http.ResponseWriter, _ *http.Request, p params) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	instance := s.findInstance(p["id"])
+	if instance == nil || instance.Ended() {
+		writeNotFound(w, "Process instance with id %s does not exist", p["id"])
+		return
+	}
+
+	s.endInstance(instance, StateExternallyTerminated, "")
+	s.notify()
+	writeNoContent(w)
+}
+
+// deleteProcessInstancesAsync deletes process instances by ids and a query immediately and returns a completed batch
+func (s *Server) deleteProcessInstancesAsync(w http.ResponseWriter, r *http.Request, _ params) {
+	req := struct {
+		ProcessInstanceIds   []string               `json:"processInstanceIds"`
+		ProcessInstanceQuery map[string]interface{} `json:"processInstanceQuery"`
+		DeleteReason         *string                `json:"deleteReason"`
+	}{}
+	body, err := readBody(r)
+	if err == nil {
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		err = decoder.Decode(&req)
+	}
+	if err != nil {
+		writeBadRequest(w, "invalid request body: %s", err)
+		return
+	}
+
+	ids := req.ProcessInstanceIds
+	if req.ProcessInstanceQuery != nil {
+		values := map[string]string{}
+		addValues(values, req.ProcessInstanceQuery)
+		for _, p := range s.processInstanceList(values) {
+			ids = append(ids, p.Id)
+		}
+	}
+
+	if len(ids) == 0 {
+		writeBadRequest(w, "processInstanceIds is empty")
+		return
+	}
+
+	reason := ""
+	if req.DeleteReason != nil {
+		reason = *req.DeleteReason
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range ids {
+		if instance := s.findInstance(id); instance != nil && !instance.Ended() {
+			s.endInstance(instance, StateExternallyTerminated, reason)
+		}
+	}
+
+	s.notify()
+	writeJson(w, http.StatusOK, &camundaclientgo.ResBatch{
+		Id:                     s.newId(),
+		Type:                   "instance-deletion",
+		TotalJobs:              len(ids),
+		BatchJobsPerSeed:       100,
+		InvocationsPerBatchJob: 1,
+	})
+}
+
+func (s *Server) suspendProcessInstance(w http.ResponseWriter, r *http.Request, p params) {
+	req := camundaclientgo.ReqProcessInstanceActivateSuspend{}
+	if err := decodeBody(r, &req); err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	instance := s.findInstance(p["id"])
+	if instance == nil || instance.Ended() {
+		writeNotFound(w, "Process instance with id %s does not exist", p["id"])
+		return
+	}
+
+	instance.suspend(req.Suspended)
+	s.notify()
+	writeNoContent(w)
+}
+
+func (p *processInstance) suspend(suspended bool) {
+	if p.Ended() {
+		return
+	}
+
+	if suspended {
+		p.State = StateSuspended
+	} else {
+		p.State = StateActive
+	}
+}
+
+func toResProcessInstance(p *processInstance) *camundaclientgo.ResProcessInstance {
+	return &camundaclientgo.ResProcessInstance{
+		Id:           p.Id,
+		DefinitionId: p.ProcessDefinitionId,
+		BusinessKey:  p.BusinessKey,
+		Suspended:    p.State == StateSuspended,
+		TenantId:     p.TenantId,
+	}
+}
+
+func (s *Server) getProcessVariableList(w http.ResponseWriter, _ *http.Request, p params) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	instance := s.findInstance(p["id"])
+	if instance == nil || instance.Ended() {
+		writeNotFound(w, "process instance %s doesn't exist", p["id"])
+		return
+	}
+
+	variables := map[string]*camundaclientgo.ResProcessVariable{}
+	for name, v := range instance.Variables {
+		variables[name] = toResProcessVariable(v)
+	}
+
+	writeJson(w, http.StatusOK, variables)
+}
+
+func (s *Server) getProcessVariable(w http.ResponseWriter, _ *http.Request, p params) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, ok := s.processVariable(p)
+	if !ok {
+		writeNotFound(w, "process instance variable with name %s does not exist", p["varName"])
+		return
+	}
+
+	writeJson(w, http.StatusOK, toResProcessVariable(v))
+}
+
+func (s *Server) modifyProcessVariables(w http.ResponseWriter, r *http.Request, p params) {
+	req := camundaclientgo.ReqModifyProcessVariables{}
+	if err := decodeBody(r, &req); err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	instance := s.findInstance(p["id"])
+	if instance == nil || instance.Ended() {
+		writeError(w, http.StatusInternalServerError, "ProcessEngineException",
+			fmt.Sprintf("Cannot modify variables for process instance %s: execution %s doesn't exist", p["id"], p["id"]))
+		return
+	}
+
+	if req.Modifications != nil {
+		for name, v := range *req.Modifications {
+			instance.Variables[name] = fromReqProcessVariable(v)
+		}
+	}
+	for _, name := range req.Deletions {
+		delete(instance.Variables, name)
+	}
+
+	s.notify()
+	writeNoContent(w)
+}
+
+func (s *Server) putProcessVariable(w http.ResponseWriter, r *http.Request, p params) {
+	req := camundaclientgo.ReqProcessVariable{}
+	if err := decodeBody(r, &req); err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	instance := s.findInstance(p["id"])
+	if instance == nil || instance.Ended() {
+		writeError(w, http.StatusInternalServerError, "ProcessEngineException",
+			fmt.Sprintf("Cannot put process instance variable %s: execution %s doesn't exist", p["varName"], p["id"]))
+		return
+	}
+
+	instance.Variables[p["varName"]] = fromReqProcessVariable(req)
+	s.notify()
+	writeNoContent(w)
+}
+
+func (s *Server) deleteProcessVariable(w http.ResponseWriter, _ *http.Request, p params) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	instance := s.findInstance(p["id"])
+	if instance == nil || instance.Ended() {
+		writeError(w, http.StatusInternalServerError, "ProcessEngineException",
+			fmt.Sprintf("Cannot delete process instance variable %s: execution %s doesn't exist", p["varName"], p["id"]))
+		return
+	}
+
+	delete(instance.Variables, p["varName"])
+	s.notify()
+	writeNoContent(w)
+}
+
+func (s *Server) getProcessVariableData(w http.ResponseWriter, _ *http.Request, p params) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, ok := s.processVariable(p)
+	if !ok {
+		writeNotFound(w, "process instance variable with name %s does not exist", p["varName"])
+		return
+	}
+
+	writeVariableData(w, v)
+}
+
+func (s *Server) postProcessVariableData(w http.ResponseWriter, r *http.Request, p params) {
+	v, err := readVariableData(r)
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	instance := s.findInstance(p["id"])
+	if instance == nil || instance.Ended() {
+		writeError(w, http.StatusInternalServerError, "ProcessEngineException",
+			fmt.Sprintf("Cannot set process instance variable %s: execution %s doesn't exist", p["varName"], p["id"]))
+		return
+	}
+
+	instance.Variables[p["varName"]] = v
+	s.notify()
+	writeNoContent(w)
+}
+
+// processVariable returns a variable of an active process instance by path parameters, s.mu must be held
+func (s *Server) processVariable(p params) (camundaclientgo.Variable, bool) {
+	instance := s.findInstance(p["id"])
+	if instance == nil || instance.Ended() {
+		return camundaclientgo.Variable{}, false
+	}
+
+	v, ok := instance.Variables[p["varName"]]
+	return v, ok
+}
+
+func toResProcessVariable(v camundaclientgo.Variable) *camundaclientgo.ResProcessVariable {
+	res := &camundaclientgo.ResProcessVariable{Value: v.Value, Type: v.Type}
+	if v.ValueInfo.ObjectTypeName != nil {
+		res.ValueInfo.ObjectTypeName = *v.ValueInfo.ObjectTypeName
+	}
+	if v.ValueInfo.SerializationDataFormat != nil {
+		res.ValueInfo.SerializationDataFormat = *v.ValueInfo.SerializationDataFormat
+	}
+
+	return res
+}
+
+func fromReqProcessVariable(req camundaclientgo.ReqProcessVariable) camundaclientgo.Variable {
+	v := camundaclientgo.Variable{Value: req.Value}
+	if req.Type != nil {
+		v.Type = *req.Type
+	}
+
+	if info := req.ValueInfo; info != nil {
+		v.ValueInfo.ObjectTypeName = info.ObjectTypeName
+		v.ValueInfo.SerializationDataFormat = info.SerializationDataFormat
+		if info.FileName != nil {
+			v.ValueInfo.FileName = *info.FileName
+		}
+		if info.MimeType != nil {
+			v.ValueInfo.MimeType = *info.MimeType
+		}
+		if info.Encoding != nil {
+			v.ValueInfo.Encoding = *info.Encoding
+		}
+	}
+
+	return v
+}
+
+// writeVariableData writes the content of a Bytes or File variable, which is stored base64 encoded
+func writeVariableData(w http.ResponseWriter, v camundaclientgo.Variable) {
+	value, _ := v.Value.(string)
+	content, err := base64.StdEncoding.DecodeString(value)
+	if err != nil || (v.Type != camundaclientgo.VariableTypeBytes && v.Type != camundaclientgo.VariableTypeFile) {
+		writeBadRequest(w, "Value of variable with type %s is not a binary value", v.Type)
+		return
+	}
+
+	contentType := v.ValueInfo.MimeType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	if v.ValueInfo.Encoding != "" {
+		contentType += "; charset=" + v.ValueInfo.Encoding
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	if v.ValueInfo.FileName != "" {
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": v.ValueInfo.FileName}))
+	}
+	_, _ = w.Write(content)
+}
+
+// readVariableData reads a Bytes or File variable from a multipart form with the data and valueType fields
+func readVariableData(r *http.Request) (camundaclientgo.Variable, error) {
+	f, header, err := r.FormFile("data")
+	if err != nil {
+		return camundaclientgo.Variable{}, fmt.Errorf("invalid variable data: %w", err)
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(f)
+	if err != nil {
+		return camundaclientgo.Variable{}, fmt.Errorf("invalid variable data: %w", err)
+	}
+
+	v := camundaclientgo.Variable{
+		Value: base64.StdEncoding.EncodeToString(content),
+		Type:  r.FormValue("valueType"),
+	}
+	if v.Type == "" {
+		v.Type = camundaclientgo.VariableTypeFile
+	}
+
+	if v.Type == camundaclientgo.VariableTypeFile {
+		v.ValueInfo.FileName = header.Filename
+		if mediaType, params, err := mime.ParseMediaType(header.Header.Get("Content-Type")); err == nil {
+			v.ValueInfo.MimeType = mediaType
+			v.ValueInfo.Encoding = params["charset"]
+		}
+	}
+
+	return v, nil
+}
@@ -0,0 +1,282 @@
+// Package camundatest provides an in-process fake of the Camunda REST API for unit tests.
+//
+// The Server keeps deployments, process definitions and instances, external and user tasks, messages,
+// incidents and history in memory and serves them over httptest, so code built on camunda_client_go and the
+// processor package can be tested without a running engine:
+//
+//	server := camundatest.NewServer()
+//	defer server.Close()
+//
+//	client := server.Client()
+//	task := server.AddExternalTask(camundatest.ExternalTask{TopicName: "PrintHello"})
+//	// ... run the code under test
+//	server.AssertExternalTaskCompleted(t, task.Id)
+//
+// Processes deployed with Deployment.Create are executed: start and end events, external service tasks,
+// user tasks, exclusive gateways with conditions on variables, parallel gateways, message catch events,
+// timer events on the server clock and error boundary events are supported. A flow node the server cannot
+// execute, e.g. an embedded subprocess, creates an incident of type IncidentTypeFailedJob
+package camundatest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+)
+
+// BasePath the path of the REST API on the server
+const BasePath = "/engine-rest"
+
+// Server a fake Camunda engine
+type Server struct {
+	*httptest.Server
+
+	routes []route
+
+	mu sync.Mutex
+	// changed is closed and replaced on every change of the state to wake up long polling requests
+	changed chan struct{}
+	// frozen is the time of the clock set by SetTime, the real time is used while it is zero
+	frozen time.Time
+	offset time.Duration
+	nextId int
+
+	requests      []Request
+	deployments   []*Deployment
+	definitions   []*processDefinition
+	instances     []*processInstance
+	externalTasks []*ExternalTask
+	userTasks     []*UserTask
+	messages      []*Message
+	incidents     []*Incident
+}
+
+// Request a request received by the server
+type Request struct {
+	Method string
+	// Path is the path of the request without BasePath
+	Path string
+	// Query is the raw query of the request
+	Query string
+	Body  []byte
+}
+
+// NewServer starts and returns a new Server. The caller should call Close when finished, to shut it down
+func NewServer() *Server {
+	s := &Server{
+		changed: make(chan struct{}),
+	}
+	s.registerRoutes()
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// Client returns a client of the server
+func (s *Server) Client() *camundaclientgo.Client {
+	return camundaclientgo.NewClient(s.ClientOptions())
+}
+
+// ClientOptions returns options of a client of the server
+func (s *Server) ClientOptions() camundaclientgo.ClientOptions {
+	return camundaclientgo.ClientOptions{
+		EndpointUrl: s.URL + BasePath,
+		Timeout:     time.Second * 30,
+	}
+}
+
+// Now returns the current time of the server clock
+func (s *Server) Now() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.now()
+}
+
+// SetTime stops the server clock at t. Lock expirations, timer events and timestamps of the state use
+// the server clock
+func (s *Server) SetTime(t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.frozen = t
+	s.fireDueTimers()
+	s.notify()
+}
+
+// Advance moves the server clock forward by d and fires timer events that became due
+func (s *Server) Advance(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.frozen.IsZero() {
+		s.offset += d
+	} else {
+		s.frozen = s.frozen.Add(d)
+	}
+	s.fireDueTimers()
+	s.notify()
+}
+
+// Requests returns requests received by the server in order of arrival
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *Server) now() time.Time {
+	if !s.frozen.IsZero() {
+		return s.frozen
+	}
+
+	return time.Now().Add(s.offset)
+}
+
+// notify wakes up requests waiting for a change of the state, s.mu must be held
+func (s *Server) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// newId returns a new unique id, s.mu must be held
+func (s *Server) newId() string {
+	s.nextId++
+	return strconv.Itoa(s.nextId)
+}
+
+type params map[string]string
+
+type handlerFunc func(w http.ResponseWriter, r *http.Request, p params)
+
+type route struct {
+	method  string
+	pattern []string
+	handler handlerFunc
+}
+
+func (s *Server) handle(method, pattern string, handler handlerFunc) {
+	s.routes = append(s.routes, route{
+		method:  method,
+		pattern: strings.Split(strings.Trim(pattern, "/"), "/"),
+		handler: handler,
+	})
+}
+
+func (s *Server) registerRoutes() {
+	s.registerDeploymentRoutes()
+	s.registerProcessRoutes()
+	s.registerExternalTaskRoutes()
+	s.registerUserTaskRoutes()
+	s.registerMessageRoutes()
+	s.registerHistoryRoutes()
+	s.registerIncidentRoutes()
+}
+
+// ServeHTTP serves the REST API, requests to unsupported endpoints are answered with 501 Not Implemented
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, BasePath)
+	body, err := readBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequestException", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: path, Query: r.URL.RawQuery, Body: body})
+	if s.fireDueTimers() {
+		s.notify()
+	}
+	s.mu.Unlock()
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for _, route := range s.routes {
+		if route.method != r.Method {
+			continue
+		}
+
+		if p, ok := match(route.pattern, segments); ok {
+			route.handler(w, r, p)
+			return
+		}
+	}
+
+	http.Error(w, fmt.Sprintf("camundatest: %s %s is not implemented", r.Method, path), http.StatusNotImplemented)
+}
+
+func match(pattern, segments []string) (params, bool) {
+	if len(pattern) != len(segments) {
+		return nil, false
+	}
+
+	p := params{}
+	for i, segment := range pattern {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			p[segment[1:len(segment)-1]] = segments[i]
+			continue
+		}
+
+		if segment != segments[i] {
+			return nil, false
+		}
+	}
+
+	return p, true
+}
+
+func writeJson(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeCount(w http.ResponseWriter, count int) {
+	writeJson(w, http.StatusOK, &camundaclientgo.ResCount{Count: count})
+}
+
+// writeError writes an error in the format of the engine, which the client returns as *camundaclientgo.Error
+// or camundaclientgo.ErrorNotFound for 404
+func writeError(w http.ResponseWriter, status int, errorType, message string) {
+	writeJson(w, status, &camundaclientgo.Error{Type: errorType, Message: message})
+}
+
+func writeNotFound(w http.ResponseWriter, format string, args ...interface{}) {
+	writeError(w, http.StatusNotFound, "RestException", fmt.Sprintf(format, args...))
+}
+
+func writeBadRequest(w http.ResponseWriter, format string, args ...interface{}) {
+	writeError(w, http.StatusBadRequest, "RestException", fmt.Sprintf(format, args...))
+}
+
+func writeNoContent(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeBody decodes a JSON body of the request into v, an empty body is not an error
+func decodeBody(r *http.Request, v interface{}) error {
+	body, err := readBody(r)
+	if err != nil || len(body) == 0 {
+		return err
+	}
+
+	if err = json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+
+	return nil
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(camundaclientgo.DefaultDateTimeFormat)
+}
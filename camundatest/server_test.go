@@ -0,0 +1,370 @@
+package camundatest
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+	"github.com/citilinkru/camunda-client-go/v3/processor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func deployHelloWorld(t *testing.T, client *camundaclientgo.Client) *camundaclientgo.ResDeploymentCreate {
+	t.Helper()
+
+	bpmn, err := os.ReadFile("../examples/deployment/HelloWorld.bpmn")
+	require.NoError(t, err)
+
+	deployment, err := client.Deployment.Create(camundaclientgo.ReqDeploymentCreate{
+		DeploymentName: "HelloWorld",
+		Resources: map[string]interface{}{
+			"HelloWorld.bpmn": strings.NewReader(string(bpmn)),
+		},
+	})
+	require.NoError(t, err)
+	return deployment
+}
+
+func TestServer_DeployAndStart(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	deployment := deployHelloWorld(t, client)
+	assert.Len(t, deployment.DeployedProcessDefinitions, 1)
+
+	key := "hello-world-process"
+	definition, err := client.ProcessDefinition.Get(camundaclientgo.QueryProcessDefinitionBy{Key: &key})
+	require.NoError(t, err)
+	assert.Equal(t, "Hello World Process", definition.Name)
+	assert.Equal(t, 1, definition.Version)
+	assert.Equal(t, deployment.Id, definition.DeploymentId)
+
+	xml, err := client.ProcessDefinition.GetXML(camundaclientgo.QueryProcessDefinitionBy{Id: &definition.Id})
+	require.NoError(t, err)
+	assert.Contains(t, xml.Bpmn20Xml, `camunda:topic="PrintHello"`)
+
+	businessKey := "order-1"
+	started, err := client.ProcessDefinition.StartInstance(camundaclientgo.QueryProcessDefinitionBy{Key: &key}, camundaclientgo.ReqStartInstance{
+		BusinessKey: &businessKey,
+		Variables: &map[string]camundaclientgo.Variable{
+			"isWorld": {Value: true, Type: camundaclientgo.VariableTypeBoolean},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, definition.Id, started.DefinitionId)
+	server.AssertProcessInstanceActive(t, started.Id)
+	server.AssertVariable(t, started.Id, "isWorld", true)
+
+	// the exclusive gateway takes the conditional flow to PrintWorld
+	tasks := server.ExternalTasks()
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "PrintWorld", tasks[0].TopicName)
+	assert.Equal(t, "Task_1s4a9px", tasks[0].ActivityId)
+	assert.Equal(t, started.Id, tasks[0].ProcessInstanceId)
+	assert.Equal(t, businessKey, tasks[0].BusinessKey)
+
+	instances, err := client.ProcessInstance.GetList(map[string]string{"businessKey": businessKey})
+	require.NoError(t, err)
+	require.Len(t, instances, 1)
+	assert.Equal(t, started.Id, instances[0].Id)
+
+	count, err := client.ProcessInstance.GetCount(map[string]string{"businessKey": "unknown"})
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	require.NoError(t, client.ProcessInstance.Delete(started.Id, nil))
+	server.AssertProcessInstanceEnded(t, started.Id)
+
+	history, err := client.History.GetProcessInstance(started.Id)
+	require.NoError(t, err)
+	assert.Equal(t, StateExternallyTerminated, history.State)
+	assert.Equal(t, key, history.ProcessDefinitionKey)
+
+	_, err = client.ProcessInstance.Get(started.Id)
+	assert.True(t, errors.Is(err, camundaclientgo.ErrorNotFound))
+
+	// an unchanged deployment is filtered out as a duplicate
+	enable := true
+	redeployed, err := client.Deployment.Create(camundaclientgo.ReqDeploymentCreate{
+		DeploymentName:           "HelloWorld",
+		EnableDuplicateFiltering: &enable,
+		Resources: map[string]interface{}{
+			"HelloWorld.bpmn": strings.NewReader(xml.Bpmn20Xml),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, deployment.Id, redeployed.Id)
+	assert.Len(t, server.ProcessDefinitions(), 1)
+}
+
+func TestServer_ExternalTask(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	server.SetTime(now)
+
+	instance := server.AddProcessInstance(ProcessInstance{
+		Variables: map[string]camundaclientgo.Variable{
+			"name": {Value: "John", Type: camundaclientgo.VariableTypeString},
+			"age":  {Value: 42, Type: camundaclientgo.VariableTypeInteger},
+		},
+	})
+	task := server.AddExternalTask(ExternalTask{TopicName: "greet", ProcessInstanceId: instance.Id})
+	server.AddExternalTask(ExternalTask{TopicName: "other"})
+
+	tasks, err := client.ExternalTask.FetchAndLock(camundaclientgo.QueryFetchAndLock{
+		WorkerId: "worker",
+		MaxTasks: 10,
+		Topics: []*camundaclientgo.QueryFetchAndLockTopic{
+			{TopicName: "greet", LockDuration: 1000, Variables: []string{"name"}},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, task.Id, tasks[0].Id)
+	assert.Equal(t, instance.Id, tasks[0].ProcessInstanceId)
+	assert.Equal(t, map[string]camundaclientgo.Variable{
+		"name": {Value: "John", Type: camundaclientgo.VariableTypeString},
+	}, tasks[0].Variables)
+
+	// the task is locked
+	tasks, err = client.ExternalTask.FetchAndLock(camundaclientgo.QueryFetchAndLock{
+		WorkerId: "other-worker",
+		MaxTasks: 10,
+		Topics:   []*camundaclientgo.QueryFetchAndLockTopic{{TopicName: "greet", LockDuration: 1000}},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+
+	otherWorker := "other-worker"
+	err = client.ExternalTask.Complete(task.Id, camundaclientgo.QueryComplete{WorkerId: &otherWorker})
+	assert.Error(t, err)
+
+	worker := "worker"
+	newDuration := 5000
+	require.NoError(t, client.ExternalTask.ExtendLock(task.Id, camundaclientgo.QueryExtendLock{WorkerId: &worker, NewDuration: &newDuration}))
+	locked, _ := server.ExternalTask(task.Id)
+	assert.Equal(t, now.Add(5*time.Second), locked.LockExpirationTime)
+
+	require.NoError(t, client.ExternalTask.Complete(task.Id, camundaclientgo.QueryComplete{
+		WorkerId: &worker,
+		Variables: &map[string]camundaclientgo.Variable{
+			"greeting": {Value: "Hello, John", Type: camundaclientgo.VariableTypeString},
+		},
+	}))
+	server.AssertExternalTaskCompleted(t, task.Id)
+	server.AssertVariable(t, instance.Id, "greeting", "Hello, John")
+	server.AssertVariable(t, instance.Id, "age", 42)
+
+	_, err = client.ExternalTask.Get(task.Id)
+	assert.True(t, errors.Is(err, camundaclientgo.ErrorNotFound))
+
+	count, err := client.ExternalTask.GetListCount(map[string]string{"topicName": "other"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	server.AssertNoIncidents(t)
+}
+
+func TestServer_ExternalTaskFailure(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	task := server.AddExternalTask(ExternalTask{TopicName: "fail"})
+	fetch := camundaclientgo.QueryFetchAndLock{
+		WorkerId: "worker",
+		MaxTasks: 1,
+		Topics:   []*camundaclientgo.QueryFetchAndLockTopic{{TopicName: "fail", LockDuration: 60000}},
+	}
+
+	tasks, err := client.ExternalTask.FetchAndLock(fetch)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+
+	worker, message, retries := "worker", "boom", 1
+	require.NoError(t, client.ExternalTask.HandleFailure(task.Id, camundaclientgo.QueryHandleFailure{
+		WorkerId:     &worker,
+		ErrorMessage: &message,
+		Retries:      &retries,
+	}))
+	server.AssertExternalTaskFailed(t, task.Id)
+
+	// the task is available again without a retry timeout
+	tasks, err = client.ExternalTask.FetchAndLock(fetch)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "boom", tasks[0].ErrorMessage)
+
+	retries = 0
+	require.NoError(t, client.ExternalTask.HandleFailure(task.Id, camundaclientgo.QueryHandleFailure{
+		WorkerId:     &worker,
+		ErrorMessage: &message,
+		Retries:      &retries,
+	}))
+
+	incidents := server.Incidents()
+	require.Len(t, incidents, 1)
+	assert.Equal(t, IncidentTypeFailedExternalTask, incidents[0].Type)
+	assert.Equal(t, task.Id, incidents[0].Configuration)
+
+	tasks, err = client.ExternalTask.FetchAndLock(fetch)
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+
+	require.NoError(t, client.ExternalTask.SetRetries(task.Id, 1))
+	assert.Empty(t, server.Incidents())
+}
+
+func TestServer_FetchAndLockLongPolling(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		server.AddExternalTask(ExternalTask{TopicName: "later"})
+	}()
+
+	timeout := 5000
+	started := time.Now()
+	tasks, err := client.ExternalTask.FetchAndLock(camundaclientgo.QueryFetchAndLock{
+		WorkerId:             "worker",
+		MaxTasks:             1,
+		AsyncResponseTimeout: &timeout,
+		Topics:               []*camundaclientgo.QueryFetchAndLockTopic{{TopicName: "later", LockDuration: 1000}},
+	})
+	require.NoError(t, err)
+	assert.Len(t, tasks, 1)
+	assert.Less(t, time.Since(started), 5*time.Second)
+}
+
+func TestServer_Processor(t *testing.T) {
+	type in struct {
+		Name string `camunda:"name"`
+	}
+	type out struct {
+		Greeting string `camunda:"greeting"`
+	}
+
+	server := NewServer()
+	defer server.Close()
+
+	instance := server.AddProcessInstance(ProcessInstance{
+		Variables: map[string]camundaclientgo.Variable{
+			"name": {Value: "John", Type: camundaclientgo.VariableTypeString},
+		},
+	})
+	completed := server.AddExternalTask(ExternalTask{TopicName: "greet", ProcessInstanceId: instance.Id})
+	rejected := server.AddExternalTask(ExternalTask{TopicName: "reject"})
+
+	p := processor.NewProcessor(server.Client(), &processor.Options{
+		WorkerId:           "test",
+		LockDuration:       time.Minute,
+		MaxTasks:           10,
+		LongPollingTimeout: time.Second,
+	}, func(err error) {
+		t.Log(err)
+	})
+	defer p.Shutdown()
+
+	processor.AddTypedHandler(p, []*camundaclientgo.QueryFetchAndLockTopic{{TopicName: "greet"}}, func(ctx *processor.Context, in in) (out, error) {
+		return out{Greeting: "Hello, " + in.Name}, nil
+	})
+	processor.AddTypedHandler(p, []*camundaclientgo.QueryFetchAndLockTopic{{TopicName: "reject"}}, func(ctx *processor.Context, in in) (out, error) {
+		return out{}, &processor.BPMNError{Code: "REJECTED"}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, server.Wait(ctx, func() bool {
+		first, _ := server.ExternalTask(completed.Id)
+		second, _ := server.ExternalTask(rejected.Id)
+		return first.State != TaskStateCreated && second.State != TaskStateCreated
+	}))
+
+	server.AssertExternalTaskCompleted(t, completed.Id)
+	server.AssertVariable(t, instance.Id, "greeting", "Hello, John")
+	server.AssertBPMNError(t, rejected.Id, "REJECTED")
+}
+
+func TestServer_UserTask(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	task := server.AddUserTask(UserTask{Name: "Approve", TaskDefinitionKey: "approve", CandidateGroups: []string{"managers"}})
+	server.AddUserTask(UserTask{Name: "Review", TaskDefinitionKey: "review"})
+
+	tasks, err := client.UserTask.GetList(&camundaclientgo.UserTaskGetListQuery{CandidateGroup: "managers"})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, task.Id, tasks[0].Id)
+	assert.Equal(t, "Approve", tasks[0].Name)
+
+	require.NoError(t, client.UserTask.AddIdentityLink(task.Id, camundaclientgo.ReqIdentityLink{UserId: "john", Type: "assignee"}))
+	links, err := client.UserTask.GetIdentityLinks(task.Id)
+	require.NoError(t, err)
+	assert.Contains(t, *links, camundaclientgo.IdentityLink{UserId: "john", Type: "assignee"})
+
+	require.NoError(t, client.UserTask.Complete(task.Id, camundaclientgo.QueryUserTaskComplete{
+		Variables: map[string]camundaclientgo.Variable{"approved": {Value: true, Type: camundaclientgo.VariableTypeBoolean}},
+	}))
+	server.AssertUserTaskCompleted(t, task.Id)
+	server.AssertVariable(t, task.ProcessInstanceId, "approved", true)
+
+	count, err := client.UserTask.GetListCount(nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	history, err := client.History.GetTaskList(map[string]string{"finished": "true"})
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, task.Id, history[0].Id)
+	assert.Equal(t, "john", history[0].Assignee)
+}
+
+func TestServer_Message(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	err := client.Message.SendMessage(&camundaclientgo.ReqMessage{MessageName: "paid", BusinessKey: "order-1"})
+	var camundaErr *camundaclientgo.Error
+	require.True(t, errors.As(err, &camundaErr))
+	assert.Contains(t, camundaErr.Message, "No process definition or execution matches the parameters")
+
+	deploy(t, client, "order.bpmn", orderBpmn)
+	err = client.Message.SendMessage(&camundaclientgo.ReqMessage{
+		MessageName: "ordered",
+		BusinessKey: "order-1",
+	})
+	require.NoError(t, err)
+
+	instances := server.ProcessInstances()
+	require.Len(t, instances, 1)
+	assert.Equal(t, "order-1", instances[0].BusinessKey)
+
+	err = client.Message.SendMessage(&camundaclientgo.ReqMessage{
+		MessageName: "paid",
+		BusinessKey: "order-1",
+		ProcessVariables: &map[string]camundaclientgo.Variable{
+			"amount": {Value: 10, Type: camundaclientgo.VariableTypeInteger},
+		},
+	})
+	require.NoError(t, err)
+	server.AssertMessageReceived(t, "paid", "order-1")
+	server.AssertVariable(t, instances[0].Id, "amount", 10)
+	server.AssertProcessInstanceEnded(t, instances[0].Id)
+
+	messages := server.Messages()
+	require.Len(t, messages, 2)
+	assert.Equal(t, instances[0].Id, messages[1].ProcessInstanceId)
+}
@@ -0,0 +1,585 @@
+package camundatest
+
+import (
+	"sort"
+	"time"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+	"github.com/citilinkru/camunda-client-go/v3/bpmn"
+)
+
+// States of process instances, they are the states of historic process instances of the engine
+const (
+	StateActive               = "ACTIVE"
+	StateSuspended            = "SUSPENDED"
+	StateCompleted            = "COMPLETED"
+	StateExternallyTerminated = "EXTERNALLY_TERMINATED"
+	StateInternallyTerminated = "INTERNALLY_TERMINATED"
+)
+
+// States of external and user tasks
+const (
+	TaskStateCreated   = "CREATED"
+	TaskStateCompleted = "COMPLETED"
+	TaskStateBPMNError = "BPMN_ERROR"
+	TaskStateDeleted   = "DELETED"
+)
+
+// Types of incidents
+const (
+	// IncidentTypeFailedExternalTask a type of incidents created when an external task has no retries left
+	IncidentTypeFailedExternalTask = "failedExternalTask"
+	// IncidentTypeFailedJob a type of incidents created when the server cannot execute a flow node,
+	// e.g. a condition cannot be evaluated or the node is not supported
+	IncidentTypeFailedJob = "failedJob"
+)
+
+// Deployment a deployment of the server
+type Deployment struct {
+	Id        string
+	Name      string
+	Source    string
+	TenantId  string
+	Time      time.Time
+	Resources []Resource
+}
+
+// Resource a resource of a deployment
+type Resource struct {
+	Id      string
+	Name    string
+	Content []byte
+}
+
+// ProcessDefinition a process definition deployed to the server
+type ProcessDefinition struct {
+	Id           string
+	Key          string
+	Name         string
+	Version      int
+	VersionTag   string
+	Resource     string
+	DeploymentId string
+	TenantId     string
+	Suspended    bool
+}
+
+// ProcessInstance a process instance of the server
+type ProcessInstance struct {
+	Id                   string
+	BusinessKey          string
+	ProcessDefinitionId  string
+	ProcessDefinitionKey string
+	TenantId             string
+	// State is one of StateActive, StateSuspended, StateCompleted, StateExternallyTerminated
+	// and StateInternallyTerminated
+	State        string
+	Variables    map[string]camundaclientgo.Variable
+	StartTime    time.Time
+	EndTime      time.Time
+	DeleteReason string
+}
+
+// Ended returns true if the process instance is not active or suspended anymore
+func (p ProcessInstance) Ended() bool {
+	return p.State != StateActive && p.State != StateSuspended
+}
+
+// ExternalTask an external task of the server
+type ExternalTask struct {
+	Id                   string
+	TopicName            string
+	ActivityId           string
+	ActivityInstanceId   string
+	ExecutionId          string
+	ProcessInstanceId    string
+	ProcessDefinitionId  string
+	ProcessDefinitionKey string
+	BusinessKey          string
+	TenantId             string
+	Priority             int
+	// Retries is nil until a failure is reported for the task
+	Retries            *int
+	WorkerId           string
+	LockExpirationTime time.Time
+	ErrorMessage       string
+	ErrorDetails       string
+	// State is one of TaskStateCreated, TaskStateCompleted, TaskStateBPMNError and TaskStateDeleted
+	State string
+	// ErrorCode is the code of a BPMN error reported for the task
+	ErrorCode string
+	// Variables are variables sent on completion or with a BPMN error
+	Variables      map[string]camundaclientgo.Variable
+	LocalVariables map[string]camundaclientgo.Variable
+}
+
+// UserTask a user task of the server
+type UserTask struct {
+	Id                   string
+	Name                 string
+	Description          string
+	TaskDefinitionKey    string
+	Assignee             string
+	Owner                string
+	FormKey              string
+	CandidateUsers       []string
+	CandidateGroups      []string
+	Priority             int
+	ExecutionId          string
+	ProcessInstanceId    string
+	ProcessDefinitionId  string
+	ProcessDefinitionKey string
+	TenantId             string
+	// State is one of TaskStateCreated, TaskStateCompleted and TaskStateDeleted
+	State   string
+	Created time.Time
+	EndTime time.Time
+	// Variables are variables sent on completion
+	Variables map[string]camundaclientgo.Variable
+}
+
+// Message a message received by the server
+type Message struct {
+	Name        string
+	BusinessKey string
+	Variables   map[string]camundaclientgo.Variable
+	// ProcessInstanceId is the id of the process instance the message was correlated to
+	ProcessInstanceId string
+	Time              time.Time
+}
+
+// Incident an incident of the server
+type Incident struct {
+	Id                  string
+	Type                string
+	Message             string
+	ProcessInstanceId   string
+	ProcessDefinitionId string
+	ActivityId          string
+	// Configuration is the id of the external task for IncidentTypeFailedExternalTask and the id of
+	// the execution for IncidentTypeFailedJob
+	Configuration string
+	Time          time.Time
+}
+
+type processDefinition struct {
+	ProcessDefinition
+	xml     string
+	process *bpmn.Process
+}
+
+type processInstance struct {
+	ProcessInstance
+	// process is the executed process, it is nil for instances added without a deployed definition
+	process *bpmn.Process
+	tokens  []*token
+	// joins are numbers of tokens arrived at parallel gateways by gateway id
+	joins map[string]int
+}
+
+// Deployments returns deployments of the server in order of creation
+func (s *Server) Deployments() []Deployment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deployments := make([]Deployment, 0, len(s.deployments))
+	for _, d := range s.deployments {
+		copied := *d
+		copied.Resources = append([]Resource(nil), d.Resources...)
+		deployments = append(deployments, copied)
+	}
+
+	return deployments
+}
+
+// ProcessDefinitions returns process definitions of the server in order of deployment
+func (s *Server) ProcessDefinitions() []ProcessDefinition {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	definitions := make([]ProcessDefinition, 0, len(s.definitions))
+	for _, d := range s.definitions {
+		definitions = append(definitions, d.ProcessDefinition)
+	}
+
+	return definitions
+}
+
+// ProcessInstances returns process instances of the server in order of start
+func (s *Server) ProcessInstances() []ProcessInstance {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	instances := make([]ProcessInstance, 0, len(s.instances))
+	for _, p := range s.instances {
+		instances = append(instances, p.copy())
+	}
+
+	return instances
+}
+
+// ProcessInstance returns a process instance by id
+func (s *Server) ProcessInstance(id string) (ProcessInstance, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.findInstance(id)
+	if p == nil {
+		return ProcessInstance{}, false
+	}
+
+	return p.copy(), true
+}
+
+// ExternalTasks returns external tasks of the server in order of creation, all tasks are returned
+// if topics are empty
+func (s *Server) ExternalTasks(topics ...string) []ExternalTask {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var tasks []ExternalTask
+	for _, t := range s.externalTasks {
+		if len(topics) == 0 || contains(topics, t.TopicName) {
+			tasks = append(tasks, t.copy())
+		}
+	}
+
+	return tasks
+}
+
+// ExternalTask returns an external task by id
+func (s *Server) ExternalTask(id string) (ExternalTask, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.findExternalTask(id)
+	if t == nil {
+		return ExternalTask{}, false
+	}
+
+	return t.copy(), true
+}
+
+// UserTasks returns user tasks of the server in order of creation
+func (s *Server) UserTasks() []UserTask {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tasks := make([]UserTask, 0, len(s.userTasks))
+	for _, t := range s.userTasks {
+		tasks = append(tasks, t.copy())
+	}
+
+	return tasks
+}
+
+// UserTask returns a user task by id
+func (s *Server) UserTask(id string) (UserTask, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.findUserTask(id)
+	if t == nil {
+		return UserTask{}, false
+	}
+
+	return t.copy(), true
+}
+
+// Messages returns messages received by the server in order of arrival
+func (s *Server) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	messages := make([]Message, 0, len(s.messages))
+	for _, m := range s.messages {
+		copied := *m
+		copied.Variables = copyVariables(m.Variables)
+		messages = append(messages, copied)
+	}
+
+	return messages
+}
+
+// Incidents returns incidents of the server in order of creation
+func (s *Server) Incidents() []Incident {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	incidents := make([]Incident, 0, len(s.incidents))
+	for _, i := range s.incidents {
+		incidents = append(incidents, *i)
+	}
+
+	return incidents
+}
+
+// AddProcessInstance adds an active process instance without a deployed process definition, empty Id,
+// State and StartTime are generated
+func (s *Server) AddProcessInstance(instance ProcessInstance) ProcessInstance {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.addInstance(instance)
+	s.notify()
+	return p.copy()
+}
+
+// AddExternalTask adds an external task to be fetched by workers. Empty Id and State are generated
+// and a process instance is added when ProcessInstanceId is empty or unknown
+func (s *Server) AddExternalTask(task ExternalTask) ExternalTask {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.addExternalTask(task)
+	s.notify()
+	return t.copy()
+}
+
+// AddIncident adds an incident, e.g. a custom one which can be resolved. Empty Id and Time are generated
+func (s *Server) AddIncident(incident Incident) Incident {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := incident
+	if i.Id == "" {
+		i.Id = s.newId()
+	}
+	if i.Time.IsZero() {
+		i.Time = s.now()
+	}
+
+	s.incidents = append(s.incidents, &i)
+	s.notify()
+	return i
+}
+
+// AddUserTask adds a user task. Empty Id, State and Created are generated and a process instance is added
+// when ProcessInstanceId is empty or unknown
+func (s *Server) AddUserTask(task UserTask) UserTask {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.addUserTask(task)
+	s.notify()
+	return t.copy()
+}
+
+func (s *Server) addInstance(instance ProcessInstance) *processInstance {
+	if instance.Id == "" {
+		instance.Id = s.newId()
+	}
+	if instance.State == "" {
+		instance.State = StateActive
+	}
+	if instance.StartTime.IsZero() {
+		instance.StartTime = s.now()
+	}
+	instance.Variables = copyVariables(instance.Variables)
+	if instance.Variables == nil {
+		instance.Variables = map[string]camundaclientgo.Variable{}
+	}
+
+	p := &processInstance{ProcessInstance: instance}
+	s.instances = append(s.instances, p)
+	return p
+}
+
+// instanceOf returns a process instance by id or adds it, s.mu must be held
+func (s *Server) instanceOf(id, processDefinitionId, processDefinitionKey, businessKey string) *processInstance {
+	if p := s.findInstance(id); p != nil {
+		return p
+	}
+
+	return s.addInstance(ProcessInstance{
+		Id:                   id,
+		BusinessKey:          businessKey,
+		ProcessDefinitionId:  processDefinitionId,
+		ProcessDefinitionKey: processDefinitionKey,
+	})
+}
+
+func (s *Server) addExternalTask(task ExternalTask) *ExternalTask {
+	p := s.instanceOf(task.ProcessInstanceId, task.ProcessDefinitionId, task.ProcessDefinitionKey, task.BusinessKey)
+	task.ProcessInstanceId = p.Id
+	if task.Id == "" {
+		task.Id = s.newId()
+	}
+	if task.State == "" {
+		task.State = TaskStateCreated
+	}
+	if task.ExecutionId == "" {
+		task.ExecutionId = p.Id
+	}
+	if task.ProcessDefinitionId == "" {
+		task.ProcessDefinitionId = p.ProcessDefinitionId
+	}
+	if task.ProcessDefinitionKey == "" {
+		task.ProcessDefinitionKey = p.ProcessDefinitionKey
+	}
+	if task.BusinessKey == "" {
+		task.BusinessKey = p.BusinessKey
+	}
+	if task.TenantId == "" {
+		task.TenantId = p.TenantId
+	}
+	task.Variables = copyVariables(task.Variables)
+	task.LocalVariables = copyVariables(task.LocalVariables)
+
+	t := &task
+	s.externalTasks = append(s.externalTasks, t)
+	return t
+}
+
+func (s *Server) addUserTask(task UserTask) *UserTask {
+	p := s.instanceOf(task.ProcessInstanceId, task.ProcessDefinitionId, task.ProcessDefinitionKey, "")
+	task.ProcessInstanceId = p.Id
+	if task.Id == "" {
+		task.Id = s.newId()
+	}
+	if task.State == "" {
+		task.State = TaskStateCreated
+	}
+	if task.Created.IsZero() {
+		task.Created = s.now()
+	}
+	if task.ExecutionId == "" {
+		task.ExecutionId = p.Id
+	}
+	if task.ProcessDefinitionId == "" {
+		task.ProcessDefinitionId = p.ProcessDefinitionId
+	}
+	if task.ProcessDefinitionKey == "" {
+		task.ProcessDefinitionKey = p.ProcessDefinitionKey
+	}
+	if task.TenantId == "" {
+		task.TenantId = p.TenantId
+	}
+	task.CandidateUsers = append([]string(nil), task.CandidateUsers...)
+	task.CandidateGroups = append([]string(nil), task.CandidateGroups...)
+	task.Variables = copyVariables(task.Variables)
+
+	t := &task
+	s.userTasks = append(s.userTasks, t)
+	return t
+}
+
+func (s *Server) findDeployment(id string) *Deployment {
+	for _, d := range s.deployments {
+		if d.Id == id {
+			return d
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) findInstance(id string) *processInstance {
+	for _, p := range s.instances {
+		if p.Id == id {
+			return p
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) findExternalTask(id string) *ExternalTask {
+	for _, t := range s.externalTasks {
+		if t.Id == id {
+			return t
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) findUserTask(id string) *UserTask {
+	for _, t := range s.userTasks {
+		if t.Id == id {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// endInstance ends a process instance and deletes its open tasks, s.mu must be held
+func (s *Server) endInstance(p *processInstance, state, deleteReason string) {
+	p.State = state
+	p.EndTime = s.now()
+	p.DeleteReason = deleteReason
+	p.tokens = nil
+	p.joins = nil
+
+	for _, t := range s.externalTasks {
+		if t.ProcessInstanceId == p.Id && t.State == TaskStateCreated {
+			t.State = TaskStateDeleted
+		}
+	}
+
+	for _, t := range s.userTasks {
+		if t.ProcessInstanceId == p.Id && t.State == TaskStateCreated {
+			t.State = TaskStateDeleted
+			t.EndTime = p.EndTime
+		}
+	}
+}
+
+func (p *processInstance) copy() ProcessInstance {
+	copied := p.ProcessInstance
+	copied.Variables = copyVariables(p.Variables)
+	return copied
+}
+
+func (t *ExternalTask) copy() ExternalTask {
+	copied := *t
+	if t.Retries != nil {
+		retries := *t.Retries
+		copied.Retries = &retries
+	}
+	copied.Variables = copyVariables(t.Variables)
+	copied.LocalVariables = copyVariables(t.LocalVariables)
+	return copied
+}
+
+func (t *UserTask) copy() UserTask {
+	copied := *t
+	copied.CandidateUsers = append([]string(nil), t.CandidateUsers...)
+	copied.CandidateGroups = append([]string(nil), t.CandidateGroups...)
+	copied.Variables = copyVariables(t.Variables)
+	return copied
+}
+
+func copyVariables(variables map[string]camundaclientgo.Variable) map[string]camundaclientgo.Variable {
+	if variables == nil {
+		return nil
+	}
+
+	copied := make(map[string]camundaclientgo.Variable, len(variables))
+	for name, v := range variables {
+		copied[name] = v
+	}
+
+	return copied
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sortedNames(variables map[string]camundaclientgo.Variable) []string {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,327 @@
+package camundatest
+
+import (
+	"net/http"
+	"strings"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+)
+
+// Types of identity links of user tasks
+const (
+	identityLinkAssignee  = "assignee"
+	identityLinkOwner     = "owner"
+	identityLinkCandidate = "candidate"
+)
+
+func (s *Server) registerUserTaskRoutes() {
+	s.handle(http.MethodGet, "/task", s.getUserTaskList)
+	s.handle(http.MethodPost, "/task", s.getUserTaskList)
+	s.handle(http.MethodGet, "/task/count", s.getUserTaskCount)
+	s.handle(http.MethodPost, "/task/count", s.getUserTaskCount)
+	s.handle(http.MethodGet, "/task/{id}", s.getUserTask)
+	s.handle(http.MethodPost, "/task/{id}/complete", s.completeUserTask)
+	s.handle(http.MethodGet, "/task/{id}/identity-links", s.getUserTaskIdentityLinks)
+	s.handle(http.MethodPost, "/task/{id}/identity-links", s.addUserTaskIdentityLink)
+	s.handle(http.MethodPost, "/task/{id}/identity-links/delete", s.deleteUserTaskIdentityLink)
+	s.handle(http.MethodGet, "/task/{id}/variables/{varName}/data", s.getUserTaskVariableData)
+	s.handle(http.MethodPost, "/task/{id}/variables/{varName}/data", s.postUserTaskVariableData)
+}
+
+func (s *Server) getUserTaskList(w http.ResponseWriter, r *http.Request, _ params) {
+	values, err := queryValues(r)
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	writeJson(w, http.StatusOK, pageList(s.userTaskList(values), values))
+}
+
+func (s *Server) getUserTaskCount(w http.ResponseWriter, r *http.Request, _ params) {
+	values, err := queryValues(r)
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	writeCount(w, len(s.userTaskList(values)))
+}
+
+func (s *Server) userTaskList(values map[string]string) []*camundaclientgo.UserTaskResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tasks := []*camundaclientgo.UserTaskResponse{}
+	for _, t := range s.userTasks {
+		if t.State == TaskStateCreated {
+			tasks = append(tasks, s.toUserTaskResponse(t))
+		}
+	}
+
+	task := func(t *camundaclientgo.UserTaskResponse) *UserTask {
+		return s.findUserTask(t.Id)
+	}
+	instance := func(t *camundaclientgo.UserTaskResponse) *processInstance {
+		return s.findInstance(t.ProcessInstanceId)
+	}
+
+	return filterList(tasks, values, filters[*camundaclientgo.UserTaskResponse]{
+		"taskId": func(t *camundaclientgo.UserTaskResponse, value string) bool {
+			return t.Id == value
+		},
+		"processInstanceBusinessKey": func(t *camundaclientgo.UserTaskResponse, value string) bool {
+			return instance(t).BusinessKey == value
+		},
+		"processInstanceBusinessKeyIn": func(t *camundaclientgo.UserTaskResponse, value string) bool {
+			return contains(strings.Split(value, ","), instance(t).BusinessKey)
+		},
+		"processDefinitionKey": func(t *camundaclientgo.UserTaskResponse, value string) bool {
+			return task(t).ProcessDefinitionKey == value
+		},
+		"processDefinitionKeyIn": func(t *camundaclientgo.UserTaskResponse, value string) bool {
+			return contains(strings.Split(value, ","), task(t).ProcessDefinitionKey)
+		},
+		"candidateGroup": func(t *camundaclientgo.UserTaskResponse, value string) bool {
+			return contains(task(t).CandidateGroups, value)
+		},
+		"candidateGroups": func(t *camundaclientgo.UserTaskResponse, value string) bool {
+			for _, group := range strings.Split(value, ",") {
+				if contains(task(t).CandidateGroups, group) {
+					return true
+				}
+			}
+			return false
+		},
+		"candidateUser": func(t *camundaclientgo.UserTaskResponse, value string) bool {
+			return contains(task(t).CandidateUsers, value)
+		},
+		"assigned": boolFilter(func(t *camundaclientgo.UserTaskResponse) bool {
+			return t.Assignee != ""
+		}),
+		"unassigned": boolFilter(func(t *camundaclientgo.UserTaskResponse) bool {
+			return t.Assignee == ""
+		}),
+		"active": boolFilter(func(t *camundaclientgo.UserTaskResponse) bool {
+			return !t.Suspended
+		}),
+		"suspended": boolFilter(func(t *camundaclientgo.UserTaskResponse) bool {
+			return t.Suspended
+		}),
+	})
+}
+
+func (s *Server) getUserTask(w http.ResponseWriter, _ *http.Request, p params) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.openUserTask(p["id"])
+	if t == nil {
+		writeNotFound(w, "No matching task with id %s", p["id"])
+		return
+	}
+
+	writeJson(w, http.StatusOK, s.toUserTaskResponse(t))
+}
+
+func (s *Server) completeUserTask(w http.ResponseWriter, r *http.Request, p params) {
+	req := camundaclientgo.QueryUserTaskComplete{}
+	if err := decodeBody(r, &req); err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.openUserTask(p["id"])
+	if t == nil {
+		writeNotFound(w, "Cannot find task with id %s", p["id"])
+		return
+	}
+
+	s.completeUserTaskWith(t, req.Variables)
+	s.notify()
+	writeNoContent(w)
+}
+
+// completeUserTaskWith completes a user task, s.mu must be held
+func (s *Server) completeUserTaskWith(t *UserTask, variables map[string]camundaclientgo.Variable) {
+	t.State = TaskStateCompleted
+	t.EndTime = s.now()
+	t.Variables = copyVariables(variables)
+
+	instance := s.findInstance(t.ProcessInstanceId)
+	instance.Variables = mergeVariables(instance.Variables, variables)
+	s.continueExecution(t.ProcessInstanceId, t.ExecutionId, t.TaskDefinitionKey)
+}
+
+func (s *Server) getUserTaskIdentityLinks(w http.ResponseWriter, _ *http.Request, p params) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.openUserTask(p["id"])
+	if t == nil {
+		writeNotFound(w, "Cannot find task with id %s", p["id"])
+		return
+	}
+
+	links := []camundaclientgo.IdentityLink{}
+	if t.Assignee != "" {
+		links = append(links, camundaclientgo.IdentityLink{UserId: t.Assignee, Type: identityLinkAssignee})
+	}
+	if t.Owner != "" {
+		links = append(links, camundaclientgo.IdentityLink{UserId: t.Owner, Type: identityLinkOwner})
+	}
+	for _, user := range t.CandidateUsers {
+		links = append(links, camundaclientgo.IdentityLink{UserId: user, Type: identityLinkCandidate})
+	}
+	for _, group := range t.CandidateGroups {
+		links = append(links, camundaclientgo.IdentityLink{GroupId: group, Type: identityLinkCandidate})
+	}
+
+	writeJson(w, http.StatusOK, links)
+}
+
+func (s *Server) addUserTaskIdentityLink(w http.ResponseWriter, r *http.Request, p params) {
+	s.modifyUserTaskIdentityLink(w, r, p, true)
+}
+
+func (s *Server) deleteUserTaskIdentityLink(w http.ResponseWriter, r *http.Request, p params) {
+	s.modifyUserTaskIdentityLink(w, r, p, false)
+}
+
+func (s *Server) modifyUserTaskIdentityLink(w http.ResponseWriter, r *http.Request, p params, add bool) {
+	req := camundaclientgo.ReqIdentityLink{}
+	if err := decodeBody(r, &req); err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.openUserTask(p["id"])
+	if t == nil {
+		writeNotFound(w, "Cannot find task with id %s", p["id"])
+		return
+	}
+
+	userId := req.UserId
+	if !add {
+		userId = ""
+	}
+
+	switch {
+	case req.Type == identityLinkAssignee:
+		t.Assignee = userId
+	case req.Type == identityLinkOwner:
+		t.Owner = userId
+	case req.Type == identityLinkCandidate && req.GroupId != "":
+		t.CandidateGroups = modifyList(t.CandidateGroups, req.GroupId, add)
+	case req.Type == identityLinkCandidate && req.UserId != "":
+		t.CandidateUsers = modifyList(t.CandidateUsers, req.UserId, add)
+	default:
+		writeBadRequest(w, "Identity link type %s with user %s and group %s is not supported", req.Type, req.UserId, req.GroupId)
+		return
+	}
+
+	s.notify()
+	writeNoContent(w)
+}
+
+func (s *Server) getUserTaskVariableData(w http.ResponseWriter, _ *http.Request, p params) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.openUserTask(p["id"])
+	if t == nil {
+		writeNotFound(w, "Cannot find task with id %s", p["id"])
+		return
+	}
+
+	v, ok := s.findInstance(t.ProcessInstanceId).Variables[p["varName"]]
+	if !ok {
+		writeNotFound(w, "task variable with name %s does not exist", p["varName"])
+		return
+	}
+
+	writeVariableData(w, v)
+}
+
+func (s *Server) postUserTaskVariableData(w http.ResponseWriter, r *http.Request, p params) {
+	v, err := readVariableData(r)
+	if err != nil {
+		writeBadRequest(w, "%s", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.openUserTask(p["id"])
+	if t == nil {
+		writeNotFound(w, "Cannot find task with id %s", p["id"])
+		return
+	}
+
+	s.findInstance(t.ProcessInstanceId).Variables[p["varName"]] = v
+	s.notify()
+	writeNoContent(w)
+}
+
+// openUserTask returns a not completed user task by id, s.mu must be held
+func (s *Server) openUserTask(id string) *UserTask {
+	t := s.findUserTask(id)
+	if t == nil || t.State != TaskStateCreated {
+		return nil
+	}
+
+	return t
+}
+
+func (s *Server) toUserTaskResponse(t *UserTask) *camundaclientgo.UserTaskResponse {
+	instance := s.findInstance(t.ProcessInstanceId)
+	res := &camundaclientgo.UserTaskResponse{
+		Id:                  t.Id,
+		Name:                t.Name,
+		Assignee:            t.Assignee,
+		Created:             formatTime(t.Created),
+		Description:         t.Description,
+		ExecutionId:         t.ExecutionId,
+		Owner:               t.Owner,
+		Priority:            int64(t.Priority),
+		ProcessDefinitionId: t.ProcessDefinitionId,
+		ProcessInstanceId:   t.ProcessInstanceId,
+		TaskDefinitionKey:   t.TaskDefinitionKey,
+		Suspended:           instance != nil && instance.State == StateSuspended,
+	}
+	if t.FormKey != "" {
+		formKey := t.FormKey
+		res.FormKey = &formKey
+	}
+	if t.TenantId != "" {
+		tenantId := t.TenantId
+		res.TenantId = &tenantId
+	}
+
+	return res
+}
+
+func modifyList(values []string, value string, add bool) []string {
+	if add {
+		if contains(values, value) {
+			return values
+		}
+		return append(values, value)
+	}
+
+	result := values[:0]
+	for _, v := range values {
+		if v != value {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
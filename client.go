@@ -2,6 +2,7 @@ package camunda_client_go
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -36,15 +37,19 @@ type Client struct {
 	apiUser             string
 	apiPassword         string
 	authorizationHeader string
+	ctx                 context.Context
 
 	ExternalTask      *ExternalTask
 	Deployment        *Deployment
 	ProcessDefinition *ProcessDefinition
 	ProcessInstance   *ProcessInstance
+	Execution         *Execution
 	UserTask          *userTaskApi
 	Message           *Message
 	History           *History
 	Tenant            *Tenant
+	Incident          *Incident
+	Job               *Job
 }
 
 var ErrorNotFound = &Error{
@@ -56,6 +61,8 @@ var ErrorNotFound = &Error{
 type Error struct {
 	Type    string `json:"type"`
 	Message string `json:"message"`
+	// StatusCode is the HTTP status code of the response
+	StatusCode int `json:"-"`
 }
 
 // Error error message
@@ -100,6 +107,7 @@ func NewClient(options ClientOptions) *Client {
 		apiUser:             options.ApiUser,
 		apiPassword:         options.ApiPassword,
 		authorizationHeader: options.AuthorizationHeader,
+		ctx:                 context.Background(),
 	}
 
 	if options.EndpointUrl != "" {
@@ -114,18 +122,40 @@ func NewClient(options ClientOptions) *Client {
 		client.httpClient.Timeout = options.Timeout
 	}
 
-	client.ExternalTask = &ExternalTask{client: client}
-	client.Deployment = &Deployment{client: client}
-	client.ProcessDefinition = &ProcessDefinition{client: client}
-	client.ProcessInstance = &ProcessInstance{client: client}
-	client.UserTask = &userTaskApi{client: client}
-	client.Message = &Message{client: client}
-	client.History = &History{client: client}
-	client.Tenant = &Tenant{client: client}
+	client.initApis()
 
 	return client
 }
 
+// WithContext returns a shallow copy of the client, all requests of which are bound to ctx.
+// Use it to set a deadline or to cancel in-flight requests
+func (c *Client) WithContext(ctx context.Context) *Client {
+	if ctx == nil {
+		panic("nil context")
+	}
+
+	client := new(Client)
+	*client = *c
+	client.ctx = ctx
+	client.initApis()
+
+	return client
+}
+
+func (c *Client) initApis() {
+	c.ExternalTask = &ExternalTask{client: c}
+	c.Deployment = &Deployment{client: c}
+	c.ProcessDefinition = &ProcessDefinition{client: c}
+	c.ProcessInstance = &ProcessInstance{client: c}
+	c.Execution = &Execution{client: c}
+	c.UserTask = &userTaskApi{client: c}
+	c.Message = &Message{client: c}
+	c.History = &History{client: c}
+	c.Tenant = &Tenant{client: c}
+	c.Incident = &Incident{client: c}
+	c.Job = &Job{client: c}
+}
+
 func (c *Client) SetAuthorizationHeader(bearerToken string) {
 	c.authorizationHeader = bearerToken
 }
@@ -178,7 +208,12 @@ func (c *Client) do(method, path string, query map[string]string, body io.Reader
 		return nil, err
 	}
 
-	req, err := http.NewRequest(method, url, body)
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, err
 	}
@@ -220,7 +255,7 @@ func (c *Client) checkResponse(res *http.Response) error {
 			return ErrorNotFound
 		}
 
-		jsonErr := &Error{}
+		jsonErr := &Error{StatusCode: res.StatusCode}
 		err := json.NewDecoder(res.Body).Decode(jsonErr)
 		if err != nil {
 			return fmt.Errorf("response error with status code %d: failed unmarshal error response: %w", res.StatusCode, err)
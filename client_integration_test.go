@@ -6,29 +6,74 @@ package camunda_client_go
 import (
 	"fmt"
 	"os"
+	"testing"
 	"time"
+
+	"github.com/citilinkru/camunda-client-go/v3/camundatest/fixture"
 )
 
+// fixtureFile a golden file of interactions of integration tests with the engine
+const fixtureFile = "testdata/fixtures/integration.json"
+
+// fixtureMode is "record" to record interactions with the engine to fixtureFile and "replay" to serve them
+// from fixtureFile without the engine, the engine is used without recording by default
+var fixtureMode = os.Getenv("CAMUNDA_FIXTURES")
+
 var client *Client
-var logger func(err error)
+var recorder *fixture.Recorder
+
+func TestMain(m *testing.M) {
+	if err := setup(); err != nil {
+		fmt.Fprintf(os.Stderr, "setup integration tests: %s\n", err)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	if recorder != nil {
+		if err := recorder.Save(); err != nil {
+			fmt.Fprintf(os.Stderr, "save fixture: %s\n", err)
+			code = 1
+		}
+	}
+
+	os.Exit(code)
+}
+
+// setup creates the client of the engine at $CAMUNDA_ENDPOINT or localhost:8080 and deploys the test process
+func setup() error {
+	endpoint := os.Getenv("CAMUNDA_ENDPOINT")
+	if endpoint == "" {
+		endpoint = "http://localhost:8080/engine-rest"
+	}
 
-func init() {
 	client = NewClient(ClientOptions{
-		EndpointUrl: "http://localhost:8080/engine-rest",
+		EndpointUrl: endpoint,
 		ApiUser:     "demo",
 		ApiPassword: "demo",
 		Timeout:     time.Second * 10,
 	})
 
-	logger = func(err error) {
-		fmt.Println(err.Error())
+	switch fixtureMode {
+	case "record":
+		recorder = fixture.NewRecorder(fixtureFile, nil, fixture.Options{})
+		client.SetCustomTransport(recorder)
+	case "replay":
+		replayer, err := fixture.NewReplayer(fixtureFile, fixture.Options{})
+		if err != nil {
+			return fmt.Errorf("load fixture: %w", err)
+		}
+		client.SetCustomTransport(replayer)
+	case "":
+	default:
+		return fmt.Errorf("unknown CAMUNDA_FIXTURES %q, must be record or replay", fixtureMode)
 	}
 
 	file, err := os.Open("examples/deployment/HelloWorld.bpmn")
 	if err != nil {
-		fmt.Printf("Error read file: %s\n", err)
-		return
+		return err
 	}
+	defer file.Close()
+
 	_, err = client.Deployment.Create(ReqDeploymentCreate{
 		DeploymentName: "HelloWorldProcessDemo",
 		Resources: map[string]interface{}{
@@ -36,7 +81,15 @@ func init() {
 		},
 	})
 	if err != nil {
-		fmt.Printf("Error deploy process: %s\n", err)
-		return
+		return fmt.Errorf("deploy process: %w", err)
+	}
+
+	return nil
+}
+
+// waitEngine waits for asynchronous processing of the engine, there is nothing to wait for on replay
+func waitEngine(d time.Duration) {
+	if fixtureMode != "replay" {
+		time.Sleep(d)
 	}
 }
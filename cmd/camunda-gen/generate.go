@@ -0,0 +1,340 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/citilinkru/camunda-client-go/v3/bpmn"
+)
+
+// model values of the generated file
+type model struct {
+	Package   string
+	Sources   []string
+	Processes []constant
+	Topics    []*topic
+	Messages  []constant
+	Signals   []constant
+	Errors    []constant
+}
+
+// constant a named string constant
+type constant struct {
+	Name    string
+	Value   string
+	Comment string
+}
+
+// topic a handler of external tasks of a topic
+type topic struct {
+	constant
+	// Tasks are descriptions of external tasks of the topic
+	Tasks   []string
+	Inputs  []*field
+	Outputs []*field
+}
+
+// field a variable of an input or an output struct
+type field struct {
+	Name     string
+	Type     string
+	Variable string
+}
+
+// rootVariable matches an expression which refers to a variable or its property, e.g. ${order.id}
+var rootVariable = regexp.MustCompile(`^\$\{\s*([A-Za-z_][A-Za-z0-9_]*)(\.[A-Za-z0-9_.]+)?\s*\}$`)
+
+// generate returns formatted Go code of the documents, sources are names of the documents for the header
+func generate(pkg string, sources []string, documents []*bpmn.Definitions) ([]byte, error) {
+	m := &model{Package: pkg, Sources: sources}
+	names := newNames()
+	topics := map[string]*topic{}
+	for _, d := range documents {
+		for _, p := range d.Processes {
+			comment := p.Id
+			if p.Name != "" {
+				comment = oneLine(p.Name)
+			}
+			if err := names.add(&m.Processes, "Process", p.Id, comment); err != nil {
+				return nil, err
+			}
+
+			for _, task := range p.ExternalTasks() {
+				if err := m.addTask(names, topics, task); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		for _, message := range d.Messages {
+			if err := names.add(&m.Messages, "Message", message.Name, ""); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, signal := range d.Signals {
+			if err := names.add(&m.Signals, "Signal", signal.Name, ""); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, e := range d.Errors {
+			comment := e.Id
+			if e.Name != "" {
+				comment = oneLine(e.Name)
+			}
+			if err := names.add(&m.Errors, "Error", e.ErrorCode, comment); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sort.Slice(m.Topics, func(i, j int) bool {
+		return m.Topics[i].Value < m.Topics[j].Value
+	})
+
+	buf := &bytes.Buffer{}
+	if err := fileTemplate.Execute(buf, m); err != nil {
+		return nil, err
+	}
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+
+	return code, nil
+}
+
+// addTask adds an external task to the handler of its topic, tasks of a topic share input and output variables
+func (m *model) addTask(names *names, topics map[string]*topic, task *bpmn.FlowNode) error {
+	t := topics[task.Topic]
+	if t == nil {
+		var constants []constant
+		if err := names.add(&constants, "Topic", task.Topic, ""); err != nil {
+			return err
+		}
+
+		t = &topic{constant: constants[0]}
+		t.Name = strings.TrimPrefix(t.Name, "Topic")
+		topics[task.Topic] = t
+		m.Topics = append(m.Topics, t)
+	}
+
+	description := task.Id
+	if task.Name != "" {
+		description = fmt.Sprintf("%s (%s)", oneLine(task.Name), task.Id)
+	}
+	t.Tasks = append(t.Tasks, description+" of "+task.Process.Id)
+
+	if task.InputOutput == nil {
+		return nil
+	}
+
+	for _, p := range task.InputOutput.Inputs {
+		typ := "interface{}"
+		if p.Type == bpmn.ParameterText && !strings.Contains(p.Value, "${") && !strings.Contains(p.Value, "#{") {
+			typ = "string"
+		}
+		if err := addField(&t.Inputs, p.Name, typ); err != nil {
+			return fmt.Errorf("topic %s: %w", task.Topic, err)
+		}
+	}
+
+	for _, p := range task.InputOutput.Outputs {
+		variable := p.Name
+		if match := rootVariable.FindStringSubmatch(p.Value); p.Type == bpmn.ParameterText && match != nil {
+			variable = match[1]
+		}
+		if err := addField(&t.Outputs, variable, "interface{}"); err != nil {
+			return fmt.Errorf("topic %s: %w", task.Topic, err)
+		}
+	}
+
+	return nil
+}
+
+// addField adds a variable to the fields unless it is there, a string field becomes interface{}
+// if another task of the topic maps the variable to a value of unknown type
+func addField(fields *[]*field, variable, typ string) error {
+	name := goName(variable)
+	for _, f := range *fields {
+		if f.Variable == variable {
+			if f.Type != typ {
+				f.Type = "interface{}"
+			}
+			return nil
+		}
+
+		if f.Name == name {
+			return fmt.Errorf("variables %s and %s have the same Go name %s", f.Variable, variable, name)
+		}
+	}
+
+	*fields = append(*fields, &field{Name: name, Type: typ, Variable: variable})
+	return nil
+}
+
+// names checks that different values do not get the same Go name
+type names struct {
+	values map[string]string
+}
+
+func newNames() *names {
+	return &names{values: map[string]string{}}
+}
+
+// add appends a constant of the value unless it was added before
+func (n *names) add(constants *[]constant, prefix, value, comment string) error {
+	if value == "" {
+		return nil
+	}
+
+	name := prefix + goName(value)
+	if other, ok := n.values[name]; ok {
+		if other == value {
+			return nil
+		}
+
+		return fmt.Errorf("%s and %s have the same Go name %s", other, value, name)
+	}
+
+	n.values[name] = value
+	*constants = append(*constants, constant{Name: name, Value: value, Comment: comment})
+	return nil
+}
+
+// goName returns an exported identifier of a name, e.g. HelloWorldProcess of hello-world-process
+func goName(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+
+		if b.Len() == 0 && unicode.IsDigit(r) {
+			b.WriteString("X")
+		}
+
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+
+	if b.Len() == 0 {
+		return "X"
+	}
+
+	return b.String()
+}
+
+// oneLine returns a name of a model element for a comment
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+var fileTemplate = template.Must(template.New("file").Parse(`// Code generated by camunda-gen from {{ range $i, $s := .Sources }}{{ if $i }}, {{ end }}{{ $s }}{{ end }}. DO NOT EDIT.
+
+package {{ .Package }}
+{{ if .Topics }}
+import (
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+	"github.com/citilinkru/camunda-client-go/v3/processor"
+)
+{{ end }}
+{{- with .Processes }}
+// Keys of process definitions
+const (
+{{- range . }}
+	// {{ .Name }} {{ .Comment }}
+	{{ .Name }} = {{ printf "%q" .Value }}
+{{- end }}
+)
+{{ end }}
+{{- with .Topics }}
+// Topics of external tasks
+const (
+{{- range . }}
+	Topic{{ .Name }} = {{ printf "%q" .Value }}
+{{- end }}
+)
+{{ end }}
+{{- with .Messages }}
+// Names of messages
+const (
+{{- range . }}
+	{{ .Name }} = {{ printf "%q" .Value }}
+{{- end }}
+)
+{{ end }}
+{{- with .Signals }}
+// Names of signals
+const (
+{{- range . }}
+	{{ .Name }} = {{ printf "%q" .Value }}
+{{- end }}
+)
+{{ end }}
+{{- with .Errors }}
+// Codes of BPMN errors
+const (
+{{- range . }}
+	// {{ .Name }} {{ .Comment }}
+	{{ .Name }} = {{ printf "%q" .Value }}
+{{- end }}
+)
+{{ end }}
+{{- range .Topics }}
+// {{ .Name }}Input input variables of external tasks of topic {{ .Value }}
+type {{ .Name }}Input struct{{ if .Inputs }} {
+{{- range .Inputs }}
+	{{ .Name }} {{ .Type }} ` + "`" + `camunda:"{{ .Variable }}"` + "`" + `
+{{- end }}
+}{{ else }}{}{{ end }}
+
+// {{ .Name }}Output variables which complete external tasks of topic {{ .Value }}
+type {{ .Name }}Output struct{{ if .Outputs }} {
+{{- range .Outputs }}
+	{{ .Name }} {{ .Type }} ` + "`" + `camunda:"{{ .Variable }},omitempty"` + "`" + `
+{{- end }}
+}{{ else }}{}{{ end }}
+
+// {{ .Name }}Handler handles external tasks of topic {{ .Value }}:
+{{- range .Tasks }}
+//   - {{ . }}
+{{- end }}
+type {{ .Name }}Handler interface {
+	{{ .Name }}(ctx *processor.Context, in {{ .Name }}Input) ({{ .Name }}Output, error)
+}
+
+// Add{{ .Name }}Handler register the handler of topic {{ .Value }}
+func Add{{ .Name }}Handler(p *processor.Processor, handler {{ .Name }}Handler) {
+	processor.AddTypedHandler(p, []*camundaclientgo.QueryFetchAndLockTopic{ {TopicName: Topic{{ .Name }}} }, handler.{{ .Name }})
+}
+{{ end }}
+{{- if .Topics }}
+// Handlers handles external tasks of all topics
+type Handlers interface {
+{{- range .Topics }}
+	{{ .Name }}Handler
+{{- end }}
+}
+
+// AddHandlers register handlers of all topics
+func AddHandlers(p *processor.Processor, handlers Handlers) {
+{{- range .Topics }}
+	Add{{ .Name }}Handler(p, handlers)
+{{- end }}
+}
+{{- end }}
+`))
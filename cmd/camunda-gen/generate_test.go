@@ -0,0 +1,68 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/citilinkru/camunda-client-go/v3/bpmn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_Example(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "helloworld.gen.go")
+	require.NoError(t, run(output, "main", []string{"../../examples/deployment"}))
+
+	generated, err := os.ReadFile(output)
+	require.NoError(t, err)
+	expected, err := os.ReadFile("../../examples/codegen/helloworld.gen.go")
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(generated), "run go generate ./examples/codegen")
+}
+
+func TestGenerate(t *testing.T) {
+	definitions, err := parseFile("testdata/order.bpmn")
+	require.NoError(t, err)
+
+	code, err := generate("workers", []string{"order.bpmn"}, []*bpmn.Definitions{definitions})
+	require.NoError(t, err)
+	for _, expected := range []string{
+		"// Code generated by camunda-gen from order.bpmn. DO NOT EDIT.\n\npackage workers\n",
+		"\tProcessOrder = \"order\"\n",
+		"\tTopicCheckOrder  = \"check-order\"\n\tTopicSendReceipt = \"send-receipt\"\n",
+		"\tMessageOrderPaid = \"order-paid\"\n",
+		"\tSignalCancel = \"cancel\"\n",
+		"\tErrorREJECTED = \"REJECTED\"\n",
+		// country is a constant of check and an expression of recheck
+		"type CheckOrderInput struct {\n\tOrderId interface{} `camunda:\"orderId\"`\n\tCountry interface{} `camunda:\"country\"`\n}\n",
+		// the worker sets result for ${result.approved}, reason of the script
+		"type CheckOrderOutput struct {\n\tResult interface{} `camunda:\"result,omitempty\"`\n\tReason interface{} `camunda:\"reason,omitempty\"`\n}\n",
+		"//   - Check order (check) of order\n//   - recheck of order\n",
+		"\tCheckOrder(ctx *processor.Context, in CheckOrderInput) (CheckOrderOutput, error)\n",
+		"QueryFetchAndLockTopic{{TopicName: TopicSendReceipt}}, handler.SendReceipt)\n",
+		"type Handlers interface {\n\tCheckOrderHandler\n\tSendReceiptHandler\n}\n",
+	} {
+		assert.Contains(t, string(code), expected)
+	}
+}
+
+func TestGenerate_NameConflict(t *testing.T) {
+	definitions, err := bpmn.ParseString(`<definitions>
+		<process id="order">
+			<serviceTask id="a" camunda:type="external" camunda:topic="check-order" xmlns:camunda="http://camunda.org/schema/1.0/bpmn" />
+			<serviceTask id="b" camunda:type="external" camunda:topic="check_order" xmlns:camunda="http://camunda.org/schema/1.0/bpmn" />
+		</process>
+	</definitions>`)
+	require.NoError(t, err)
+
+	_, err = generate("workers", []string{"order.bpmn"}, []*bpmn.Definitions{definitions})
+	assert.EqualError(t, err, "check-order and check_order have the same Go name TopicCheckOrder")
+}
+
+func TestGoName(t *testing.T) {
+	assert.Equal(t, "HelloWorldProcess", goName("hello-world-process"))
+	assert.Equal(t, "OrderId", goName("orderId"))
+	assert.Equal(t, "X2fa", goName("2fa"))
+	assert.Equal(t, "X", goName("--"))
+}
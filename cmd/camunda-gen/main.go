@@ -0,0 +1,115 @@
+// Command camunda-gen generates Go code of BPMN models for external task workers: constants of process definition
+// keys, topics, messages, signals and BPMN error codes, and a typed handler per topic with input and output
+// variables derived from camunda:inputOutput mappings of its external tasks. Handlers are registered with
+// processor.AddTypedHandler by the generated topic constants, so a renamed topic becomes a compile error:
+//
+//	//go:generate go run github.com/citilinkru/camunda-client-go/v3/cmd/camunda-gen -o process.gen.go ../bpmn
+//
+// Arguments are .bpmn files or directories with them. Input variables of text constants are strings,
+// other inputs and outputs are interface{}. An output parameter of an expression like ${approved} or
+// ${order.id} requires the variable it refers to, other output parameters require a variable of their name
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/citilinkru/camunda-client-go/v3/bpmn"
+)
+
+func main() {
+	output := flag.String("o", "", "output file (default: stdout)")
+	pkg := flag.String("package", os.Getenv("GOPACKAGE"), "package of the generated code (default: $GOPACKAGE of go generate)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: camunda-gen [-o file] [-package name] file.bpmn|dir...\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(*output, *pkg, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, "camunda-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(output, pkg string, args []string) error {
+	if pkg == "" {
+		return fmt.Errorf("package is required")
+	}
+
+	files, err := bpmnFiles(args)
+	if err != nil {
+		return err
+	}
+
+	if len(files) == 0 {
+		return fmt.Errorf("no .bpmn files")
+	}
+
+	var sources []string
+	var documents []*bpmn.Definitions
+	for _, file := range files {
+		definitions, err := parseFile(file)
+		if err != nil {
+			return err
+		}
+
+		sources = append(sources, filepath.Base(file))
+		documents = append(documents, definitions)
+	}
+
+	code, err := generate(pkg, sources, documents)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		_, err = os.Stdout.Write(code)
+		return err
+	}
+
+	return os.WriteFile(output, code, 0o644)
+}
+
+// bpmnFiles returns the files and sorted .bpmn files of the directories
+func bpmnFiles(args []string) ([]string, error) {
+	var files []string
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			files = append(files, arg)
+			continue
+		}
+
+		matches, err := filepath.Glob(filepath.Join(arg, "*.bpmn"))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(matches)
+		files = append(files, matches...)
+	}
+
+	return files, nil
+}
+
+func parseFile(path string) (*bpmn.Definitions, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	definitions, err := bpmn.Parse(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return definitions, nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultEndpoint = "http://localhost:8080/engine-rest"
+	defaultTimeout  = 30 * time.Second
+	defaultProfile  = "default"
+)
+
+// config a config file with profiles of engines
+type config struct {
+	// The path of the config file, empty if there is no config file
+	path string
+	// The profile used if none is set by a flag or CAMUNDA_PROFILE
+	CurrentProfile string `yaml:"current-profile"`
+	// Profiles by name
+	Profiles map[string]profile `yaml:"profiles"`
+}
+
+// profile an endpoint and credentials of an engine
+type profile struct {
+	Endpoint string `yaml:"endpoint"`
+	// User and password of basic auth
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	// A bearer token sent instead of basic auth
+	Token string `yaml:"token"`
+	// A timeout of requests, e.g. 30s
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// loadConfig reads the config file of path, $CAMUNDA_CONFIG or camunda/config.yaml in the user config directory.
+// A missing default config file is an empty config
+func loadConfig(path string) (*config, error) {
+	explicit := path != ""
+	if path == "" {
+		path = os.Getenv("CAMUNDA_CONFIG")
+		explicit = path != ""
+	}
+	if path == "" {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return &config{}, nil
+		}
+		path = filepath.Join(dir, "camunda", "config.yaml")
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && !explicit {
+		return &config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+
+	c := &config{path: path}
+	if err := yaml.Unmarshal(content, c); err != nil {
+		return nil, fmt.Errorf("parse config %s: %w", path, err)
+	}
+
+	return c, nil
+}
+
+// resolve returns the profile of name, CAMUNDA_PROFILE or the current profile overridden by environment variables
+func (c *config) resolve(name string) (string, profile, error) {
+	if name == "" {
+		name = os.Getenv("CAMUNDA_PROFILE")
+	}
+	explicit := name != ""
+	if name == "" {
+		name = c.CurrentProfile
+	}
+	if name == "" {
+		name = defaultProfile
+	}
+
+	p, ok := c.Profiles[name]
+	if !ok && (explicit || c.CurrentProfile != "") {
+		if c.path == "" {
+			return "", profile{}, fmt.Errorf("profile %s not found: no config file", name)
+		}
+		return "", profile{}, fmt.Errorf("profile %s not found in %s", name, c.path)
+	}
+
+	for env, value := range map[string]*string{
+		"CAMUNDA_ENDPOINT": &p.Endpoint,
+		"CAMUNDA_USER":     &p.User,
+		"CAMUNDA_PASSWORD": &p.Password,
+		"CAMUNDA_TOKEN":    &p.Token,
+	} {
+		if v := os.Getenv(env); v != "" {
+			*value = v
+		}
+	}
+
+	return name, p, nil
+}
+
+// clientOptions returns options of a client of the profile
+func (p profile) clientOptions() camundaclientgo.ClientOptions {
+	options := camundaclientgo.ClientOptions{
+		EndpointUrl: p.Endpoint,
+		Timeout:     p.Timeout,
+		ApiUser:     p.User,
+		ApiPassword: p.Password,
+	}
+	if options.EndpointUrl == "" {
+		options.EndpointUrl = defaultEndpoint
+	}
+	if options.Timeout == 0 {
+		options.Timeout = defaultTimeout
+	}
+	if p.Token != "" {
+		options.AuthorizationHeader = "Bearer " + p.Token
+	}
+
+	return options
+}
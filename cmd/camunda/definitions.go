@@ -0,0 +1,287 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+)
+
+// profileInfo a profile without secrets
+type profileInfo struct {
+	Name     string `json:"name"`
+	Current  bool   `json:"current"`
+	Endpoint string `json:"endpoint"`
+	User     string `json:"user"`
+	Auth     string `json:"auth"`
+}
+
+func listProfiles(a *app, fs *flag.FlagSet, args []string) error {
+	if _, err := a.parse(fs, args); err != nil {
+		return err
+	}
+
+	config, err := loadConfig(a.configPath)
+	if err != nil {
+		return err
+	}
+
+	current := a.profile
+	if current == "" {
+		current = os.Getenv("CAMUNDA_PROFILE")
+	}
+	if current == "" {
+		current = config.CurrentProfile
+	}
+
+	profiles := make([]profileInfo, 0, len(config.Profiles))
+	for name, p := range config.Profiles {
+		info := profileInfo{Name: name, Current: name == current, Endpoint: p.Endpoint, User: p.User, Auth: "none"}
+		switch {
+		case p.Token != "":
+			info.Auth = "token"
+		case p.User != "":
+			info.Auth = "basic"
+		}
+		profiles = append(profiles, info)
+	}
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+
+	return printList(a, profiles,
+		column[profileInfo]{"CURRENT", func(p profileInfo) string { return mark(p.Current) }},
+		column[profileInfo]{"NAME", func(p profileInfo) string { return p.Name }},
+		column[profileInfo]{"ENDPOINT", func(p profileInfo) string { return p.Endpoint }},
+		column[profileInfo]{"AUTH", func(p profileInfo) string { return p.Auth }},
+		column[profileInfo]{"USER", func(p profileInfo) string { return p.User }},
+	)
+}
+
+func listDefinitions(a *app, fs *flag.FlagSet, args []string) error {
+	key := fs.String("key", "", "only definitions of the key")
+	latest := fs.Bool("latest", false, "only the latest version of each key")
+	suspended := fs.Bool("suspended", false, "only suspended definitions")
+	limit := fs.Int("limit", 100, "maximum number of definitions")
+	if _, err := a.parse(fs, args); err != nil {
+		return err
+	}
+
+	client, err := a.connect()
+	if err != nil {
+		return err
+	}
+
+	query := camundaclientgo.QueryProcessDefinitionList{
+		QuerySorting:    camundaclientgo.QuerySorting{SortBy: "key", SortOrder: camundaclientgo.SortOrderAsc},
+		QueryPagination: camundaclientgo.QueryPagination{MaxResults: *limit},
+		Key:             *key,
+		LatestVersion:   *latest,
+		Suspended:       *suspended,
+	}
+	definitions, err := client.ProcessDefinition.GetList(query.Params())
+	if err != nil {
+		return err
+	}
+
+	return printList(a, definitions,
+		column[*camundaclientgo.ResProcessDefinition]{"ID", func(d *camundaclientgo.ResProcessDefinition) string { return d.Id }},
+		column[*camundaclientgo.ResProcessDefinition]{"KEY", func(d *camundaclientgo.ResProcessDefinition) string { return d.Key }},
+		column[*camundaclientgo.ResProcessDefinition]{"NAME", func(d *camundaclientgo.ResProcessDefinition) string { return d.Name }},
+		column[*camundaclientgo.ResProcessDefinition]{"VERSION", func(d *camundaclientgo.ResProcessDefinition) string { return strconv.Itoa(d.Version) }},
+		column[*camundaclientgo.ResProcessDefinition]{"SUSPENDED", func(d *camundaclientgo.ResProcessDefinition) string { return mark(d.Suspended) }},
+		column[*camundaclientgo.ResProcessDefinition]{"TENANT", func(d *camundaclientgo.ResProcessDefinition) string { return d.TenantId }},
+	)
+}
+
+func describeDefinition(a *app, fs *flag.FlagSet, args []string) error {
+	args, err := a.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return usageError("one id or key is required")
+	}
+
+	client, err := a.connect()
+	if err != nil {
+		return err
+	}
+
+	// ids of process definitions are key:version:uuid, keys can't contain colons
+	by := camundaclientgo.QueryProcessDefinitionBy{Key: &args[0]}
+	if strings.Contains(args[0], ":") {
+		by = camundaclientgo.QueryProcessDefinitionBy{Id: &args[0]}
+	}
+
+	definition, err := client.ProcessDefinition.Get(by)
+	if err != nil {
+		return err
+	}
+
+	return printObject(a, definition)
+}
+
+func suspendDefinition(a *app, fs *flag.FlagSet, args []string) error {
+	return setDefinitionSuspended(a, fs, args, true)
+}
+
+func activateDefinition(a *app, fs *flag.FlagSet, args []string) error {
+	return setDefinitionSuspended(a, fs, args, false)
+}
+
+func setDefinitionSuspended(a *app, fs *flag.FlagSet, args []string, suspended bool) error {
+	instances := fs.Bool("instances", false, "also suspend or activate process instances of the definitions")
+	args, err := a.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return usageError("one key is required")
+	}
+
+	client, err := a.connect()
+	if err != nil {
+		return err
+	}
+
+	err = client.ProcessDefinition.ActivateOrSuspendByKey(camundaclientgo.ReqActivateOrSuspendByKey{
+		ProcessDefinitionKey:    args[0],
+		Suspended:               &suspended,
+		IncludeProcessInstances: instances,
+	})
+	if err != nil {
+		return err
+	}
+
+	a.info("%s process definitions of %s", suspendedState(suspended), args[0])
+	return nil
+}
+
+func deploy(a *app, fs *flag.FlagSet, args []string) error {
+	name := fs.String("name", "", "deployment name (default: the base name of the first file or directory)")
+	source := fs.String("source", "camunda-cli", "deployment source")
+	tenant := fs.String("tenant", "", "tenant id of the deployment")
+	changedOnly := fs.Bool("changed-only", false, "deploy a directory only if it differs from the latest deployment of the name")
+	args, err := a.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return usageError("files or directories are required")
+	}
+
+	if *name == "" {
+		abs, err := filepath.Abs(args[0])
+		if err != nil {
+			return err
+		}
+		*name = strings.TrimSuffix(filepath.Base(abs), filepath.Ext(abs))
+	}
+
+	var tenantId *string
+	if *tenant != "" {
+		tenantId = tenant
+	}
+
+	client, err := a.connect()
+	if err != nil {
+		return err
+	}
+
+	if *changedOnly {
+		if len(args) != 1 {
+			return usageError("-changed-only requires one directory")
+		}
+
+		report, err := client.Deployment.SyncDirectory(a.ctx, args[0], camundaclientgo.SyncOptions{
+			DeploymentName:   *name,
+			DeploymentSource: source,
+			TenantId:         tenantId,
+		})
+		if err != nil {
+			return err
+		}
+
+		if report.Deployment == nil {
+			a.info("%s is up to date with deployment %s", args[0], report.PreviousDeploymentId)
+		}
+		return printObject(a, report)
+	}
+
+	var resources []camundaclientgo.DeploymentResource
+	for _, path := range args {
+		found, err := deploymentResources(path)
+		if err != nil {
+			return err
+		}
+		resources = append(resources, found...)
+	}
+	if len(resources) == 0 {
+		return fmt.Errorf("no resources to deploy in %s", strings.Join(args, ", "))
+	}
+
+	deployment, err := client.Deployment.Create(camundaclientgo.ReqDeploymentCreate{
+		DeploymentName:      *name,
+		DeploymentSource:    source,
+		TenantId:            tenantId,
+		DeploymentResources: resources,
+	})
+	if err != nil {
+		return err
+	}
+
+	return printObject(a, deployment)
+}
+
+// deploymentResources returns a file named by its base name or resources of a directory with
+// DefaultSyncExtensions named by their paths in the directory
+func deploymentResources(path string) ([]camundaclientgo.DeploymentResource, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return []camundaclientgo.DeploymentResource{{Name: filepath.Base(path), Content: bytes.NewReader(content)}}, nil
+	}
+
+	resources, err := camundaclientgo.DeploymentResourcesFS(os.DirFS(path))
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	deployable := resources[:0]
+	for _, resource := range resources {
+		for _, extension := range camundaclientgo.DefaultSyncExtensions {
+			if strings.HasSuffix(resource.Name, extension) {
+				deployable = append(deployable, resource)
+				break
+			}
+		}
+	}
+
+	return deployable, nil
+}
+
+func mark(b bool) string {
+	if b {
+		return "*"
+	}
+	return ""
+}
+
+func suspendedState(suspended bool) string {
+	if suspended {
+		return "suspended"
+	}
+	return "activated"
+}
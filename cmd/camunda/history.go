@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+)
+
+const exportPageSize = 500
+
+func exportHistory(a *app, fs *flag.FlagSet, args []string) error {
+	key := fs.String("key", "", "only history of the process definition key")
+	instance := fs.String("instance", "", "only history of the process instance")
+	finished := fs.Bool("finished", false, "only finished process instances or tasks")
+	limit := fs.Int("limit", 0, "maximum number of exported items (default: all)")
+	args, err := a.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return usageError("one of instances, tasks or variables is required")
+	}
+
+	client, err := a.connect()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "instances":
+		query := camundaclientgo.QueryHistoryProcessInstanceList{
+			QuerySorting:         camundaclientgo.QuerySorting{SortBy: "instanceId", SortOrder: camundaclientgo.SortOrderAsc},
+			ProcessDefinitionKey: *key,
+			ProcessInstanceId:    *instance,
+			Finished:             *finished,
+		}
+		instances, err := exportAll(a.ctx, query.Params(), *limit, client.History.GetProcessInstanceList)
+		if err != nil {
+			return err
+		}
+
+		return printList(a, instances,
+			column[*camundaclientgo.ResHistoryProcessInstance]{"ID", func(p *camundaclientgo.ResHistoryProcessInstance) string { return p.Id }},
+			column[*camundaclientgo.ResHistoryProcessInstance]{"DEFINITION", func(p *camundaclientgo.ResHistoryProcessInstance) string { return p.ProcessDefinitionId }},
+			column[*camundaclientgo.ResHistoryProcessInstance]{"BUSINESS KEY", func(p *camundaclientgo.ResHistoryProcessInstance) string { return p.BusinessKey }},
+			column[*camundaclientgo.ResHistoryProcessInstance]{"STATE", func(p *camundaclientgo.ResHistoryProcessInstance) string { return p.State }},
+			column[*camundaclientgo.ResHistoryProcessInstance]{"START", func(p *camundaclientgo.ResHistoryProcessInstance) string { return p.StartTime }},
+			column[*camundaclientgo.ResHistoryProcessInstance]{"END", func(p *camundaclientgo.ResHistoryProcessInstance) string { return p.EndTime }},
+		)
+	case "tasks":
+		query := camundaclientgo.QueryHistoryTaskList{
+			QuerySorting:         camundaclientgo.QuerySorting{SortBy: "taskId", SortOrder: camundaclientgo.SortOrderAsc},
+			ProcessDefinitionKey: *key,
+			ProcessInstanceId:    *instance,
+			Finished:             *finished,
+		}
+		tasks, err := exportAll(a.ctx, query.Params(), *limit, client.History.GetTaskList)
+		if err != nil {
+			return err
+		}
+
+		return printList(a, tasks,
+			column[*camundaclientgo.ResHistoryTaskInstance]{"ID", func(t *camundaclientgo.ResHistoryTaskInstance) string { return t.Id }},
+			column[*camundaclientgo.ResHistoryTaskInstance]{"NAME", func(t *camundaclientgo.ResHistoryTaskInstance) string { return t.Name }},
+			column[*camundaclientgo.ResHistoryTaskInstance]{"PROCESS INSTANCE", func(t *camundaclientgo.ResHistoryTaskInstance) string { return t.ProcessInstanceId }},
+			column[*camundaclientgo.ResHistoryTaskInstance]{"ASSIGNEE", func(t *camundaclientgo.ResHistoryTaskInstance) string { return t.Assignee }},
+			column[*camundaclientgo.ResHistoryTaskInstance]{"START", func(t *camundaclientgo.ResHistoryTaskInstance) string { return t.StartTime }},
+			column[*camundaclientgo.ResHistoryTaskInstance]{"END", func(t *camundaclientgo.ResHistoryTaskInstance) string { return t.EndTime }},
+		)
+	case "variables":
+		if *finished {
+			return usageError("-finished is not supported by variables")
+		}
+
+		query := camundaclientgo.QueryHistoryVariableInstanceList{
+			QuerySorting:      camundaclientgo.QuerySorting{SortBy: "instanceId", SortOrder: camundaclientgo.SortOrderAsc},
+			ProcessInstanceId: *instance,
+		}
+		params := query.Params()
+		if *key != "" {
+			params["processDefinitionKey"] = *key
+		}
+		variables, err := exportAll(a.ctx, params, *limit, client.History.GetVariableInstanceList)
+		if err != nil {
+			return err
+		}
+
+		return printList(a, variables,
+			column[*camundaclientgo.ResHistoryVariableInstance]{"ID", func(v *camundaclientgo.ResHistoryVariableInstance) string { return v.Id }},
+			column[*camundaclientgo.ResHistoryVariableInstance]{"NAME", func(v *camundaclientgo.ResHistoryVariableInstance) string { return v.Name }},
+			column[*camundaclientgo.ResHistoryVariableInstance]{"TYPE", func(v *camundaclientgo.ResHistoryVariableInstance) string { return v.Type }},
+			column[*camundaclientgo.ResHistoryVariableInstance]{"VALUE", func(v *camundaclientgo.ResHistoryVariableInstance) string { return formatValue(v.Value) }},
+			column[*camundaclientgo.ResHistoryVariableInstance]{"PROCESS INSTANCE", func(v *camundaclientgo.ResHistoryVariableInstance) string { return v.ProcessInstanceId }},
+		)
+	default:
+		return usageError(fmt.Sprintf("unknown history %q, use instances, tasks or variables", args[0]))
+	}
+}
+
+// exportAll fetches items of the query by pages of exportPageSize up to limit, all items if limit is 0
+func exportAll[T any](ctx context.Context, query map[string]string, limit int, getList func(query map[string]string) ([]T, error)) ([]T, error) {
+	pager := camundaclientgo.NewPager(func(ctx context.Context, firstResult, maxResults int) ([]T, error) {
+		page := make(map[string]string, len(query)+2)
+		for name, value := range query {
+			page[name] = value
+		}
+		page["firstResult"] = strconv.Itoa(firstResult)
+		page["maxResults"] = strconv.Itoa(maxResults)
+
+		return getList(page)
+	}, exportPageSize)
+
+	items, err := camundaclientgo.CollectAll(ctx, pager, limit)
+	if errors.Is(err, camundaclientgo.ErrCollectLimit) {
+		return items, nil
+	}
+
+	return items, err
+}
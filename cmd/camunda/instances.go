@@ -0,0 +1,264 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+)
+
+func listInstances(a *app, fs *flag.FlagSet, args []string) error {
+	key := fs.String("key", "", "only instances of the process definition key")
+	businessKey := fs.String("business-key", "", "only instances of the business key")
+	incidents := fs.Bool("incidents", false, "only instances with incidents")
+	suspended := fs.Bool("suspended", false, "only suspended instances")
+	limit := fs.Int("limit", 100, "maximum number of instances")
+	if _, err := a.parse(fs, args); err != nil {
+		return err
+	}
+
+	client, err := a.connect()
+	if err != nil {
+		return err
+	}
+
+	query := camundaclientgo.QueryProcessInstanceList{
+		QuerySorting:         camundaclientgo.QuerySorting{SortBy: "instanceId", SortOrder: camundaclientgo.SortOrderAsc},
+		QueryPagination:      camundaclientgo.QueryPagination{MaxResults: *limit},
+		ProcessDefinitionKey: *key,
+		BusinessKey:          *businessKey,
+		WithIncident:         *incidents,
+		Suspended:            *suspended,
+	}
+	instances, err := client.ProcessInstance.GetList(query.Params())
+	if err != nil {
+		return err
+	}
+
+	return printList(a, instances,
+		column[*camundaclientgo.ResProcessInstance]{"ID", func(p *camundaclientgo.ResProcessInstance) string { return p.Id }},
+		column[*camundaclientgo.ResProcessInstance]{"DEFINITION", func(p *camundaclientgo.ResProcessInstance) string { return p.DefinitionId }},
+		column[*camundaclientgo.ResProcessInstance]{"BUSINESS KEY", func(p *camundaclientgo.ResProcessInstance) string { return p.BusinessKey }},
+		column[*camundaclientgo.ResProcessInstance]{"SUSPENDED", func(p *camundaclientgo.ResProcessInstance) string { return mark(p.Suspended) }},
+		column[*camundaclientgo.ResProcessInstance]{"TENANT", func(p *camundaclientgo.ResProcessInstance) string { return p.TenantId }},
+	)
+}
+
+// instanceDetails a process instance with its variables
+type instanceDetails struct {
+	*camundaclientgo.ResProcessInstance
+	Variables map[string]*camundaclientgo.ResProcessVariable `json:"variables"`
+}
+
+func describeInstance(a *app, fs *flag.FlagSet, args []string) error {
+	args, err := a.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return usageError("one id is required")
+	}
+
+	client, err := a.connect()
+	if err != nil {
+		return err
+	}
+
+	instance, err := client.ProcessInstance.Get(args[0])
+	if err != nil {
+		return err
+	}
+
+	variables, err := client.ProcessInstance.GetProcessVariableList(args[0], map[string]string{"deserializeValues": "false"})
+	if err != nil {
+		return fmt.Errorf("get variables: %w", err)
+	}
+
+	return printObject(a, instanceDetails{ResProcessInstance: instance, Variables: variables})
+}
+
+func suspendInstances(a *app, fs *flag.FlagSet, args []string) error {
+	return setInstancesSuspended(a, fs, args, true)
+}
+
+func activateInstances(a *app, fs *flag.FlagSet, args []string) error {
+	return setInstancesSuspended(a, fs, args, false)
+}
+
+func setInstancesSuspended(a *app, fs *flag.FlagSet, args []string, suspended bool) error {
+	args, err := a.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return usageError("ids are required")
+	}
+
+	client, err := a.connect()
+	if err != nil {
+		return err
+	}
+
+	for _, id := range args {
+		err := client.ProcessInstance.ActivateSuspend(id, camundaclientgo.ReqProcessInstanceActivateSuspend{Suspended: suspended})
+		if err != nil {
+			return fmt.Errorf("process instance %s: %w", id, err)
+		}
+
+		a.info("%s process instance %s", suspendedState(suspended), id)
+	}
+
+	return nil
+}
+
+func deleteInstances(a *app, fs *flag.FlagSet, args []string) error {
+	key := fs.String("key", "", "delete instances of the process definition key")
+	businessKey := fs.String("business-key", "", "delete instances of the business key")
+	reason := fs.String("reason", "", "delete reason")
+	skipListeners := fs.Bool("skip-listeners", false, "skip custom execution listeners")
+	yes := fs.Bool("yes", false, "delete the instances, otherwise only their number is printed")
+	args, err := a.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 0 {
+		return usageError("unexpected arguments, use -key or -business-key")
+	}
+	if *key == "" && *businessKey == "" {
+		return usageError("-key or -business-key is required")
+	}
+
+	client, err := a.connect()
+	if err != nil {
+		return err
+	}
+
+	query := &camundaclientgo.ReqProcessInstanceQuery{}
+	if *key != "" {
+		query.ProcessDefinitionKey = key
+	}
+	if *businessKey != "" {
+		query.BusinessKey = businessKey
+	}
+
+	count, err := client.ProcessInstance.GetCountPost(*query)
+	if err != nil {
+		return err
+	}
+
+	if !*yes {
+		a.info("%d process instances match, run with -yes to delete them", count)
+		return nil
+	}
+	if count == 0 {
+		a.info("no process instances match")
+		return nil
+	}
+
+	req := camundaclientgo.ReqDeleteProcessInstance{ProcessInstanceQuery: query, SkipCustomListeners: skipListeners}
+	if *reason != "" {
+		req.DeleteReason = reason
+	}
+
+	batch, err := client.ProcessInstance.DeleteAsync(req)
+	if err != nil {
+		return err
+	}
+
+	a.info("deleting %d process instances", count)
+	return printObject(a, batch)
+}
+
+func startInstance(a *app, fs *flag.FlagSet, args []string) error {
+	businessKey := fs.String("business-key", "", "business key of the instance")
+	tenant := fs.String("tenant", "", "tenant id of the process definition")
+	vars := fs.String("vars", "", "variables as a JSON object or @file, see -help")
+	fs.Usage = withVariablesHelp(fs)
+	args, err := a.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return usageError("one process definition key is required")
+	}
+
+	variables, err := parseVariables(*vars)
+	if err != nil {
+		return err
+	}
+
+	client, err := a.connect()
+	if err != nil {
+		return err
+	}
+
+	by := camundaclientgo.QueryProcessDefinitionBy{Key: &args[0]}
+	if *tenant != "" {
+		by.TenantId = tenant
+	}
+
+	req := camundaclientgo.ReqStartInstance{}
+	if *businessKey != "" {
+		req.BusinessKey = businessKey
+	}
+	if variables != nil {
+		req.Variables = &variables
+	}
+
+	instance, err := client.ProcessDefinition.StartInstance(by, req)
+	if err != nil {
+		return err
+	}
+
+	return printObject(a, instance)
+}
+
+func correlateMessage(a *app, fs *flag.FlagSet, args []string) error {
+	businessKey := fs.String("business-key", "", "business key of the process instance")
+	vars := fs.String("vars", "", "process variables as a JSON object or @file, see -help")
+	fs.Usage = withVariablesHelp(fs)
+	args, err := a.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return usageError("one message name is required")
+	}
+
+	variables, err := parseVariables(*vars)
+	if err != nil {
+		return err
+	}
+
+	client, err := a.connect()
+	if err != nil {
+		return err
+	}
+
+	req := &camundaclientgo.ReqMessage{MessageName: args[0], BusinessKey: *businessKey}
+	if variables != nil {
+		req.ProcessVariables = &variables
+	}
+
+	if err := client.Message.SendMessage(req); err != nil {
+		return err
+	}
+
+	a.info("correlated message %s", args[0])
+	return nil
+}
+
+// withVariablesHelp returns the usage of fs followed by the format of variables
+func withVariablesHelp(fs *flag.FlagSet) func() {
+	usage := fs.Usage
+	return func() {
+		usage()
+		fmt.Fprint(fs.Output(), `
+Variables are a JSON object, types are inferred from values: strings are String, booleans are Boolean,
+integers are Integer or Long, other numbers are Double, null is Null, objects and arrays are Json.
+An object with a value and a type is passed as is:
+
+  -vars '{"amount": 10, "due": {"value": "2024-01-01T00:00:00.000+0000", "type": "Date"}}'
+`)
+	}
+}
@@ -0,0 +1,265 @@
+// Command camunda is a command-line tool for day-to-day operations of a Camunda engine over its REST API:
+//
+//	camunda definitions list -latest
+//	camunda deploy -name orders ./bpmn
+//	camunda start -business-key 42 -vars '{"amount": 10}' order
+//	camunda correlate -business-key 42 order-paid
+//	camunda tasks failed -topic send-receipt
+//	camunda tasks retry -topic send-receipt
+//	camunda incidents resolve 5e8c1b0a-...
+//	camunda instances delete -key order -reason cleanup -yes
+//	camunda history export -key order -finished -o json instances
+//
+// The endpoint and credentials are taken from a profile of the config file ($CAMUNDA_CONFIG or camunda/config.yaml
+// in the user config directory):
+//
+//	current-profile: local
+//	profiles:
+//	  local:
+//	    endpoint: http://localhost:8080/engine-rest
+//	    user: demo
+//	    password: demo
+//	  prod:
+//	    endpoint: https://camunda.example.com/engine-rest
+//	    token: eyJhbGciOi...
+//	    timeout: 1m
+//
+// and can be overridden by CAMUNDA_PROFILE, CAMUNDA_ENDPOINT, CAMUNDA_USER, CAMUNDA_PASSWORD and CAMUNDA_TOKEN
+// or by the flags -profile, -endpoint, -user and -password. Results are printed as a table, JSON or YAML (-o)
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+)
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// command a command of the tool, run defines flags of the command on fs and parses args with app.parse
+type command struct {
+	name    string
+	args    string
+	summary string
+	run     func(a *app, fs *flag.FlagSet, args []string) error
+}
+
+var commands = []command{
+	{"profiles", "", "list profiles of the config file", listProfiles},
+	{"definitions list", "", "list process definitions", listDefinitions},
+	{"definitions describe", "<id|key>", "show a process definition, the latest version of a key", describeDefinition},
+	{"definitions suspend", "<key>", "suspend process definitions of a key", suspendDefinition},
+	{"definitions activate", "<key>", "activate process definitions of a key", activateDefinition},
+	{"deploy", "<file|dir>...", "deploy BPMN, DMN, CMMN models, forms and scripts", deploy},
+	{"instances list", "", "list process instances", listInstances},
+	{"instances describe", "<id>", "show a process instance with its variables", describeInstance},
+	{"instances suspend", "<id>...", "suspend process instances", suspendInstances},
+	{"instances activate", "<id>...", "activate process instances", activateInstances},
+	{"instances delete", "", "delete process instances of a query asynchronously", deleteInstances},
+	{"start", "<key>", "start a process instance of the latest version of a process definition", startInstance},
+	{"correlate", "<message>", "correlate a message", correlateMessage},
+	{"tasks failed", "", "list external tasks without retries left", listFailedTasks},
+	{"tasks retry", "[id...]", "set retries of failed external tasks", retryTasks},
+	{"incidents list", "", "list incidents", listIncidents},
+	{"incidents resolve", "<id>...", "resolve incidents, retrying failed jobs and external tasks", resolveIncidents},
+	{"history export", "<instances|tasks|variables>", "export historic process instances, tasks or variables", exportHistory},
+}
+
+// run runs the command of args and returns the exit code
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	a := &app{ctx: ctx, stdout: stdout, stderr: stderr}
+	global := flag.NewFlagSet("camunda", flag.ContinueOnError)
+	global.SetOutput(stderr)
+	a.globalFlags(global)
+	global.Usage = func() { usage(stderr, global) }
+	if err := global.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	cmd, rest, ok := findCommand(global.Args())
+	if !ok {
+		usage(stderr, global)
+		return 2
+	}
+
+	fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: camunda %s [flags] %s\n\n%s\n\nFlags:\n", cmd.name, cmd.args, cmd.summary)
+		fs.PrintDefaults()
+	}
+	a.globalFlags(fs)
+	if err := cmd.run(a, fs, rest); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		if errors.Is(err, errInvalidFlags) {
+			return 2
+		}
+
+		var usageErr usageError
+		if errors.As(err, &usageErr) {
+			fmt.Fprintf(stderr, "camunda %s: %s\n", cmd.name, err)
+			fs.Usage()
+			return 2
+		}
+
+		fmt.Fprintf(stderr, "camunda %s: %s\n", cmd.name, err)
+		return 1
+	}
+
+	return 0
+}
+
+// findCommand returns the command of the first one or two args and the rest of args
+func findCommand(args []string) (command, []string, bool) {
+	for _, n := range []int{2, 1} {
+		if len(args) < n {
+			continue
+		}
+
+		name := strings.Join(args[:n], " ")
+		for _, cmd := range commands {
+			if cmd.name == name {
+				return cmd, args[n:], true
+			}
+		}
+	}
+
+	return command{}, nil, false
+}
+
+func usage(w io.Writer, global *flag.FlagSet) {
+	fmt.Fprintf(w, "Usage: camunda [flags] <command> [flags] [args]\n\nCommands:\n")
+	sorted := append([]command(nil), commands...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].name < sorted[j].name })
+	width := 0
+	for _, cmd := range sorted {
+		if len(cmd.name) > width {
+			width = len(cmd.name)
+		}
+	}
+	for _, cmd := range sorted {
+		fmt.Fprintf(w, "  %-*s  %s\n", width, cmd.name, cmd.summary)
+	}
+
+	fmt.Fprintf(w, "\nFlags:\n")
+	global.PrintDefaults()
+}
+
+// errInvalidFlags an error of parsing flags of a command
+var errInvalidFlags = errors.New("invalid flags")
+
+// usageError an error of command-line arguments, printed with the usage of the command
+type usageError string
+
+func (e usageError) Error() string {
+	return string(e)
+}
+
+// app a state of a run shared by commands
+type app struct {
+	ctx    context.Context
+	stdout io.Writer
+	stderr io.Writer
+
+	format     string
+	configPath string
+	profile    string
+	endpoint   string
+	user       string
+	password   string
+
+	client *camundaclientgo.Client
+}
+
+// globalFlags defines flags accepted before and after a command
+func (a *app) globalFlags(fs *flag.FlagSet) {
+	if a.format == "" {
+		a.format = formatTable
+	}
+
+	fs.StringVar(&a.format, "o", a.format, "output format: table, json or yaml")
+	fs.StringVar(&a.configPath, "config", a.configPath, "config file (default: $CAMUNDA_CONFIG or camunda/config.yaml in the user config directory)")
+	fs.StringVar(&a.profile, "profile", a.profile, "profile of the config file (default: $CAMUNDA_PROFILE or current-profile)")
+	fs.StringVar(&a.endpoint, "endpoint", a.endpoint, "REST API endpoint, overrides the profile")
+	fs.StringVar(&a.user, "user", a.user, "user of basic auth, overrides the profile")
+	fs.StringVar(&a.password, "password", a.password, "password of basic auth, overrides the profile")
+}
+
+// parse parses flags of fs which may be mixed with positional args and returns the positional args
+func (a *app) parse(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			// the flag set has printed the error with the usage
+			return nil, errInvalidFlags
+		}
+
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+
+	switch a.format {
+	case formatTable, formatJson, formatYaml:
+	default:
+		return nil, usageError(fmt.Sprintf("unknown output format %q", a.format))
+	}
+
+	return positional, nil
+}
+
+// connect returns a client of the resolved profile
+func (a *app) connect() (*camundaclientgo.Client, error) {
+	if a.client != nil {
+		return a.client, nil
+	}
+
+	config, err := loadConfig(a.configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	_, profile, err := config.resolve(a.profile)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.endpoint != "" {
+		profile.Endpoint = a.endpoint
+	}
+	if a.user != "" {
+		profile.User = a.user
+	}
+	if a.password != "" {
+		profile.Password = a.password
+	}
+
+	a.client = camundaclientgo.NewClient(profile.clientOptions()).WithContext(a.ctx)
+	return a.client, nil
+}
+
+// info prints a message about a performed action
+func (a *app) info(format string, args ...interface{}) {
+	fmt.Fprintf(a.stderr, format+"\n", args...)
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+	"github.com/citilinkru/camunda-client-go/v3/camundatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// camunda runs the tool with args against the server and returns stdout, stderr and the exit code
+func camunda(t *testing.T, server *camundatest.Server, args ...string) (string, string, int) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	args = append([]string{"-endpoint", server.URL + camundatest.BasePath}, args...)
+	code := run(context.Background(), args, &stdout, &stderr)
+	return stdout.String(), stderr.String(), code
+}
+
+func TestRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+	t.Setenv("CAMUNDA_CONFIG", path)
+	server := camundatest.NewServer()
+	defer server.Close()
+
+	_, stderr, code := camunda(t, server, "deploy", "../../examples/deployment")
+	require.Equal(t, 0, code, stderr)
+	require.Len(t, server.ProcessDefinitions(), 1)
+
+	stdout, stderr, code := camunda(t, server, "definitions", "list", "-latest")
+	require.Equal(t, 0, code, stderr)
+	assert.Regexp(t, `ID\s+KEY\s+NAME\s+VERSION\s+SUSPENDED\s+TENANT\n`, stdout)
+	assert.Regexp(t, `hello-world-process\s+Hello World Process\s+1\s+\n`, stdout)
+
+	stdout, stderr, code = camunda(t, server, "start", "-business-key", "42", "-vars", `{"amount": 10, "note": "rush", "isWorld": false}`, "hello-world-process", "-o", "json")
+	require.Equal(t, 0, code, stderr)
+	var started camundaclientgo.ResStartedProcessDefinition
+	require.NoError(t, json.Unmarshal([]byte(stdout), &started))
+	server.AssertVariable(t, started.Id, "amount", int64(10))
+
+	stdout, stderr, code = camunda(t, server, "instances", "describe", started.Id, "-o", "yaml")
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "businessKey: \"42\"\n")
+	assert.Contains(t, stdout, "  note:\n    type: String\n    value: rush\n")
+
+	client := server.Client()
+	tasks, err := client.ExternalTask.FetchAndLock(camundaclientgo.QueryFetchAndLock{
+		WorkerId: "worker",
+		MaxTasks: 1,
+		Topics:   []*camundaclientgo.QueryFetchAndLockTopic{{TopicName: "PrintHello", LockDuration: 1000}},
+	})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	workerId, message, retries := "worker", "printer is out of paper", 0
+	require.NoError(t, client.ExternalTask.HandleFailure(tasks[0].Id, camundaclientgo.QueryHandleFailure{
+		WorkerId: &workerId, ErrorMessage: &message, Retries: &retries,
+	}))
+
+	stdout, stderr, code = camunda(t, server, "tasks", "failed")
+	require.Equal(t, 0, code, stderr)
+	assert.Regexp(t, tasks[0].Id+`\s+PrintHello\s+`+started.Id+`.*printer is out of paper`, stdout)
+
+	stdout, stderr, code = camunda(t, server, "incidents", "list", "-type", "failedExternalTask", "-o", "json")
+	require.Equal(t, 0, code, stderr)
+	var incidents []camundaclientgo.ResIncident
+	require.NoError(t, json.Unmarshal([]byte(stdout), &incidents))
+	require.Len(t, incidents, 1)
+
+	_, stderr, code = camunda(t, server, "incidents", "resolve", incidents[0].Id)
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stderr, "resolved incident "+incidents[0].Id)
+	server.AssertNoIncidents(t)
+	task, _ := server.ExternalTask(tasks[0].Id)
+	require.NotNil(t, task.Retries)
+	assert.Equal(t, 1, *task.Retries)
+
+	_, stderr, code = camunda(t, server, "correlate", "-business-key", "42", "order-paid")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "camunda correlate: org.camunda.bpm.engine.MismatchingMessageCorrelationException")
+
+	_, stderr, code = camunda(t, server, "instances", "suspend", started.Id)
+	require.Equal(t, 0, code, stderr)
+	instance, _ := server.ProcessInstance(started.Id)
+	assert.Equal(t, camundatest.StateSuspended, instance.State)
+
+	_, stderr, code = camunda(t, server, "instances", "activate", started.Id)
+	require.Equal(t, 0, code, stderr)
+
+	_, stderr, code = camunda(t, server, "instances", "delete", "-key", "hello-world-process")
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, "1 process instances match, run with -yes to delete them\n", stderr)
+	server.AssertProcessInstanceActive(t, started.Id)
+
+	_, stderr, code = camunda(t, server, "instances", "delete", "-key", "hello-world-process", "-reason", "cleanup", "-yes")
+	require.Equal(t, 0, code, stderr)
+	server.AssertProcessInstanceEnded(t, started.Id)
+
+	stdout, stderr, code = camunda(t, server, "history", "export", "-key", "hello-world-process", "-finished", "-o", "json", "instances")
+	require.Equal(t, 0, code, stderr)
+	var history []camundaclientgo.ResHistoryProcessInstance
+	require.NoError(t, json.Unmarshal([]byte(stdout), &history))
+	require.Len(t, history, 1)
+	assert.Equal(t, started.Id, history[0].Id)
+	assert.Equal(t, "cleanup", history[0].DeleteReason)
+}
+
+func TestRun_Usage(t *testing.T) {
+	server := camundatest.NewServer()
+	defer server.Close()
+
+	_, stderr, code := camunda(t, server, "unknown")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "  incidents resolve     resolve incidents, retrying failed jobs and external tasks\n")
+
+	_, stderr, code = camunda(t, server, "instances", "delete")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "camunda instances delete: -key or -business-key is required\nUsage: camunda instances delete")
+
+	_, stderr, code = camunda(t, server, "definitions", "list", "-o", "xml")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, `unknown output format "xml"`)
+}
+
+func TestConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`current-profile: local
+profiles:
+  local:
+    endpoint: http://localhost:8080/engine-rest
+    user: demo
+    password: demo
+  prod:
+    endpoint: https://camunda.example.com/engine-rest
+    token: secret
+    timeout: 1m
+`), 0o600))
+	for _, env := range []string{"CAMUNDA_PROFILE", "CAMUNDA_ENDPOINT", "CAMUNDA_USER", "CAMUNDA_PASSWORD", "CAMUNDA_TOKEN"} {
+		t.Setenv(env, "")
+	}
+
+	config, err := loadConfig(path)
+	require.NoError(t, err)
+
+	name, p, err := config.resolve("")
+	require.NoError(t, err)
+	assert.Equal(t, "local", name)
+	assert.Equal(t, "demo", p.clientOptions().ApiUser)
+
+	t.Setenv("CAMUNDA_PROFILE", "prod")
+	t.Setenv("CAMUNDA_ENDPOINT", "https://camunda-2.example.com/engine-rest")
+	name, p, err = config.resolve("")
+	require.NoError(t, err)
+	assert.Equal(t, "prod", name)
+	options := p.clientOptions()
+	assert.Equal(t, "https://camunda-2.example.com/engine-rest", options.EndpointUrl)
+	assert.Equal(t, "Bearer secret", options.AuthorizationHeader)
+	assert.Equal(t, time.Minute, options.Timeout)
+
+	_, _, err = config.resolve("staging")
+	assert.EqualError(t, err, "profile staging not found in "+path)
+
+	_, err = loadConfig(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+
+	t.Setenv("CAMUNDA_PROFILE", "")
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"-config", path, "profiles"}, &stdout, &stderr)
+	require.Equal(t, 0, code, stderr.String())
+	assert.Regexp(t, `\*\s+local\s+http://localhost:8080/engine-rest\s+basic\s+demo\n`, stdout.String())
+	assert.NotContains(t, stdout.String(), "secret")
+}
+
+func TestParseVariables(t *testing.T) {
+	variables, err := parseVariables(`{"s": "a", "b": true, "i": 1, "l": 3000000000, "d": 1.5, "n": null,
+		"j": {"a": [1]}, "date": {"value": "2024-01-01T00:00:00.000+0000", "type": "Date"}}`)
+	require.NoError(t, err)
+	assert.Equal(t, camundaclientgo.Variable{Value: "a", Type: "String"}, variables["s"])
+	assert.Equal(t, camundaclientgo.Variable{Value: true, Type: "Boolean"}, variables["b"])
+	assert.Equal(t, camundaclientgo.Variable{Value: int64(1), Type: "Integer"}, variables["i"])
+	assert.Equal(t, camundaclientgo.Variable{Value: int64(3000000000), Type: "Long"}, variables["l"])
+	assert.Equal(t, camundaclientgo.Variable{Value: 1.5, Type: "Double"}, variables["d"])
+	assert.Equal(t, camundaclientgo.Variable{Type: "Null"}, variables["n"])
+	assert.Equal(t, camundaclientgo.Variable{Value: `{"a": [1]}`, Type: "Json"}, variables["j"])
+	assert.Equal(t, camundaclientgo.Variable{Value: "2024-01-01T00:00:00.000+0000", Type: "Date"}, variables["date"])
+
+	path := filepath.Join(t.TempDir(), "vars.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"amount": 10}`), 0o644))
+	variables, err = parseVariables("@" + path)
+	require.NoError(t, err)
+	assert.Equal(t, "Integer", variables["amount"].Type)
+
+	_, err = parseVariables(`[1]`)
+	assert.Error(t, err)
+}
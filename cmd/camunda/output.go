@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	formatTable = "table"
+	formatJson  = "json"
+	formatYaml  = "yaml"
+)
+
+// column a column of a table of items of type T
+type column[T any] struct {
+	header string
+	value  func(item T) string
+}
+
+// printList prints items as a table of the columns or as a JSON or YAML array
+func printList[T any](a *app, items []T, columns ...column[T]) error {
+	if a.format != formatTable {
+		if items == nil {
+			items = []T{}
+		}
+		return a.encode(items)
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	headers := make([]string, len(columns))
+	for i, c := range columns {
+		headers[i] = c.header
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+
+	for _, item := range items {
+		values := make([]string, len(columns))
+		for i, c := range columns {
+			values[i] = oneLine(c.value(item))
+		}
+		fmt.Fprintln(w, strings.Join(values, "\t"))
+	}
+
+	return w.Flush()
+}
+
+// printObject prints non-empty fields of v by their JSON names as a table or v as JSON or YAML
+func printObject(a *app, v interface{}) error {
+	if a.format != formatTable {
+		return a.encode(v)
+	}
+
+	var fields map[string]interface{}
+	if err := roundTrip(v, &fields); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(fields))
+	for name, value := range fields {
+		if !isEmpty(value) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	w := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	for _, name := range names {
+		fmt.Fprintf(w, "%s:\t%s\n", name, oneLine(formatValue(fields[name])))
+	}
+
+	return w.Flush()
+}
+
+// encode prints v as JSON or YAML with names of JSON fields
+func (a *app) encode(v interface{}) error {
+	if a.format == formatJson {
+		encoder := json.NewEncoder(a.stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+
+	var value interface{}
+	if err := roundTrip(v, &value); err != nil {
+		return err
+	}
+
+	encoder := yaml.NewEncoder(a.stdout)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(value); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
+// roundTrip decodes the JSON encoding of v into out
+func roundTrip(v interface{}, out interface{}) error {
+	content, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	return decoder.Decode(out)
+}
+
+// formatValue formats a decoded JSON value, objects and arrays as compact JSON
+func formatValue(v interface{}) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case map[string]interface{}, []interface{}:
+		content, _ := json.Marshal(x)
+		return string(content)
+	default:
+		return fmt.Sprint(x)
+	}
+}
+
+func isEmpty(v interface{}) bool {
+	switch x := v.(type) {
+	case nil:
+		return true
+	case string:
+		return x == ""
+	case map[string]interface{}:
+		return len(x) == 0
+	case []interface{}:
+		return len(x) == 0
+	default:
+		return false
+	}
+}
+
+// oneLine replaces line breaks and tabs which would break a table
+func oneLine(s string) string {
+	return strings.NewReplacer("\r\n", " ", "\n", " ", "\t", " ").Replace(s)
+}
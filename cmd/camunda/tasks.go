@@ -0,0 +1,173 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+)
+
+const (
+	incidentTypeFailedJob          = "failedJob"
+	incidentTypeFailedExternalTask = "failedExternalTask"
+)
+
+func listFailedTasks(a *app, fs *flag.FlagSet, args []string) error {
+	topic := fs.String("topic", "", "only tasks of the topic")
+	limit := fs.Int("limit", 100, "maximum number of tasks")
+	if _, err := a.parse(fs, args); err != nil {
+		return err
+	}
+
+	client, err := a.connect()
+	if err != nil {
+		return err
+	}
+
+	tasks, err := failedTasks(client, *topic, *limit)
+	if err != nil {
+		return err
+	}
+
+	return printList(a, tasks,
+		column[*camundaclientgo.ResExternalTask]{"ID", func(t *camundaclientgo.ResExternalTask) string { return t.Id }},
+		column[*camundaclientgo.ResExternalTask]{"TOPIC", func(t *camundaclientgo.ResExternalTask) string { return t.TopicName }},
+		column[*camundaclientgo.ResExternalTask]{"PROCESS INSTANCE", func(t *camundaclientgo.ResExternalTask) string { return t.ProcessInstanceId }},
+		column[*camundaclientgo.ResExternalTask]{"ACTIVITY", func(t *camundaclientgo.ResExternalTask) string { return t.ActivityId }},
+		column[*camundaclientgo.ResExternalTask]{"WORKER", func(t *camundaclientgo.ResExternalTask) string { return t.WorkerId }},
+		column[*camundaclientgo.ResExternalTask]{"ERROR", func(t *camundaclientgo.ResExternalTask) string { return t.ErrorMessage }},
+	)
+}
+
+func retryTasks(a *app, fs *flag.FlagSet, args []string) error {
+	topic := fs.String("topic", "", "retry all failed tasks of the topic")
+	retries := fs.Int("retries", 1, "number of retries")
+	ids, err := a.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 && *topic == "" {
+		return usageError("ids or -topic is required")
+	}
+	if len(ids) > 0 && *topic != "" {
+		return usageError("ids and -topic are mutually exclusive")
+	}
+
+	client, err := a.connect()
+	if err != nil {
+		return err
+	}
+
+	if *topic != "" {
+		tasks, err := failedTasks(client, *topic, 0)
+		if err != nil {
+			return err
+		}
+		for _, task := range tasks {
+			ids = append(ids, task.Id)
+		}
+	}
+
+	for _, id := range ids {
+		if err := client.ExternalTask.SetRetries(id, *retries); err != nil {
+			return fmt.Errorf("external task %s: %w", id, err)
+		}
+	}
+
+	a.info("set %d retries of %d external tasks", *retries, len(ids))
+	return nil
+}
+
+// failedTasks returns external tasks without retries left, of all topics if topic is empty
+func failedTasks(client *camundaclientgo.Client, topic string, limit int) ([]*camundaclientgo.ResExternalTask, error) {
+	query := camundaclientgo.QueryExternalTaskList{
+		QuerySorting:    camundaclientgo.QuerySorting{SortBy: "id", SortOrder: camundaclientgo.SortOrderAsc},
+		QueryPagination: camundaclientgo.QueryPagination{MaxResults: limit},
+		TopicName:       topic,
+		NoRetriesLeft:   true,
+	}
+
+	return client.ExternalTask.GetList(query.Params())
+}
+
+func listIncidents(a *app, fs *flag.FlagSet, args []string) error {
+	key := fs.String("key", "", "only incidents of the process definition key")
+	incidentType := fs.String("type", "", "only incidents of the type, e.g. failedJob or failedExternalTask")
+	instance := fs.String("instance", "", "only incidents of the process instance")
+	limit := fs.Int("limit", 100, "maximum number of incidents")
+	if _, err := a.parse(fs, args); err != nil {
+		return err
+	}
+
+	client, err := a.connect()
+	if err != nil {
+		return err
+	}
+
+	query := camundaclientgo.QueryIncidentList{
+		QuerySorting:      camundaclientgo.QuerySorting{SortBy: "incidentTimestamp", SortOrder: camundaclientgo.SortOrderDesc},
+		QueryPagination:   camundaclientgo.QueryPagination{MaxResults: *limit},
+		IncidentType:      *incidentType,
+		ProcessInstanceId: *instance,
+	}
+	if *key != "" {
+		query.ProcessDefinitionKeyIn = []string{*key}
+	}
+
+	incidents, err := client.Incident.GetList(query.Params())
+	if err != nil {
+		return err
+	}
+
+	return printList(a, incidents,
+		column[*camundaclientgo.ResIncident]{"ID", func(i *camundaclientgo.ResIncident) string { return i.Id }},
+		column[*camundaclientgo.ResIncident]{"TYPE", func(i *camundaclientgo.ResIncident) string { return i.IncidentType }},
+		column[*camundaclientgo.ResIncident]{"TIME", func(i *camundaclientgo.ResIncident) string {
+			return i.IncidentTimestamp.Format(camundaclientgo.DefaultDateTimeFormat)
+		}},
+		column[*camundaclientgo.ResIncident]{"PROCESS INSTANCE", func(i *camundaclientgo.ResIncident) string { return i.ProcessInstanceId }},
+		column[*camundaclientgo.ResIncident]{"ACTIVITY", func(i *camundaclientgo.ResIncident) string { return i.ActivityId }},
+		column[*camundaclientgo.ResIncident]{"MESSAGE", func(i *camundaclientgo.ResIncident) string { return i.IncidentMessage }},
+	)
+}
+
+// resolveIncidents resolves incidents by their types: a failed external task or a failed job gets retries, so it is
+// executed again and the incident is resolved by the engine, other incidents are resolved directly
+func resolveIncidents(a *app, fs *flag.FlagSet, args []string) error {
+	retries := fs.Int("retries", 1, "number of retries of failed jobs and external tasks")
+	ids, err := a.parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return usageError("ids are required")
+	}
+
+	client, err := a.connect()
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		incident, err := client.Incident.Get(id)
+		if err != nil {
+			return fmt.Errorf("incident %s: %w", id, err)
+		}
+
+		switch incident.IncidentType {
+		case incidentTypeFailedExternalTask:
+			err = client.ExternalTask.SetRetries(incident.Configuration, *retries)
+		case incidentTypeFailedJob:
+			err = client.Job.SetRetries(incident.Configuration, *retries)
+		default:
+			err = client.Incident.Resolve(id)
+		}
+		if err != nil {
+			return fmt.Errorf("resolve incident %s of type %s: %w", id, incident.IncidentType, err)
+		}
+
+		a.info("resolved incident %s of type %s", id, incident.IncidentType)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+)
+
+// parseVariables parses a JSON object of variables or of the file after @. Types of variables are inferred from
+// JSON values: strings are String, booleans are Boolean, integers are Integer or Long, other numbers are Double,
+// null is Null, objects and arrays are Json. An object with a value and a type, e.g.
+// {"value": "2024-01-01T00:00:00.000+0000", "type": "Date"}, is a variable as is
+func parseVariables(s string) (map[string]camundaclientgo.Variable, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	content := []byte(s)
+	if strings.HasPrefix(s, "@") {
+		var err error
+		if content, err = os.ReadFile(s[1:]); err != nil {
+			return nil, fmt.Errorf("read variables: %w", err)
+		}
+	}
+
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(content, &values); err != nil {
+		return nil, fmt.Errorf("parse variables: %w", err)
+	}
+
+	variables := make(map[string]camundaclientgo.Variable, len(values))
+	for name, raw := range values {
+		variable, err := toVariable(raw)
+		if err != nil {
+			return nil, fmt.Errorf("parse variable %s: %w", name, err)
+		}
+		variables[name] = variable
+	}
+
+	return variables, nil
+}
+
+// toVariable returns a variable of a JSON value
+func toVariable(raw json.RawMessage) (camundaclientgo.Variable, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return camundaclientgo.Variable{}, err
+	}
+
+	switch x := value.(type) {
+	case nil:
+		return camundaclientgo.Variable{Type: "Null"}, nil
+	case string:
+		return camundaclientgo.Variable{Value: x, Type: "String"}, nil
+	case bool:
+		return camundaclientgo.Variable{Value: x, Type: "Boolean"}, nil
+	case json.Number:
+		if n, err := x.Int64(); err == nil {
+			if n >= math.MinInt32 && n <= math.MaxInt32 {
+				return camundaclientgo.Variable{Value: n, Type: "Integer"}, nil
+			}
+			return camundaclientgo.Variable{Value: n, Type: "Long"}, nil
+		}
+
+		f, err := x.Float64()
+		if err != nil {
+			return camundaclientgo.Variable{}, err
+		}
+		return camundaclientgo.Variable{Value: f, Type: "Double"}, nil
+	case map[string]interface{}:
+		if isTypedValue(x) {
+			var variable camundaclientgo.Variable
+			err := json.Unmarshal(raw, &variable)
+			return variable, err
+		}
+	}
+
+	return camundaclientgo.Variable{Value: string(bytes.TrimSpace(raw)), Type: "Json"}, nil
+}
+
+// isTypedValue returns true if an object has a value and a type and no other fields than valueInfo
+func isTypedValue(object map[string]interface{}) bool {
+	_, hasValue := object["value"]
+	_, hasType := object["type"].(string)
+	if !hasValue || !hasType {
+		return false
+	}
+
+	for name := range object {
+		if name != "value" && name != "type" && name != "valueInfo" {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,233 @@
+package camunda_client_go
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultSyncExtensions extensions of BPMN, DMN and CMMN models, forms and scripts deployed by SyncDirectory and SyncFS
+var DefaultSyncExtensions = []string{
+	".bpmn", ".bpmn20.xml", ".dmn", ".dmn11.xml", ".cmmn", ".cmmn11.xml", ".form",
+	".js", ".groovy", ".py", ".rb",
+}
+
+// SyncOptions options of SyncDirectory and SyncFS
+type SyncOptions struct {
+	// The name of the deployment (default of SyncDirectory: the base name of the directory)
+	DeploymentName string
+	// Sets the source of a new deployment
+	DeploymentSource *string
+	// The tenant id of the deployment
+	TenantId *string
+	// Extensions of deployed resources (default: DefaultSyncExtensions)
+	Extensions []string
+	// Compare resources without deploying
+	DryRun bool
+}
+
+// ResSyncDirectory a difference between resources of a directory or a file system and the latest deployment
+// with the same name
+type ResSyncDirectory struct {
+	// The id of the latest deployment before the sync, empty if there was none
+	PreviousDeploymentId string
+	// Resources which are not in the latest deployment
+	Added []string
+	// Resources with a content that differs from the latest deployment
+	Changed []string
+	// Resources with the same content as in the latest deployment
+	Unchanged []string
+	// Resources of the latest deployment which are no longer deployed
+	Removed []string
+	// SHA-256 hashes of deployed resources by name
+	Hashes map[string]string
+	// The created deployment, nil if nothing changed or DryRun is set
+	Deployment *ResDeploymentCreate
+}
+
+// HasChanges returns true if the resources differ from the latest deployment
+func (r *ResSyncDirectory) HasChanges() bool {
+	return len(r.Added) > 0 || len(r.Changed) > 0 || len(r.Removed) > 0
+}
+
+// SyncDirectory deploys resources of a directory and its subdirectories if they differ from resources of the latest
+// deployment with the same name, see SyncFS
+func (d *Deployment) SyncDirectory(ctx context.Context, dir string, opts SyncOptions) (*ResSyncDirectory, error) {
+	if opts.DeploymentName == "" {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		opts.DeploymentName = filepath.Base(abs)
+	}
+
+	report, err := d.SyncFS(ctx, os.DirFS(dir), opts)
+	if err != nil {
+		return nil, fmt.Errorf("sync %s: %w", dir, err)
+	}
+
+	return report, nil
+}
+
+// SyncFS deploys resources of fsys, e.g. of an embed.FS, if they differ from resources of the latest deployment
+// with the same name. Resources are named by their paths in fsys and compared by SHA-256 hashes of their content.
+// All resources are deployed if any was added, changed or removed, so the new deployment becomes the latest one
+// to compare with
+func (d *Deployment) SyncFS(ctx context.Context, fsys fs.FS, opts SyncOptions) (*ResSyncDirectory, error) {
+	if opts.DeploymentName == "" {
+		return nil, fmt.Errorf("deployment name is required")
+	}
+
+	client := d.client.WithContext(ctx)
+	resources, err := readSyncResources(fsys, opts.Extensions)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ResSyncDirectory{Hashes: make(map[string]string, len(resources))}
+	for _, resource := range resources {
+		report.Hashes[resource.name] = hashResource(resource.content)
+	}
+
+	deployed, err := client.Deployment.latestResourceHashes(opts.DeploymentName, opts.TenantId, report)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, resource := range resources {
+		previous, ok := deployed[resource.name]
+		switch {
+		case !ok:
+			report.Added = append(report.Added, resource.name)
+		case previous != report.Hashes[resource.name]:
+			report.Changed = append(report.Changed, resource.name)
+		default:
+			report.Unchanged = append(report.Unchanged, resource.name)
+		}
+	}
+
+	for resourceName := range deployed {
+		if _, ok := report.Hashes[resourceName]; !ok {
+			report.Removed = append(report.Removed, resourceName)
+		}
+	}
+	sort.Strings(report.Removed)
+
+	if !report.HasChanges() || opts.DryRun || len(resources) == 0 {
+		return report, nil
+	}
+
+	req := ReqDeploymentCreate{
+		DeploymentName:   opts.DeploymentName,
+		DeploymentSource: opts.DeploymentSource,
+		TenantId:         opts.TenantId,
+	}
+	for _, resource := range resources {
+		req.DeploymentResources = append(req.DeploymentResources, DeploymentResource{
+			Name:    resource.name,
+			Content: bytes.NewReader(resource.content),
+		})
+	}
+
+	if report.Deployment, err = client.Deployment.Create(req); err != nil {
+		return nil, fmt.Errorf("create deployment %s: %w", opts.DeploymentName, err)
+	}
+
+	return report, nil
+}
+
+// latestResourceHashes returns hashes of resources of the latest deployment with the name by resource name
+// and sets the id of the deployment in the report
+func (d *Deployment) latestResourceHashes(name string, tenantId *string, report *ResSyncDirectory) (map[string]string, error) {
+	query := QueryDeploymentList{
+		QuerySorting:    QuerySorting{SortBy: "deploymentTime", SortOrder: SortOrderDesc},
+		QueryPagination: QueryPagination{MaxResults: 1},
+		Name:            name,
+		WithoutTenantId: tenantId == nil,
+	}
+	if tenantId != nil {
+		query.TenantIdIn = []string{*tenantId}
+	}
+
+	deployments, err := d.GetList(query.Params())
+	if err != nil {
+		return nil, fmt.Errorf("get latest deployment %s: %w", name, err)
+	}
+
+	hashes := map[string]string{}
+	if len(deployments) == 0 {
+		return hashes, nil
+	}
+
+	report.PreviousDeploymentId = deployments[0].Id
+	resources, err := d.GetResources(report.PreviousDeploymentId)
+	if err != nil {
+		return nil, fmt.Errorf("get resources of deployment %s: %w", report.PreviousDeploymentId, err)
+	}
+
+	for _, resource := range resources {
+		content, err := d.GetResourceBinary(report.PreviousDeploymentId, resource.Id)
+		if err != nil {
+			return nil, fmt.Errorf("get resource %s of deployment %s: %w", resource.Name, report.PreviousDeploymentId, err)
+		}
+
+		hashes[resource.Name] = hashResource(content)
+	}
+
+	return hashes, nil
+}
+
+// syncResource a content of a resource
+type syncResource struct {
+	name    string
+	content []byte
+}
+
+// readSyncResources returns files of fsys with the extensions sorted by path
+func readSyncResources(fsys fs.FS, extensions []string) ([]syncResource, error) {
+	if extensions == nil {
+		extensions = DefaultSyncExtensions
+	}
+
+	var resources []syncResource
+	err := fs.WalkDir(fsys, ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || !hasExtension(entry.Name(), extensions) {
+			return err
+		}
+
+		content, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+
+		resources = append(resources, syncResource{name: path, content: content})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read resources: %w", err)
+	}
+
+	return resources, nil
+}
+
+func hasExtension(name string, extensions []string) bool {
+	for _, extension := range extensions {
+		if strings.HasSuffix(name, extension) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hashResource(content []byte) string {
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
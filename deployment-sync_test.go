@@ -0,0 +1,90 @@
+package camunda_client_go_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+	"github.com/citilinkru/camunda-client-go/v3/camundatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncDirectory(t *testing.T) {
+	server := camundatest.NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	dir := filepath.Join(t.TempDir(), "processes")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	bpmn, err := os.ReadFile("examples/deployment/HelloWorld.bpmn")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "HelloWorld.bpmn"), bpmn, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "check.js"), []byte("true"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("ignored"), 0o644))
+
+	ctx := context.Background()
+	report, err := client.Deployment.SyncDirectory(ctx, dir, camundaclientgo.SyncOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, report.PreviousDeploymentId)
+	assert.Equal(t, []string{"HelloWorld.bpmn", "check.js"}, report.Added)
+	assert.Len(t, report.Hashes, 2)
+	require.NotNil(t, report.Deployment)
+	assert.Equal(t, "processes", report.Deployment.Name)
+	assert.Len(t, report.Deployment.DeployedProcessDefinitions, 1)
+	first := report.Deployment.Id
+
+	server.Advance(time.Second)
+	report, err = client.Deployment.SyncDirectory(ctx, dir, camundaclientgo.SyncOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, first, report.PreviousDeploymentId)
+	assert.False(t, report.HasChanges())
+	assert.Equal(t, []string{"HelloWorld.bpmn", "check.js"}, report.Unchanged)
+	assert.Nil(t, report.Deployment)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "check.js"), []byte("false"), 0o644))
+	report, err = client.Deployment.SyncDirectory(ctx, dir, camundaclientgo.SyncOptions{DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"check.js"}, report.Changed)
+	assert.Nil(t, report.Deployment)
+
+	report, err = client.Deployment.SyncDirectory(ctx, dir, camundaclientgo.SyncOptions{})
+	require.NoError(t, err)
+	require.NotNil(t, report.Deployment)
+	assert.NotEqual(t, first, report.Deployment.Id)
+
+	server.Advance(time.Second)
+	require.NoError(t, os.Remove(filepath.Join(dir, "check.js")))
+	report, err = client.Deployment.SyncDirectory(ctx, dir, camundaclientgo.SyncOptions{DeploymentName: "processes"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"check.js"}, report.Removed)
+	assert.Equal(t, []string{"HelloWorld.bpmn"}, report.Unchanged)
+	require.NotNil(t, report.Deployment)
+
+	count, err := client.Deployment.GetListCount(map[string]string{"name": "processes"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+}
+
+func TestSyncFS(t *testing.T) {
+	server := camundatest.NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	bpmn, err := os.ReadFile("examples/deployment/HelloWorld.bpmn")
+	require.NoError(t, err)
+	fsys := fstest.MapFS{"processes/HelloWorld.bpmn": {Data: bpmn}}
+
+	_, err = client.Deployment.SyncFS(context.Background(), fsys, camundaclientgo.SyncOptions{})
+	assert.EqualError(t, err, "deployment name is required")
+
+	report, err := client.Deployment.SyncFS(context.Background(), fsys, camundaclientgo.SyncOptions{DeploymentName: "embedded"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"processes/HelloWorld.bpmn"}, report.Added)
+	require.NotNil(t, report.Deployment)
+	assert.Len(t, report.Deployment.DeployedProcessDefinitions, 1)
+}
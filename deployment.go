@@ -2,12 +2,16 @@ package camunda_client_go
 
 import (
 	"bytes"
+	"context"
 	"io"
+	"io/fs"
 	"io/ioutil"
 	"mime/multipart"
 	"net/http"
 	"os"
+	"sort"
 	"strconv"
+	"time"
 )
 
 // Deployment a client for Deployment API
@@ -64,7 +68,18 @@ type ReqDeploymentCreate struct {
 	DeployChangedOnly        *bool
 	DeploymentSource         *string
 	TenantId                 *string
-	Resources                map[string]interface{}
+	// Resources by name: an *os.File is sent with its file name, a DeploymentResource with its name,
+	// other readers with the key as a file name. Resources are sent sorted by key
+	Resources map[string]interface{}
+	// Resources sent in the given order after Resources
+	DeploymentResources []DeploymentResource
+}
+
+// DeploymentResource a resource of a deployment, Content is closed after sending if it is an io.Closer
+type DeploymentResource struct {
+	// The name of the resource, e.g. processes/order.bpmn
+	Name    string
+	Content io.Reader
 }
 
 // ReqRedeploy a request to redeploy
@@ -87,6 +102,38 @@ type ResDeploymentResource struct {
 	DeploymentId string `json:"deploymentId"`
 }
 
+// QueryDeploymentList a typed query for GetList and GetListCount, use Params to get query parameters
+// https://docs.camunda.org/manual/latest/reference/rest/deployment/get-query/#query-parameters
+type QueryDeploymentList struct {
+	QuerySorting
+	QueryPagination
+	// Filter by deployment id
+	Id string `query:"id"`
+	// Filter by the deployment name. Exact match
+	Name string `query:"name"`
+	// Filter by the deployment name that the parameter is a substring of
+	NameLike string `query:"nameLike"`
+	// Filter by the deployment source
+	Source string `query:"source"`
+	// Filter by the deployment source whereby source is equal to null
+	WithoutSource bool `query:"withoutSource"`
+	// Filter by a list of tenant ids. A deployment must have one of the given tenant ids
+	TenantIdIn []string `query:"tenantIdIn"`
+	// Only include deployments which belong to no tenant
+	WithoutTenantId bool `query:"withoutTenantId"`
+	// Include deployments which belong to no tenant. Can be used in combination with TenantIdIn
+	IncludeDeploymentsWithoutTenantId bool `query:"includeDeploymentsWithoutTenantId"`
+	// Restricts to all deployments after the given date
+	After time.Time `query:"after"`
+	// Restricts to all deployments before the given date
+	Before time.Time `query:"before"`
+}
+
+// Params returns query parameters
+func (q *QueryDeploymentList) Params() map[string]string {
+	return encodeQuery(q)
+}
+
 // GetList a queries for deployments that fulfill given parameters. Parameters may be the properties of deployments,
 // such as the id or name or a range of the deployment time. The size of the result set can be retrieved by using
 // the Get Deployment count method.
@@ -102,6 +149,20 @@ func (d *Deployment) GetList(query map[string]string) (deployments []*ResDeploym
 	return
 }
 
+// GetListPager returns a Pager over deployments that fulfill given parameters.
+// Results are sorted by id unless sortBy is set in query
+func (d *Deployment) GetListPager(query map[string]string, pageSize int) *Pager[*ResDeployment] {
+	if query["sortBy"] == "" {
+		query = pageQuery(query, 0, 0)
+		query["sortBy"] = "id"
+		query["sortOrder"] = "asc"
+	}
+
+	return NewPager(func(ctx context.Context, firstResult, maxResults int) ([]*ResDeployment, error) {
+		return d.client.WithContext(ctx).Deployment.GetList(pageQuery(query, firstResult, maxResults))
+	}, pageSize)
+}
+
 // GetListCount a queries for the number of deployments that fulfill given parameters.
 // Takes the same parameters as the Get Deployments method
 func (d *Deployment) GetListCount(query map[string]string) (count int, err error) {
@@ -161,31 +222,42 @@ func (d *Deployment) Create(deploymentCreate ReqDeploymentCreate) (deployment *R
 		}
 	}
 
-	for key, resource := range deploymentCreate.Resources {
-		var fw io.Writer
+	keys := make([]string, 0, len(deploymentCreate.Resources))
+	for key := range deploymentCreate.Resources {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		resource := deploymentCreate.Resources[key]
+		if x, ok := resource.(DeploymentResource); ok {
+			resource = x.Content
+			if x.Name != "" {
+				key = x.Name
+			}
+		}
 
 		if x, ok := resource.(io.Closer); ok {
 			defer x.Close()
 		}
 
+		fileName := key
 		if x, ok := resource.(*os.File); ok {
-			if fw, err = w.CreateFormFile(key, x.Name()); err != nil {
-				return nil, err
-			}
-		} else if _, ok := resource.(multipart.File); ok {
-			if fw, err = w.CreateFormFile(key, key); err != nil {
-				return nil, err
-			}
-		} else {
-			if fw, err = w.CreateFormField(key); err != nil {
-				return nil, err
-			}
+			fileName = x.Name()
 		}
 
-		if r, ok := resource.(io.Reader); ok {
-			if _, err = io.Copy(fw, r); err != nil {
-				return nil, err
-			}
+		if err = writeDeploymentResource(w, key, fileName, resource); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, resource := range deploymentCreate.DeploymentResources {
+		if x, ok := resource.Content.(io.Closer); ok {
+			defer x.Close()
+		}
+
+		if err = writeDeploymentResource(w, resource.Name, resource.Name, resource.Content); err != nil {
+			return nil, err
 		}
 	}
 
@@ -203,6 +275,73 @@ func (d *Deployment) Create(deploymentCreate ReqDeploymentCreate) (deployment *R
 	return deployment, err
 }
 
+// DeploymentResourcesFS returns resources of files of fsys matching the patterns of fs.Glob, or of all files
+// if there are no patterns, e.g. of an embed.FS. Resources are named by their paths in fsys and sorted by name
+func DeploymentResourcesFS(fsys fs.FS, patterns ...string) ([]DeploymentResource, error) {
+	var names []string
+	if len(patterns) == 0 {
+		err := fs.WalkDir(fsys, ".", func(path string, entry fs.DirEntry, err error) error {
+			if err == nil && !entry.IsDir() {
+				names = append(names, path)
+			}
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, pattern := range patterns {
+		matches, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, matches...)
+	}
+
+	sort.Strings(names)
+	resources := make([]DeploymentResource, 0, len(names))
+	for i, name := range names {
+		if i > 0 && names[i-1] == name {
+			continue
+		}
+
+		info, err := fs.Stat(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if info.IsDir() {
+			continue
+		}
+
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, DeploymentResource{Name: name, Content: bytes.NewReader(content)})
+	}
+
+	return resources, nil
+}
+
+// writeDeploymentResource writes a resource as a file part, values which are not readers are sent empty
+func writeDeploymentResource(w *multipart.Writer, name, fileName string, resource interface{}) error {
+	fw, err := w.CreateFormFile(name, fileName)
+	if err != nil {
+		return err
+	}
+
+	if r, ok := resource.(io.Reader); ok {
+		if _, err = io.Copy(fw, r); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Redeploy a re-deploys an existing deployment.
 // The deployment resources to re-deploy can be restricted by using the properties resourceIds or resourceNames.
 // If no deployment resources to re-deploy are passed then all existing resources of the given deployment
@@ -0,0 +1,81 @@
+package camunda_client_go
+
+import (
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeploymentResourcesFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"processes/order.bpmn":   {Data: []byte("<order/>")},
+		"processes/archive.bpmn": {Data: []byte("<archive/>")},
+		"processes/forms":        {Mode: fs.ModeDir | 0o755},
+		"decisions/risk.dmn":     {Data: []byte("<risk/>")},
+	}
+
+	resources, err := DeploymentResourcesFS(fsys, "processes/*", "*/*.bpmn")
+	require.NoError(t, err)
+	require.Len(t, resources, 2)
+	assert.Equal(t, "processes/archive.bpmn", resources[0].Name)
+	assert.Equal(t, "processes/order.bpmn", resources[1].Name)
+	content, err := io.ReadAll(resources[1].Content)
+	require.NoError(t, err)
+	assert.Equal(t, "<order/>", string(content))
+
+	resources, err = DeploymentResourcesFS(fsys)
+	require.NoError(t, err)
+	require.Len(t, resources, 3)
+	assert.Equal(t, "decisions/risk.dmn", resources[0].Name)
+
+	_, err = DeploymentResourcesFS(fsys, "[")
+	assert.Error(t, err)
+}
+
+func TestDeploymentCreate_Resources(t *testing.T) {
+	var parts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reader, err := r.MultipartReader()
+		require.NoError(t, err)
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+
+			content, err := io.ReadAll(part)
+			require.NoError(t, err)
+			if part.FileName() != "" {
+				parts = append(parts, part.FormName()+"="+string(content))
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOptions{EndpointUrl: server.URL})
+	_, err := client.Deployment.Create(ReqDeploymentCreate{
+		DeploymentName: "orders",
+		Resources: map[string]interface{}{
+			"c.bpmn": strings.NewReader("c"),
+			"a.bpmn": strings.NewReader("a"),
+			"b":      DeploymentResource{Name: "processes/b.bpmn", Content: strings.NewReader("b")},
+		},
+		DeploymentResources: []DeploymentResource{
+			{Name: "z.dmn", Content: strings.NewReader("z")},
+			{Name: "y.dmn", Content: strings.NewReader("y")},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.bpmn=a", "processes/b.bpmn=b", "c.bpmn=c", "z.dmn=z", "y.dmn=y"}, parts)
+}
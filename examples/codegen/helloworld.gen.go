@@ -0,0 +1,66 @@
+// Code generated by camunda-gen from HelloWorld.bpmn. DO NOT EDIT.
+
+package main
+
+import (
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+	"github.com/citilinkru/camunda-client-go/v3/processor"
+)
+
+// Keys of process definitions
+const (
+	// ProcessHelloWorldProcess Hello World Process
+	ProcessHelloWorldProcess = "hello-world-process"
+)
+
+// Topics of external tasks
+const (
+	TopicPrintHello = "PrintHello"
+	TopicPrintWorld = "PrintWorld"
+)
+
+// PrintHelloInput input variables of external tasks of topic PrintHello
+type PrintHelloInput struct{}
+
+// PrintHelloOutput variables which complete external tasks of topic PrintHello
+type PrintHelloOutput struct{}
+
+// PrintHelloHandler handles external tasks of topic PrintHello:
+//   - Print "Hello" (Task_18yse8m) of hello-world-process
+type PrintHelloHandler interface {
+	PrintHello(ctx *processor.Context, in PrintHelloInput) (PrintHelloOutput, error)
+}
+
+// AddPrintHelloHandler register the handler of topic PrintHello
+func AddPrintHelloHandler(p *processor.Processor, handler PrintHelloHandler) {
+	processor.AddTypedHandler(p, []*camundaclientgo.QueryFetchAndLockTopic{{TopicName: TopicPrintHello}}, handler.PrintHello)
+}
+
+// PrintWorldInput input variables of external tasks of topic PrintWorld
+type PrintWorldInput struct{}
+
+// PrintWorldOutput variables which complete external tasks of topic PrintWorld
+type PrintWorldOutput struct{}
+
+// PrintWorldHandler handles external tasks of topic PrintWorld:
+//   - Print "World" (Task_1s4a9px) of hello-world-process
+type PrintWorldHandler interface {
+	PrintWorld(ctx *processor.Context, in PrintWorldInput) (PrintWorldOutput, error)
+}
+
+// AddPrintWorldHandler register the handler of topic PrintWorld
+func AddPrintWorldHandler(p *processor.Processor, handler PrintWorldHandler) {
+	processor.AddTypedHandler(p, []*camundaclientgo.QueryFetchAndLockTopic{{TopicName: TopicPrintWorld}}, handler.PrintWorld)
+}
+
+// Handlers handles external tasks of all topics
+type Handlers interface {
+	PrintHelloHandler
+	PrintWorldHandler
+}
+
+// AddHandlers register handlers of all topics
+func AddHandlers(p *processor.Processor, handlers Handlers) {
+	AddPrintHelloHandler(p, handlers)
+	AddPrintWorldHandler(p, handlers)
+}
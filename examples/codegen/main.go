@@ -0,0 +1,52 @@
+package main
+
+//go:generate go run ../../cmd/camunda-gen -o helloworld.gen.go ../deployment/HelloWorld.bpmn
+
+import (
+	"fmt"
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+	"github.com/citilinkru/camunda-client-go/v3/processor"
+	"time"
+)
+
+// printer implements handlers of all topics of HelloWorld.bpmn
+type printer struct{}
+
+func (printer) PrintHello(ctx *processor.Context, in PrintHelloInput) (PrintHelloOutput, error) {
+	fmt.Printf("Running task %s. TopicName: %s\n", ctx.Task.Id, ctx.Task.TopicName)
+	fmt.Println("Hello")
+	return PrintHelloOutput{}, nil
+}
+
+func (printer) PrintWorld(ctx *processor.Context, in PrintWorldInput) (PrintWorldOutput, error) {
+	fmt.Printf("Running task %s. TopicName: %s\n", ctx.Task.Id, ctx.Task.TopicName)
+	fmt.Println("World")
+	return PrintWorldOutput{}, nil
+}
+
+func main() {
+	client := camundaclientgo.NewClient(camundaclientgo.ClientOptions{
+		EndpointUrl: "http://localhost:8080/engine-rest",
+		ApiUser:     "demo",
+		ApiPassword: "demo",
+		Timeout:     time.Second * 10,
+	})
+
+	logger := func(err error) {
+		fmt.Println(err.Error())
+	}
+	proc := processor.NewProcessor(client, &processor.Options{
+		WorkerId:           "hello-world-worker",
+		LockDuration:       time.Second * 5,
+		MaxTasks:           10,
+		LongPollingTimeout: 5 * time.Second,
+	}, logger)
+
+	AddHandlers(proc, printer{})
+	fmt.Println("Processor is started")
+
+	// wait...
+	for {
+		time.Sleep(time.Second * 180)
+	}
+}
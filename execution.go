@@ -0,0 +1,18 @@
+package camunda_client_go
+
+// Execution a client for Execution API
+type Execution struct {
+	client *Client
+}
+
+// DownloadLocalVariableData retrieves the content of a Bytes or File local variable of the execution by id
+// without buffering it. The returned data must be closed
+func (e *Execution) DownloadLocalVariableData(id, variableName string) (data *ResVariableData, err error) {
+	return e.client.downloadVariableData("/execution/" + id + "/localVariables/" + variableName + "/data")
+}
+
+// UploadLocalVariableData sets the content of a Bytes or File local variable of the execution by id,
+// the content is streamed as multipart form data
+func (e *Execution) UploadLocalVariableData(id, variableName string, req ReqBinaryVariable) error {
+	return e.client.uploadVariableData("/execution/"+id+"/localVariables/"+variableName+"/data", variableName, req)
+}
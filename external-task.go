@@ -1,7 +1,11 @@
 package camunda_client_go
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"time"
 )
 
 // ExternalTask a client for ExternalTask API
@@ -98,6 +102,25 @@ type QueryGetListPost struct {
 	// that specifies one ordering. The position in the array identifies the rank of an ordering,
 	// i.e., whether it is primary, secondary, etc.
 	Sorting *QueryListPostSorting `json:"sorting,omitempty"`
+	// Criteria to sort the result by in the order of their rank. Sent as the sorting array
+	// in place of Sorting when set
+	SortingList []QueryListPostSorting `json:"-"`
+}
+
+// MarshalJSON sends SortingList as the sorting array when it is set
+func (q QueryGetListPost) MarshalJSON() ([]byte, error) {
+	type Alias QueryGetListPost
+	if len(q.SortingList) == 0 {
+		return json.Marshal(Alias(q))
+	}
+
+	return json.Marshal(&struct {
+		Alias
+		Sorting []QueryListPostSorting `json:"sorting"`
+	}{
+		Alias:   Alias(q),
+		Sorting: q.SortingList,
+	})
 }
 
 // QueryFetchAndLock query for FetchAndLock request
@@ -125,7 +148,7 @@ type QueryFetchAndLockTopic struct {
 	LockDuration int `json:"lockDuration"`
 	// A JSON array of String values that represent variable names. For each result task belonging to this topic,
 	// the given variables are returned as well if they are accessible from the external task's execution.
-	// If not provided - all variables will be fetched
+	// If not provided (nil) - all variables will be fetched, an empty slice fetches none
 	Variables []string `json:"variables,omitempty"`
 	// If true only local variables will be fetched
 	LocalVariables *bool `json:"localVariables,omitempty"`
@@ -157,15 +180,26 @@ type QueryFetchAndLockTopic struct {
 	DeserializeValues *bool `json:"deserializeValues,omitempty"`
 }
 
-// QueryListPostSorting a JSON array of criteria to sort the result by
-type QueryListPostSorting struct {
-	// Mandatory. Sort the results lexicographically by a given criterion. Valid values are id, lockExpirationTime,
-	// processInstanceId, processDefinitionId, processDefinitionKey, taskPriority and tenantId
-	SortBy string `json:"sortBy"`
-	// Mandatory. Sort the results in a given order. Values may be asc for ascending order or desc for descending order
-	SortOrder string `json:"sortOrder"`
+// MarshalJSON sends an empty Variables as an empty array, which omitempty would drop
+func (q QueryFetchAndLockTopic) MarshalJSON() ([]byte, error) {
+	type Alias QueryFetchAndLockTopic
+	if q.Variables == nil || len(q.Variables) > 0 {
+		return json.Marshal(Alias(q))
+	}
+
+	return json.Marshal(&struct {
+		Alias
+		Variables []string `json:"variables"`
+	}{
+		Alias:     Alias(q),
+		Variables: q.Variables,
+	})
 }
 
+// QueryListPostSorting a criterion to sort external tasks by. Valid values of SortBy are id, lockExpirationTime,
+// processInstanceId, processDefinitionId, processDefinitionKey, taskPriority and tenantId
+type QueryListPostSorting = ReqSort
+
 // ResLockedExternalTask a response FetchAndLock method
 type ResLockedExternalTask struct {
 	// The id of the activity that this external task belongs to
@@ -235,6 +269,12 @@ type ValueInfo struct {
 	ObjectTypeName *string `json:"objectTypeName"`
 	// The serialization format used to store the variable.
 	SerializationDataFormat *string `json:"serializationDataFormat"`
+	// The name of the file of a File variable
+	FileName string `json:"filename,omitempty"`
+	// The MIME type of the file of a File variable
+	MimeType string `json:"mimetype,omitempty"`
+	// The encoding of the file of a File variable
+	Encoding string `json:"encoding,omitempty"`
 }
 
 // QueryComplete a query for Complete request
@@ -330,6 +370,60 @@ func (e *ExternalTask) Get(id string) (*ResExternalTask, error) {
 	return resp, nil
 }
 
+// QueryExternalTaskList a typed query for GetList and GetListCount, use Params to get query parameters
+// https://docs.camunda.org/manual/latest/reference/rest/external-task/get-query/#query-parameters
+type QueryExternalTaskList struct {
+	QuerySorting
+	QueryPagination
+	// Filter by an external task's id
+	ExternalTaskId string `query:"externalTaskId"`
+	// Filter by the comma-separated list of external task ids
+	ExternalTaskIdIn []string `query:"externalTaskIdIn"`
+	// Filter by an external task topic
+	TopicName string `query:"topicName"`
+	// Filter by the id of the worker that the task was most recently locked by
+	WorkerId string `query:"workerId"`
+	// Only include external tasks that are currently locked
+	Locked bool `query:"locked"`
+	// Only include external tasks that are currently not locked
+	NotLocked bool `query:"notLocked"`
+	// Only include external tasks that have a positive (> 0) number of retries (or null)
+	WithRetriesLeft bool `query:"withRetriesLeft"`
+	// Only include external tasks that have 0 retries
+	NoRetriesLeft bool `query:"noRetriesLeft"`
+	// Restrict to external tasks that have a lock that expires after a given date
+	LockExpirationAfter time.Time `query:"lockExpirationAfter"`
+	// Restrict to external tasks that have a lock that expires before a given date
+	LockExpirationBefore time.Time `query:"lockExpirationBefore"`
+	// Filter by the id of the activity that an external task is created for
+	ActivityId string `query:"activityId"`
+	// Filter by the comma-separated list of ids of the activities that an external task is created for
+	ActivityIdIn []string `query:"activityIdIn"`
+	// Filter by the id of the execution that an external task belongs to
+	ExecutionId string `query:"executionId"`
+	// Filter by the id of the process instance that an external task belongs to
+	ProcessInstanceId string `query:"processInstanceId"`
+	// Filter by a comma-separated list of process instance ids that an external task may belong to
+	ProcessInstanceIdIn []string `query:"processInstanceIdIn"`
+	// Filter by the id of the process definition that an external task belongs to
+	ProcessDefinitionId string `query:"processDefinitionId"`
+	// Only include active tasks
+	Active bool `query:"active"`
+	// Only include suspended tasks
+	Suspended bool `query:"suspended"`
+	// Only include jobs with a priority higher than or equal to the given value
+	PriorityHigherThanOrEquals *int `query:"priorityHigherThanOrEquals"`
+	// Only include jobs with a priority lower than or equal to the given value
+	PriorityLowerThanOrEquals *int `query:"priorityLowerThanOrEquals"`
+	// Filter by a list of tenant ids. An external task must have one of the given tenant ids
+	TenantIdIn []string `query:"tenantIdIn"`
+}
+
+// Params returns query parameters
+func (q *QueryExternalTaskList) Params() map[string]string {
+	return encodeQuery(q)
+}
+
 // GetList queries for the external tasks that fulfill given parameters.
 // Parameters may be static as well as dynamic runtime properties of executions
 // Query parameters described in the documentation:
@@ -351,6 +445,20 @@ func (e *ExternalTask) GetList(query map[string]string) ([]*ResExternalTask, err
 	return resp, nil
 }
 
+// GetListPager returns a Pager over external tasks that fulfill given parameters.
+// Results are sorted by id unless sortBy is set in query
+func (e *ExternalTask) GetListPager(query map[string]string, pageSize int) *Pager[*ResExternalTask] {
+	if query["sortBy"] == "" {
+		query = pageQuery(query, 0, 0)
+		query["sortBy"] = "id"
+		query["sortOrder"] = "asc"
+	}
+
+	return NewPager(func(ctx context.Context, firstResult, maxResults int) ([]*ResExternalTask, error) {
+		return e.client.WithContext(ctx).ExternalTask.GetList(pageQuery(query, firstResult, maxResults))
+	}, pageSize)
+}
+
 // GetListCount queries for the number of external tasks that fulfill given parameters.
 // Takes the same parameters as the Get External Tasks method.
 // Query parameters described in the documentation:
@@ -384,6 +492,19 @@ func (e *ExternalTask) GetListPost(query map[string]string, req QueryGetListPost
 	return
 }
 
+// GetListPostPager returns a Pager over external tasks that fulfill the given parameters.
+// Results are sorted by id after the criteria of req, so pages do not overlap
+func (e *ExternalTask) GetListPostPager(query map[string]string, req QueryGetListPost, pageSize int) *Pager[*ResExternalTask] {
+	sorting := req.SortingList
+	if len(sorting) == 0 && req.Sorting != nil {
+		sorting = []QueryListPostSorting{*req.Sorting}
+	}
+	req.SortingList = stableSorting(sorting, "id")
+	return NewPager(func(ctx context.Context, firstResult, maxResults int) ([]*ResExternalTask, error) {
+		return e.client.WithContext(ctx).ExternalTask.GetListPost(pageQuery(query, firstResult, maxResults), req)
+	}, pageSize)
+}
+
 // GetListPostCount queries for the number of external tasks that fulfill given parameters.
 // This method takes the same message body as the Get External Tasks (POST) method
 func (e *ExternalTask) GetListPostCount(query QueryGetListPost) (int, error) {
@@ -430,6 +551,23 @@ func (e *ExternalTask) Complete(id string, query QueryComplete) error {
 	return err
 }
 
+// CompleteWithBinaryVariables a completes an external task by id and updates process variables,
+// including Bytes and File variables, which content is streamed in base64 without buffering
+func (e *ExternalTask) CompleteWithBinaryVariables(id string, query QueryComplete, variables, localVariables map[string]ReqBinaryVariable) error {
+	defer func() {
+		for _, v := range variables {
+			v.close()
+		}
+		for _, v := range localVariables {
+			v.close()
+		}
+	}()
+
+	return e.client.doPostStream("/external-task/"+id+"/complete", nil, "application/json", func(w io.Writer) error {
+		return writeCompleteJson(w, query, variables, localVariables)
+	})
+}
+
 // HandleBPMNError reports a business error in the context of a running external task by id.
 // The error code must be specified to identify the BPMN error handler
 func (e *ExternalTask) HandleBPMNError(id string, query QueryHandleBPMNError) error {
@@ -31,7 +31,7 @@ func TestFetchAndLockIntegration(t *testing.T) {
 	assert.NoError(t, err)
 
 	// wait processing StartInstance in camunda
-	time.Sleep(time.Second * 15)
+	waitEngine(time.Second * 15)
 
 	tasks, err := client.ExternalTask.FetchAndLock(QueryFetchAndLock{
 		WorkerId: "test-fetch-and-lock-integration",
@@ -0,0 +1,226 @@
+package camunda_client_go
+
+import (
+	"context"
+	"time"
+)
+
+// ResHistoryActivityInstance a JSON object corresponding to the HistoricActivityInstance interface in the engine
+type ResHistoryActivityInstance struct {
+	// The id of the activity instance
+	Id string `json:"id"`
+	// The id of the parent activity instance, for example a sub process instance
+	ParentActivityInstanceId string `json:"parentActivityInstanceId"`
+	// The id of the activity that this object is an instance of
+	ActivityId string `json:"activityId"`
+	// The name of the activity that this object is an instance of
+	ActivityName string `json:"activityName"`
+	// The type of the activity that this object is an instance of, e.g. serviceTask or userTask
+	ActivityType string `json:"activityType"`
+	// The key of the process definition that this activity instance belongs to
+	ProcessDefinitionKey string `json:"processDefinitionKey"`
+	// The id of the process definition that this activity instance belongs to
+	ProcessDefinitionId string `json:"processDefinitionId"`
+	// The id of the process instance that this activity instance belongs to
+	ProcessInstanceId string `json:"processInstanceId"`
+	// The id of the execution that executed this activity instance
+	ExecutionId string `json:"executionId"`
+	// The id of the task that is associated to this activity instance. Is only set if the activity is a user task
+	TaskId string `json:"taskId"`
+	// The assignee of the task that is associated to this activity instance. Is only set if the activity is a user task
+	Assignee string `json:"assignee"`
+	// The id of the called process instance. Is only set if the activity is a call activity and the called instance
+	// a process instance
+	CalledProcessInstanceId string `json:"calledProcessInstanceId"`
+	// The id of the called case instance. Is only set if the activity is a call activity and the called instance
+	// a case instance
+	CalledCaseInstanceId string `json:"calledCaseInstanceId"`
+	// The time the instance was started. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	StartTime string `json:"startTime"`
+	// The time the instance ended. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	EndTime string `json:"endTime"`
+	// The time the instance took to finish (in milliseconds)
+	DurationInMillis int64 `json:"durationInMillis"`
+	// If true, this activity instance is canceled
+	Canceled bool `json:"canceled"`
+	// If true, this activity instance did complete a BPMN 2.0 scope
+	CompleteScope bool `json:"completeScope"`
+	// The tenant id of the activity instance
+	TenantId string `json:"tenantId"`
+	// The time after which the activity instance should be removed by the History Cleanup job.
+	// Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	RemovalTime string `json:"removalTime"`
+	// The process instance id of the root process instance that initiated the process containing this activity instance
+	RootProcessInstanceId string `json:"rootProcessInstanceId"`
+}
+
+// QueryHistoryActivityInstanceList a typed query for GetActivityInstanceList and GetActivityInstanceCount,
+// use Params to get query parameters
+// https://docs.camunda.org/manual/latest/reference/rest/history/activity-instance/get-activity-instance-query/#query-parameters
+type QueryHistoryActivityInstanceList struct {
+	QuerySorting
+	QueryPagination
+	// Filter by activity instance id
+	ActivityInstanceId string `query:"activityInstanceId"`
+	// Filter by process instance id
+	ProcessInstanceId string `query:"processInstanceId"`
+	// Filter by process definition id
+	ProcessDefinitionId string `query:"processDefinitionId"`
+	// Filter by the id of the execution that executed the activity instance
+	ExecutionId string `query:"executionId"`
+	// Filter by the activity id (according to BPMN 2.0 XML)
+	ActivityId string `query:"activityId"`
+	// Filter by the activity name (according to BPMN 2.0 XML)
+	ActivityName string `query:"activityName"`
+	// Filter by activity type, e.g. serviceTask
+	ActivityType string `query:"activityType"`
+	// Only include activity instances that are user tasks and assigned to a given user
+	TaskAssignee string `query:"taskAssignee"`
+	// Only include finished activity instances
+	Finished bool `query:"finished"`
+	// Only include unfinished activity instances
+	Unfinished bool `query:"unfinished"`
+	// Only include canceled activity instances
+	Canceled bool `query:"canceled"`
+	// Only include activity instances which completed a scope
+	CompleteScope bool `query:"completeScope"`
+	// Restrict to instances that were started before the given date
+	StartedBefore time.Time `query:"startedBefore"`
+	// Restrict to instances that were started after the given date
+	StartedAfter time.Time `query:"startedAfter"`
+	// Restrict to instances that were finished before the given date
+	FinishedBefore time.Time `query:"finishedBefore"`
+	// Restrict to instances that were finished after the given date
+	FinishedAfter time.Time `query:"finishedAfter"`
+	// Filter by a list of tenant ids. An activity instance must have one of the given tenant ids
+	TenantIdIn []string `query:"tenantIdIn"`
+	// Only include historic activity instances that belong to no tenant
+	WithoutTenantId bool `query:"withoutTenantId"`
+}
+
+// Params returns query parameters
+func (q *QueryHistoryActivityInstanceList) Params() map[string]string {
+	return encodeQuery(q)
+}
+
+// ReqHistoryActivityInstanceQuery a JSON object of a query of GetActivityInstanceListPost
+// and GetActivityInstanceCountPost
+// https://docs.camunda.org/manual/latest/reference/rest/history/activity-instance/post-activity-instance-query/#request-body
+type ReqHistoryActivityInstanceQuery struct {
+	// Filter by activity instance id
+	ActivityInstanceId *string `json:"activityInstanceId,omitempty"`
+	// Filter by process instance id
+	ProcessInstanceId *string `json:"processInstanceId,omitempty"`
+	// Filter by process definition id
+	ProcessDefinitionId *string `json:"processDefinitionId,omitempty"`
+	// Filter by the id of the execution that executed the activity instance
+	ExecutionId *string `json:"executionId,omitempty"`
+	// Filter by the activity id (according to BPMN 2.0 XML)
+	ActivityId *string `json:"activityId,omitempty"`
+	// Filter by the activity name (according to BPMN 2.0 XML)
+	ActivityName *string `json:"activityName,omitempty"`
+	// Filter by activity type, e.g. serviceTask
+	ActivityType *string `json:"activityType,omitempty"`
+	// Only include activity instances that are user tasks and assigned to a given user
+	TaskAssignee *string `json:"taskAssignee,omitempty"`
+	// Only include finished activity instances. Value may only be true, as false is the default behavior
+	Finished *bool `json:"finished,omitempty"`
+	// Only include unfinished activity instances. Value may only be true, as false is the default behavior
+	Unfinished *bool `json:"unfinished,omitempty"`
+	// Only include canceled activity instances. Value may only be true, as false is the default behavior
+	Canceled *bool `json:"canceled,omitempty"`
+	// Only include activity instances which completed a scope. Value may only be true,
+	// as false is the default behavior
+	CompleteScope *bool `json:"completeScope,omitempty"`
+	// Restrict to instances that were started before the given date
+	StartedBefore *Time `json:"startedBefore,omitempty"`
+	// Restrict to instances that were started after the given date
+	StartedAfter *Time `json:"startedAfter,omitempty"`
+	// Restrict to instances that were finished before the given date
+	FinishedBefore *Time `json:"finishedBefore,omitempty"`
+	// Restrict to instances that were finished after the given date
+	FinishedAfter *Time `json:"finishedAfter,omitempty"`
+	// Filter by a list of tenant ids. An activity instance must have one of the given tenant ids
+	TenantIdIn []string `json:"tenantIdIn,omitempty"`
+	// Only include historic activity instances that belong to no tenant. Value may only be true,
+	// as false is the default behavior
+	WithoutTenantId *bool `json:"withoutTenantId,omitempty"`
+	// A JSON array of criteria to sort the result by. Valid values of sortBy are activityInstanceId, instanceId,
+	// executionId, activityId, activityName, activityType, startTime, endTime, duration, definitionId,
+	// occurrence and tenantId
+	Sorting []ReqSort `json:"sorting,omitempty"`
+}
+
+// GetActivityInstanceList queries for historic activity instances that fulfill the given parameters.
+// https://docs.camunda.org/manual/latest/reference/rest/history/activity-instance/get-activity-instance-query/#query-parameters
+func (h *History) GetActivityInstanceList(query map[string]string) (activityInstances []*ResHistoryActivityInstance, err error) {
+	res, err := h.client.doGet("/history/activity-instance", query)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, &activityInstances)
+	return
+}
+
+// GetActivityInstanceCount queries for the number of historic activity instances that fulfill the given parameters.
+// https://docs.camunda.org/manual/latest/reference/rest/history/activity-instance/get-activity-instance-query-count/#query-parameters
+func (h *History) GetActivityInstanceCount(query map[string]string) (count int, err error) {
+	resCount := ResCount{}
+	res, err := h.client.doGet("/history/activity-instance/count", query)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, &resCount)
+	return resCount.Count, err
+}
+
+// GetActivityInstanceListPost queries for historic activity instances that fulfill the given parameters
+// through a JSON object, sorted by the sorting of req. Query parameters are used for pagination
+// https://docs.camunda.org/manual/latest/reference/rest/history/activity-instance/post-activity-instance-query/
+func (h *History) GetActivityInstanceListPost(query map[string]string, req ReqHistoryActivityInstanceQuery) (activityInstances []*ResHistoryActivityInstance, err error) {
+	res, err := h.client.doPostJson("/history/activity-instance", query, req)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, &activityInstances)
+	return
+}
+
+// GetActivityInstanceCountPost queries for the number of historic activity instances that fulfill the given parameters
+// through a JSON object
+// https://docs.camunda.org/manual/latest/reference/rest/history/activity-instance/post-activity-instance-query-count/
+func (h *History) GetActivityInstanceCountPost(req ReqHistoryActivityInstanceQuery) (count int, err error) {
+	resCount := ResCount{}
+	res, err := h.client.doPostJson("/history/activity-instance/count", nil, req)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, &resCount)
+	return resCount.Count, err
+}
+
+// GetActivityInstanceListPostPager returns a Pager over historic activity instances that fulfill the given parameters.
+// Results are sorted by activityInstanceId after the criteria of req, so pages do not overlap
+func (h *History) GetActivityInstanceListPostPager(query map[string]string, req ReqHistoryActivityInstanceQuery, pageSize int) *Pager[*ResHistoryActivityInstance] {
+	req.Sorting = stableSorting(req.Sorting, "activityInstanceId")
+	return NewPager(func(ctx context.Context, firstResult, maxResults int) ([]*ResHistoryActivityInstance, error) {
+		return h.client.WithContext(ctx).History.GetActivityInstanceListPost(pageQuery(query, firstResult, maxResults), req)
+	}, pageSize)
+}
+
+// GetActivityInstance retrieves a historic activity instance by id, according to the HistoricActivityInstance
+// interface in the engine
+func (h *History) GetActivityInstance(id string) (activityInstance *ResHistoryActivityInstance, err error) {
+	activityInstance = &ResHistoryActivityInstance{}
+	res, err := h.client.doGet("/history/activity-instance/"+id, nil)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, activityInstance)
+	return
+}
@@ -0,0 +1,78 @@
+package camunda_client_go
+
+import (
+	"strconv"
+)
+
+// ResHistoryCleanupJob a JSON object of a history cleanup job
+type ResHistoryCleanupJob struct {
+	// The id of the job
+	Id string `json:"id"`
+	// The date on which the job is scheduled to be executed. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	DueDate string `json:"dueDate"`
+}
+
+// ResHistoryCleanupConfiguration a JSON object of the history cleanup configuration of the engine
+type ResHistoryCleanupConfiguration struct {
+	// Start time of the current or next batch window. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	BatchWindowStartTime string `json:"batchWindowStartTime"`
+	// End time of the current or next batch window. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	BatchWindowEndTime string `json:"batchWindowEndTime"`
+	// Indicates whether the engine node participates in history cleanup or not
+	Enabled bool `json:"enabled"`
+}
+
+// CleanupAsync schedules asynchronous history cleanup. If immediatelyDue is true the cleanup will be scheduled
+// for the current time, otherwise it will be scheduled according to the configured batch window.
+// Returns the first of the history cleanup jobs
+// https://docs.camunda.org/manual/latest/reference/rest/history/history-cleanup/post-history-cleanup/
+func (h *History) CleanupAsync(immediatelyDue bool) (job *ResHistoryCleanupJob, err error) {
+	job = &ResHistoryCleanupJob{}
+	res, err := h.client.doPost("/history/cleanup", map[string]string{
+		"immediatelyDue": strconv.FormatBool(immediatelyDue),
+	})
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, job)
+	return
+}
+
+// GetCleanupJob retrieves the next scheduled history cleanup job
+// https://docs.camunda.org/manual/latest/reference/rest/history/history-cleanup/get-history-cleanup-job/
+func (h *History) GetCleanupJob() (job *ResHistoryCleanupJob, err error) {
+	job = &ResHistoryCleanupJob{}
+	res, err := h.client.doGet("/history/cleanup/job", nil)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, job)
+	return
+}
+
+// GetCleanupJobs retrieves all scheduled history cleanup jobs
+// https://docs.camunda.org/manual/latest/reference/rest/history/history-cleanup/get-history-cleanup-jobs/
+func (h *History) GetCleanupJobs() (jobs []*ResHistoryCleanupJob, err error) {
+	res, err := h.client.doGet("/history/cleanup/jobs", nil)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, &jobs)
+	return
+}
+
+// GetCleanupConfiguration retrieves the history cleanup configuration of the engine
+// https://docs.camunda.org/manual/latest/reference/rest/history/history-cleanup/get-history-cleanup-configuration/
+func (h *History) GetCleanupConfiguration() (configuration *ResHistoryCleanupConfiguration, err error) {
+	configuration = &ResHistoryCleanupConfiguration{}
+	res, err := h.client.doGet("/history/cleanup/configuration", nil)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, configuration)
+	return
+}
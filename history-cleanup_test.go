@@ -0,0 +1,74 @@
+package camunda_client_go
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryCleanup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "POST /history/cleanup":
+			assert.Equal(t, "true", r.URL.Query().Get("immediatelyDue"))
+			_, _ = w.Write([]byte(`{"id": "j1", "dueDate": "2026-10-19T10:00:00.000+0000"}`))
+		case "GET /history/cleanup/jobs":
+			_, _ = w.Write([]byte(`[{"id": "j1"}, {"id": "j2"}]`))
+		case "GET /history/cleanup/configuration":
+			_, _ = w.Write([]byte(`{"batchWindowStartTime": "2026-10-19T22:00:00.000+0000", "batchWindowEndTime": "2026-10-20T06:00:00.000+0000", "enabled": true}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOptions{EndpointUrl: server.URL})
+	job, err := client.History.CleanupAsync(true)
+	require.NoError(t, err)
+	assert.Equal(t, "j1", job.Id)
+	assert.Equal(t, "2026-10-19T10:00:00.000+0000", job.DueDate)
+
+	jobs, err := client.History.GetCleanupJobs()
+	require.NoError(t, err)
+	assert.Len(t, jobs, 2)
+
+	configuration, err := client.History.GetCleanupConfiguration()
+	require.NoError(t, err)
+	assert.True(t, configuration.Enabled)
+	assert.Equal(t, "2026-10-20T06:00:00.000+0000", configuration.BatchWindowEndTime)
+}
+
+func TestHistorySetProcessInstanceRemovalTimeAsync(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/history/process-instance/set-removal-time", r.URL.Path)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "b1", "type": "process-set-removal-time", "totalJobs": 2}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOptions{EndpointUrl: server.URL})
+	calculated, hierarchical := true, true
+	batch, err := client.History.SetProcessInstanceRemovalTimeAsync(ReqHistoryProcessInstanceRemovalTime{
+		CalculatedRemovalTime:      &calculated,
+		Hierarchical:               &hierarchical,
+		HistoricProcessInstanceIds: []string{"p1", "p2"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "b1", batch.Id)
+	assert.Equal(t, 2, batch.TotalJobs)
+	assert.Equal(t, map[string]interface{}{
+		"calculatedRemovalTime":      true,
+		"hierarchical":               true,
+		"historicProcessInstanceIds": []interface{}{"p1", "p2"},
+	}, body)
+}
@@ -0,0 +1,295 @@
+package camunda_client_go
+
+import (
+	"time"
+)
+
+// ResHistoryDecisionInstance a JSON object corresponding to the HistoricDecisionInstance interface in the engine
+type ResHistoryDecisionInstance struct {
+	// The id of the decision instance
+	Id string `json:"id"`
+	// The id of the decision definition that this decision instance belongs to
+	DecisionDefinitionId string `json:"decisionDefinitionId"`
+	// The key of the decision definition that this decision instance belongs to
+	DecisionDefinitionKey string `json:"decisionDefinitionKey"`
+	// The name of the decision definition that this decision instance belongs to
+	DecisionDefinitionName string `json:"decisionDefinitionName"`
+	// The time the instance was evaluated. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	EvaluationTime string `json:"evaluationTime"`
+	// The time after which the instance should be removed by the History Cleanup job.
+	// Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	RemovalTime string `json:"removalTime"`
+	// The id of the process definition that this decision instance belongs to
+	ProcessDefinitionId string `json:"processDefinitionId"`
+	// The key of the process definition that this decision instance belongs to
+	ProcessDefinitionKey string `json:"processDefinitionKey"`
+	// The id of the process instance that this decision instance belongs to
+	ProcessInstanceId string `json:"processInstanceId"`
+	// The process instance id of the root process instance that initiated the evaluation of this decision
+	RootProcessInstanceId string `json:"rootProcessInstanceId"`
+	// The id of the case definition that this decision instance belongs to
+	CaseDefinitionId string `json:"caseDefinitionId"`
+	// The key of the case definition that this decision instance belongs to
+	CaseDefinitionKey string `json:"caseDefinitionKey"`
+	// The id of the case instance that this decision instance belongs to
+	CaseInstanceId string `json:"caseInstanceId"`
+	// The id of the activity that this decision instance belongs to
+	ActivityId string `json:"activityId"`
+	// The id of the activity instance that this decision instance belongs to
+	ActivityInstanceId string `json:"activityInstanceId"`
+	// The tenant id of the historic decision instance
+	TenantId string `json:"tenantId"`
+	// The id of the authenticated user that has evaluated this decision instance without a process or case instance
+	UserId string `json:"userId"`
+	// The list of decision input values. Only exists if includeInputs was set to true in the query
+	Inputs []*ResHistoryDecisionInput `json:"inputs"`
+	// The list of decision output values. Only exists if includeOutputs was set to true in the query
+	Outputs []*ResHistoryDecisionOutput `json:"outputs"`
+	// The result of the collect aggregation of the decision result if used
+	CollectResultValue *float64 `json:"collectResultValue"`
+	// The decision instance id of the evaluated root decision. Can be null if this instance is the root decision
+	// instance of the evaluation
+	RootDecisionInstanceId string `json:"rootDecisionInstanceId"`
+	// The id of the decision requirements definition that this decision instance belongs to
+	DecisionRequirementsDefinitionId string `json:"decisionRequirementsDefinitionId"`
+	// The key of the decision requirements definition that this decision instance belongs to
+	DecisionRequirementsDefinitionKey string `json:"decisionRequirementsDefinitionKey"`
+}
+
+// ResHistoryDecisionInput a decision input value of a historic decision instance
+type ResHistoryDecisionInput struct {
+	// The id of the decision input value
+	Id string `json:"id"`
+	// The id of the decision instance the input value belongs to
+	DecisionInstanceId string `json:"decisionInstanceId"`
+	// The id of the clause the input value belongs to
+	ClauseId string `json:"clauseId"`
+	// The name of the clause the input value belongs to
+	ClauseName string `json:"clauseName"`
+	// An error message in case a Java Serialized Object could not be de-serialized
+	ErrorMessage string `json:"errorMessage"`
+	// The value type of the variable
+	Type string `json:"type"`
+	// The time the variable was inserted. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	CreateTime string `json:"createTime"`
+	// The time after which the entry should be removed by the History Cleanup job.
+	// Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	RemovalTime string `json:"removalTime"`
+	// The process instance id of the root process instance that initiated the evaluation of this decision
+	RootProcessInstanceId string `json:"rootProcessInstanceId"`
+	// The variable's value
+	Value interface{} `json:"value"`
+	// A JSON object containing additional, value-type-dependent properties
+	ValueInfo ResProcessVariableValueInfo `json:"valueInfo"`
+}
+
+// ResHistoryDecisionOutput a decision output value of a historic decision instance
+type ResHistoryDecisionOutput struct {
+	ResHistoryDecisionInput
+	// The id of the rule the output value belongs to
+	RuleId string `json:"ruleId"`
+	// The order of the rule the output value belongs to
+	RuleOrder int `json:"ruleOrder"`
+	// The name of the output variable
+	VariableName string `json:"variableName"`
+}
+
+// QueryHistoryDecisionInstanceList a typed query for GetDecisionInstanceList and GetDecisionInstanceCount,
+// use Params to get query parameters
+// https://docs.camunda.org/manual/latest/reference/rest/history/decision-instance/get-decision-instance-query/#query-parameters
+type QueryHistoryDecisionInstanceList struct {
+	QuerySorting
+	QueryPagination
+	// Filter by decision instance id
+	DecisionInstanceId string `query:"decisionInstanceId"`
+	// Filter by decision instance ids
+	DecisionInstanceIdIn []string `query:"decisionInstanceIdIn"`
+	// Filter by the decision definition the instances belongs to
+	DecisionDefinitionId string `query:"decisionDefinitionId"`
+	// Filter by the decision definitions the instances belongs to
+	DecisionDefinitionIdIn []string `query:"decisionDefinitionIdIn"`
+	// Filter by the key of the decision definition the instances belongs to
+	DecisionDefinitionKey string `query:"decisionDefinitionKey"`
+	// Filter by the keys of the decision definition the instances belongs to
+	DecisionDefinitionKeyIn []string `query:"decisionDefinitionKeyIn"`
+	// Filter by the name of the decision definition the instances belongs to
+	DecisionDefinitionName string `query:"decisionDefinitionName"`
+	// Filter by the name of the decision definition the instances belongs to, that the parameter is a substring of
+	DecisionDefinitionNameLike string `query:"decisionDefinitionNameLike"`
+	// Filter by the process definition the instances belongs to
+	ProcessDefinitionId string `query:"processDefinitionId"`
+	// Filter by the key of the process definition the instances belongs to
+	ProcessDefinitionKey string `query:"processDefinitionKey"`
+	// Filter by the process instance the instances belongs to
+	ProcessInstanceId string `query:"processInstanceId"`
+	// Filter by the case definition the instances belongs to
+	CaseDefinitionId string `query:"caseDefinitionId"`
+	// Filter by the key of the case definition the instances belongs to
+	CaseDefinitionKey string `query:"caseDefinitionKey"`
+	// Filter by the case instance the instances belongs to
+	CaseInstanceId string `query:"caseInstanceId"`
+	// Filter by the activity ids the instances belongs to
+	ActivityIdIn []string `query:"activityIdIn"`
+	// Filter by the activity instance ids the instances belongs to
+	ActivityInstanceIdIn []string `query:"activityInstanceIdIn"`
+	// Filter by a list of tenant ids. A historic decision instance must have one of the given tenant ids
+	TenantIdIn []string `query:"tenantIdIn"`
+	// Only include historic decision instances that belong to no tenant
+	WithoutTenantId bool `query:"withoutTenantId"`
+	// Restrict to instances that were evaluated before the given date
+	EvaluatedBefore time.Time `query:"evaluatedBefore"`
+	// Restrict to instances that were evaluated after the given date
+	EvaluatedAfter time.Time `query:"evaluatedAfter"`
+	// Restrict to instances that were evaluated by the given user
+	UserId string `query:"userId"`
+	// Restrict to instances that have a given root decision instance id
+	RootDecisionInstanceId string `query:"rootDecisionInstanceId"`
+	// Restrict to instances those are the root decision instance of an evaluation
+	RootDecisionInstancesOnly bool `query:"rootDecisionInstancesOnly"`
+	// Filter by the decision requirements definition the instances belongs to
+	DecisionRequirementsDefinitionId string `query:"decisionRequirementsDefinitionId"`
+	// Filter by the key of the decision requirements definition the instances belongs to
+	DecisionRequirementsDefinitionKey string `query:"decisionRequirementsDefinitionKey"`
+	// Include input values in the result
+	IncludeInputs bool `query:"includeInputs"`
+	// Include output values in the result
+	IncludeOutputs bool `query:"includeOutputs"`
+	// Disables fetching of byte array input and output values
+	DisableBinaryFetching bool `query:"disableBinaryFetching"`
+	// Disables deserialization of input and output values that are custom objects
+	DisableCustomObjectDeserialization bool `query:"disableCustomObjectDeserialization"`
+}
+
+// Params returns query parameters
+func (q *QueryHistoryDecisionInstanceList) Params() map[string]string {
+	return encodeQuery(q)
+}
+
+// ReqHistoryDecisionInstanceQuery a JSON object of a historic decision instance query used by
+// DeleteDecisionInstanceAsync and SetDecisionInstanceRemovalTimeAsync
+type ReqHistoryDecisionInstanceQuery struct {
+	// Filter by decision instance id
+	DecisionInstanceId *string `json:"decisionInstanceId,omitempty"`
+	// Filter by decision instance ids
+	DecisionInstanceIdIn []string `json:"decisionInstanceIdIn,omitempty"`
+	// Filter by the decision definition the instances belongs to
+	DecisionDefinitionId *string `json:"decisionDefinitionId,omitempty"`
+	// Filter by the decision definitions the instances belongs to
+	DecisionDefinitionIdIn []string `json:"decisionDefinitionIdIn,omitempty"`
+	// Filter by the key of the decision definition the instances belongs to
+	DecisionDefinitionKey *string `json:"decisionDefinitionKey,omitempty"`
+	// Filter by the keys of the decision definition the instances belongs to
+	DecisionDefinitionKeyIn []string `json:"decisionDefinitionKeyIn,omitempty"`
+	// Filter by the process definition the instances belongs to
+	ProcessDefinitionId *string `json:"processDefinitionId,omitempty"`
+	// Filter by the key of the process definition the instances belongs to
+	ProcessDefinitionKey *string `json:"processDefinitionKey,omitempty"`
+	// Filter by the process instance the instances belongs to
+	ProcessInstanceId *string `json:"processInstanceId,omitempty"`
+	// Filter by a list of tenant ids. A historic decision instance must have one of the given tenant ids
+	TenantIdIn []string `json:"tenantIdIn,omitempty"`
+	// Only include historic decision instances that belong to no tenant. Value may only be true,
+	// as false is the default behavior
+	WithoutTenantId *bool `json:"withoutTenantId,omitempty"`
+	// Restrict to instances that were evaluated before the given date
+	EvaluatedBefore *Time `json:"evaluatedBefore,omitempty"`
+	// Restrict to instances that were evaluated after the given date
+	EvaluatedAfter *Time `json:"evaluatedAfter,omitempty"`
+	// Restrict to instances that were evaluated by the given user
+	UserId *string `json:"userId,omitempty"`
+	// Restrict to instances those are the root decision instance of an evaluation. Value may only be true,
+	// as false is the default behavior
+	RootDecisionInstancesOnly *bool `json:"rootDecisionInstancesOnly,omitempty"`
+}
+
+// ReqHistoryDeleteDecisionInstance a JSON object of DeleteDecisionInstanceAsync,
+// either ids or a query must be given
+type ReqHistoryDeleteDecisionInstance struct {
+	// A list of historic decision instance ids to delete
+	HistoricDecisionInstanceIds []string `json:"historicDecisionInstanceIds,omitempty"`
+	// A historic decision instance query
+	HistoricDecisionInstanceQuery *ReqHistoryDecisionInstanceQuery `json:"historicDecisionInstanceQuery,omitempty"`
+	// A string with delete reason
+	DeleteReason *string `json:"deleteReason,omitempty"`
+}
+
+// ReqHistoryDecisionInstanceRemovalTime a JSON object of SetDecisionInstanceRemovalTimeAsync,
+// exactly one of AbsoluteRemovalTime, ClearedRemovalTime and CalculatedRemovalTime must be given
+type ReqHistoryDecisionInstanceRemovalTime struct {
+	// The date for which the historic decision instances shall be removed
+	AbsoluteRemovalTime *Time `json:"absoluteRemovalTime,omitempty"`
+	// Sets the removal time to null
+	ClearedRemovalTime *bool `json:"clearedRemovalTime,omitempty"`
+	// The removal time is calculated based on the engine's configuration settings
+	CalculatedRemovalTime *bool `json:"calculatedRemovalTime,omitempty"`
+	// Sets the removal time to all historic decision instances in the hierarchy
+	Hierarchical *bool `json:"hierarchical,omitempty"`
+	// A list of historic decision instance ids
+	HistoricDecisionInstanceIds []string `json:"historicDecisionInstanceIds,omitempty"`
+	// A historic decision instance query
+	HistoricDecisionInstanceQuery *ReqHistoryDecisionInstanceQuery `json:"historicDecisionInstanceQuery,omitempty"`
+}
+
+// GetDecisionInstanceList queries for historic decision instances that fulfill the given parameters,
+// set includeInputs and includeOutputs to get the evaluated inputs and outputs.
+// https://docs.camunda.org/manual/latest/reference/rest/history/decision-instance/get-decision-instance-query/#query-parameters
+func (h *History) GetDecisionInstanceList(query map[string]string) (decisionInstances []*ResHistoryDecisionInstance, err error) {
+	res, err := h.client.doGet("/history/decision-instance", query)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, &decisionInstances)
+	return
+}
+
+// GetDecisionInstanceCount queries for the number of historic decision instances that fulfill the given parameters.
+// https://docs.camunda.org/manual/latest/reference/rest/history/decision-instance/get-decision-instance-query-count/#query-parameters
+func (h *History) GetDecisionInstanceCount(query map[string]string) (count int, err error) {
+	resCount := ResCount{}
+	res, err := h.client.doGet("/history/decision-instance/count", query)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, &resCount)
+	return resCount.Count, err
+}
+
+// GetDecisionInstance retrieves a historic decision instance by id, set includeInputs and includeOutputs
+// in query to get the evaluated inputs and outputs
+func (h *History) GetDecisionInstance(id string, query map[string]string) (decisionInstance *ResHistoryDecisionInstance, err error) {
+	decisionInstance = &ResHistoryDecisionInstance{}
+	res, err := h.client.doGet("/history/decision-instance/"+id, query)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, decisionInstance)
+	return
+}
+
+// DeleteDecisionInstanceAsync deletes multiple historic decision instances asynchronously (batch)
+func (h *History) DeleteDecisionInstanceAsync(req ReqHistoryDeleteDecisionInstance) (batch *ResBatch, err error) {
+	batch = &ResBatch{}
+	res, err := h.client.doPostJson("/history/decision-instance/delete", nil, req)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, batch)
+	return
+}
+
+// SetDecisionInstanceRemovalTimeAsync sets the removal time of multiple historic decision instances
+// asynchronously (batch)
+func (h *History) SetDecisionInstanceRemovalTimeAsync(req ReqHistoryDecisionInstanceRemovalTime) (batch *ResBatch, err error) {
+	batch = &ResBatch{}
+	res, err := h.client.doPostJson("/history/decision-instance/set-removal-time", nil, req)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, batch)
+	return
+}
@@ -0,0 +1,105 @@
+package camunda_client_go
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryDecisionInstanceList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/history/decision-instance", r.URL.Path)
+		assert.Equal(t, "loan-42", r.URL.Query().Get("processInstanceId"))
+		assert.Equal(t, "true", r.URL.Query().Get("includeInputs"))
+		assert.Equal(t, "true", r.URL.Query().Get("includeOutputs"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{
+			"id": "d1",
+			"decisionDefinitionKey": "risk",
+			"inputs": [{"clauseId": "in1", "clauseName": "amount", "type": "Integer", "value": 500}],
+			"outputs": [{"clauseId": "out1", "ruleId": "rule3", "ruleOrder": 3, "variableName": "risk", "type": "String", "value": "low"}]
+		}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOptions{EndpointUrl: server.URL})
+	decisionInstances, err := client.History.GetDecisionInstanceList((&QueryHistoryDecisionInstanceList{
+		ProcessInstanceId: "loan-42",
+		IncludeInputs:     true,
+		IncludeOutputs:    true,
+	}).Params())
+	require.NoError(t, err)
+	require.Len(t, decisionInstances, 1)
+	require.Len(t, decisionInstances[0].Inputs, 1)
+	require.Len(t, decisionInstances[0].Outputs, 1)
+	assert.Equal(t, "amount", decisionInstances[0].Inputs[0].ClauseName)
+	assert.Equal(t, float64(500), decisionInstances[0].Inputs[0].Value)
+	assert.Equal(t, "rule3", decisionInstances[0].Outputs[0].RuleId)
+	assert.Equal(t, "out1", decisionInstances[0].Outputs[0].ClauseId)
+	assert.Equal(t, "low", decisionInstances[0].Outputs[0].Value)
+}
+
+func TestHistoryDecisionInstanceRemovalTimeAsync(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/history/decision-instance/set-removal-time", r.URL.Path)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "b1", "type": "decision-set-removal-time", "totalJobs": 1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOptions{EndpointUrl: server.URL})
+	key := "risk"
+	batch, err := client.History.SetDecisionInstanceRemovalTimeAsync(ReqHistoryDecisionInstanceRemovalTime{
+		AbsoluteRemovalTime:           &Time{time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)},
+		HistoricDecisionInstanceQuery: &ReqHistoryDecisionInstanceQuery{DecisionDefinitionKey: &key},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "b1", batch.Id)
+	assert.Equal(t, map[string]interface{}{"decisionDefinitionKey": "risk"}, body["historicDecisionInstanceQuery"])
+	assert.Contains(t, body["absoluteRemovalTime"], "2030-01-02T03:04:05")
+	assert.NotContains(t, body, "clearedRemovalTime")
+}
+
+func TestHistoryUserOperationAnnotation(t *testing.T) {
+	var paths []string
+	var annotation map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/history/user-operation":
+			assert.Equal(t, "Suspend", r.URL.Query().Get("operationType"))
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[{"id": "u1", "userId": "demo", "operationId": "op1", "operationType": "Suspend", "entityType": "ProcessInstance"}]`))
+			return
+		case "/history/user-operation/op1/set-annotation":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&annotation))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOptions{EndpointUrl: server.URL})
+	operations, err := client.History.GetUserOperationList((&QueryHistoryUserOperationList{OperationType: "Suspend"}).Params())
+	require.NoError(t, err)
+	require.Len(t, operations, 1)
+	assert.Equal(t, "demo", operations[0].UserId)
+
+	require.NoError(t, client.History.SetUserOperationAnnotation("op1", "audit 2026-10"))
+	require.NoError(t, client.History.ClearUserOperationAnnotation("op1"))
+	assert.Equal(t, map[string]string{"annotation": "audit 2026-10"}, annotation)
+	assert.Equal(t, []string{
+		"GET /history/user-operation",
+		"PUT /history/user-operation/op1/set-annotation",
+		"PUT /history/user-operation/op1/clear-annotation",
+	}, paths)
+}
@@ -0,0 +1,181 @@
+package camunda_client_go
+
+import (
+	"context"
+	"time"
+)
+
+// Types of historic details
+const (
+	HistoryDetailTypeFormField      = "formField"
+	HistoryDetailTypeVariableUpdate = "variableUpdate"
+)
+
+// ResHistoryDetail a JSON object corresponding to the HistoricDetail interface in the engine, either a variable
+// update (Type is variableUpdate) or a submitted form field (Type is formField)
+type ResHistoryDetail struct {
+	// The id of the historic detail
+	Id string `json:"id"`
+	// The type of the historic detail, either formField or variableUpdate
+	Type string `json:"type"`
+	// The key of the process definition that this historic detail belongs to
+	ProcessDefinitionKey string `json:"processDefinitionKey"`
+	// The id of the process definition that this historic detail belongs to
+	ProcessDefinitionId string `json:"processDefinitionId"`
+	// The id of the process instance the historic detail belongs to
+	ProcessInstanceId string `json:"processInstanceId"`
+	// The id of the activity instance the historic detail belongs to
+	ActivityInstanceId string `json:"activityInstanceId"`
+	// The id of the execution the historic detail belongs to
+	ExecutionId string `json:"executionId"`
+	// The key of the case definition that this historic detail belongs to
+	CaseDefinitionKey string `json:"caseDefinitionKey"`
+	// The id of the case definition that this historic detail belongs to
+	CaseDefinitionId string `json:"caseDefinitionId"`
+	// The id of the case instance the historic detail belongs to
+	CaseInstanceId string `json:"caseInstanceId"`
+	// The id of the case execution the historic detail belongs to
+	CaseExecutionId string `json:"caseExecutionId"`
+	// The id of the task the historic detail belongs to
+	TaskId string `json:"taskId"`
+	// The id of the tenant that this historic detail belongs to
+	TenantId string `json:"tenantId"`
+	// The id of user operation which links historic detail with user operation log entries
+	UserOperationId string `json:"userOperationId"`
+	// The time when this historic detail occurred. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	Time string `json:"time"`
+	// The time after which the historic detail should be removed by the History Cleanup job.
+	// Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	RemovalTime string `json:"removalTime"`
+	// The process instance id of the root process instance that initiated the process containing this historic detail
+	RootProcessInstanceId string `json:"rootProcessInstanceId"`
+
+	// The name of the variable which has been updated. Only set for variable updates
+	VariableName string `json:"variableName"`
+	// The id of the associated variable instance. Only set for variable updates
+	VariableInstanceId string `json:"variableInstanceId"`
+	// The value type of the variable. Only set for variable updates
+	VariableType string `json:"variableType"`
+	// The variable's value. Value differs depending on the variable's type and on the deserializeValues parameter.
+	// Only set for variable updates
+	Value interface{} `json:"value"`
+	// A JSON object containing additional, value-type-dependent properties. Only set for variable updates
+	ValueInfo ResProcessVariableValueInfo `json:"valueInfo"`
+	// Returns true for variable updates that contains the initial values of the variables. Only set for variable updates
+	Initial bool `json:"initial"`
+	// The revision of the historic variable update. Only set for variable updates
+	Revision int `json:"revision"`
+	// The name of the serializer used to serialize the variable. Only set for variable updates
+	SerializerName string `json:"serializerName"`
+	// An error message in case a Java Serialized Object could not be de-serialized. Only set for variable updates
+	ErrorMessage string `json:"errorMessage"`
+
+	// The id of the form field. Only set for form fields
+	FieldId string `json:"fieldId"`
+	// The submitted form field value. Only set for form fields
+	FieldValue interface{} `json:"fieldValue"`
+}
+
+// QueryHistoryDetailList a typed query for GetDetailList and GetDetailCount, use Params to get query parameters
+// https://docs.camunda.org/manual/latest/reference/rest/history/detail/get-detail-query/#query-parameters
+type QueryHistoryDetailList struct {
+	QuerySorting
+	QueryPagination
+	// Filter by process instance id
+	ProcessInstanceId string `query:"processInstanceId"`
+	// Only include historic details which belong to one of the passed process instance ids
+	ProcessInstanceIdIn []string `query:"processInstanceIdIn"`
+	// Filter by execution id
+	ExecutionId string `query:"executionId"`
+	// Filter by task id
+	TaskId string `query:"taskId"`
+	// Filter by activity instance id
+	ActivityInstanceId string `query:"activityInstanceId"`
+	// Filter by case instance id
+	CaseInstanceId string `query:"caseInstanceId"`
+	// Filter by case execution id
+	CaseExecutionId string `query:"caseExecutionId"`
+	// Filter by variable instance id
+	VariableInstanceId string `query:"variableInstanceId"`
+	// Only include historic details where the variable updates belong to one of the passed variable types
+	VariableTypeIn []string `query:"variableTypeIn"`
+	// Filter by a list of tenant ids
+	TenantIdIn []string `query:"tenantIdIn"`
+	// Only include historic details that belong to no tenant
+	WithoutTenantId bool `query:"withoutTenantId"`
+	// Filter by a user operation id
+	UserOperationId string `query:"userOperationId"`
+	// Only include form fields
+	FormFields bool `query:"formFields"`
+	// Only include variable updates
+	VariableUpdates bool `query:"variableUpdates"`
+	// Excludes all task-related historic details, so only variable updates and form fields of process and case
+	// executions are returned
+	ExcludeTaskDetails bool `query:"excludeTaskDetails"`
+	// Only include historic variable updates that contain the initial values of the variables
+	Initial bool `query:"initial"`
+	// Restrict to historic details that occurred before the given date
+	OccurredBefore time.Time `query:"occurredBefore"`
+	// Restrict to historic details that occurred after the given date
+	OccurredAfter time.Time `query:"occurredAfter"`
+	// Determines whether serializable variable values (typically variables that store custom Java objects)
+	// should be deserialized on server side, default true
+	DeserializeValues *bool `query:"deserializeValues"`
+}
+
+// Params returns query parameters
+func (q *QueryHistoryDetailList) Params() map[string]string {
+	return encodeQuery(q)
+}
+
+// GetDetailList queries for historic details that fulfill the given parameters, set variableUpdates
+// or formFields to restrict the result to one type of details.
+// https://docs.camunda.org/manual/latest/reference/rest/history/detail/get-detail-query/#query-parameters
+func (h *History) GetDetailList(query map[string]string) (details []*ResHistoryDetail, err error) {
+	res, err := h.client.doGet("/history/detail", query)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, &details)
+	return
+}
+
+// GetDetailListPager returns a Pager over historic details that fulfill the given parameters.
+// Results are sorted in the order of occurrence unless sortBy is set in query
+func (h *History) GetDetailListPager(query map[string]string, pageSize int) *Pager[*ResHistoryDetail] {
+	if query["sortBy"] == "" {
+		query = pageQuery(query, 0, 0)
+		query["sortBy"] = "occurrence"
+		query["sortOrder"] = "asc"
+	}
+
+	return NewPager(func(ctx context.Context, firstResult, maxResults int) ([]*ResHistoryDetail, error) {
+		return h.client.WithContext(ctx).History.GetDetailList(pageQuery(query, firstResult, maxResults))
+	}, pageSize)
+}
+
+// GetDetailCount queries for the number of historic details that fulfill the given parameters.
+// https://docs.camunda.org/manual/latest/reference/rest/history/detail/get-detail-query-count/#query-parameters
+func (h *History) GetDetailCount(query map[string]string) (count int, err error) {
+	resCount := ResCount{}
+	res, err := h.client.doGet("/history/detail/count", query)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, &resCount)
+	return resCount.Count, err
+}
+
+// GetDetail retrieves a historic detail by id
+func (h *History) GetDetail(id string, query map[string]string) (detail *ResHistoryDetail, err error) {
+	detail = &ResHistoryDetail{}
+	res, err := h.client.doGet("/history/detail/"+id, query)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, detail)
+	return
+}
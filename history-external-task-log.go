@@ -0,0 +1,167 @@
+package camunda_client_go
+
+import (
+	"context"
+	"io/ioutil"
+)
+
+// ResHistoryExternalTaskLog a JSON object corresponding to the HistoricExternalTaskLog interface in the engine
+type ResHistoryExternalTaskLog struct {
+	// The id of the log entry
+	Id string `json:"id"`
+	// The time when the log entry has been written. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	Timestamp string `json:"timestamp"`
+	// The time after which the log entry should be removed by the History Cleanup job.
+	// Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	RemovalTime string `json:"removalTime"`
+	// The id of the external task
+	ExternalTaskId string `json:"externalTaskId"`
+	// The topic name of the associated external task
+	TopicName string `json:"topicName"`
+	// The id of the worker that fetched the external task most recently
+	WorkerId string `json:"workerId"`
+	// The priority of the external task
+	Priority int64 `json:"priority"`
+	// The number of retries the associated external task has left
+	Retries *int `json:"retries"`
+	// The error message submitted with the latest reported failure executing this task
+	ErrorMessage string `json:"errorMessage"`
+	// The id of the activity on which the associated external task was created
+	ActivityId string `json:"activityId"`
+	// The id of the activity instance on which the associated external task was created
+	ActivityInstanceId string `json:"activityInstanceId"`
+	// The id of the execution on which the associated external task was created
+	ExecutionId string `json:"executionId"`
+	// The id of the process instance on which the associated external task was created
+	ProcessInstanceId string `json:"processInstanceId"`
+	// The id of the process definition which the associated external task belongs to
+	ProcessDefinitionId string `json:"processDefinitionId"`
+	// The key of the process definition which the associated external task belongs to
+	ProcessDefinitionKey string `json:"processDefinitionKey"`
+	// The id of the tenant that this historic external task log entry belongs to
+	TenantId string `json:"tenantId"`
+	// The process instance id of the root process instance that initiated the process containing this log
+	RootProcessInstanceId string `json:"rootProcessInstanceId"`
+	// If true, this log represents the creation of the associated external task
+	CreationLog bool `json:"creationLog"`
+	// If true, this log represents the failed execution of the associated external task
+	FailureLog bool `json:"failureLog"`
+	// If true, this log represents the successful execution of the associated external task
+	SuccessLog bool `json:"successLog"`
+	// If true, this log represents the deletion of the associated external task
+	DeletionLog bool `json:"deletionLog"`
+}
+
+// QueryHistoryExternalTaskLogList a typed query for GetExternalTaskLogList and GetExternalTaskLogCount,
+// use Params to get query parameters
+// https://docs.camunda.org/manual/latest/reference/rest/history/external-task-log/get-external-task-log-query/#query-parameters
+type QueryHistoryExternalTaskLogList struct {
+	QuerySorting
+	QueryPagination
+	// Filter by historic external task log id
+	LogId string `query:"logId"`
+	// Filter by external task id
+	ExternalTaskId string `query:"externalTaskId"`
+	// Filter by an external task topic
+	TopicName string `query:"topicName"`
+	// Filter by the id of the worker that the task was most recently locked by
+	WorkerId string `query:"workerId"`
+	// Filter by external task exception message
+	ErrorMessage string `query:"errorMessage"`
+	// Only include historic external task logs which belong to one of the passed activity ids
+	ActivityIdIn []string `query:"activityIdIn"`
+	// Only include historic external task logs which belong to one of the passed activity instance ids
+	ActivityInstanceIdIn []string `query:"activityInstanceIdIn"`
+	// Only include historic external task logs which belong to one of the passed execution ids
+	ExecutionIdIn []string `query:"executionIdIn"`
+	// Filter by process instance id
+	ProcessInstanceId string `query:"processInstanceId"`
+	// Filter by process definition id
+	ProcessDefinitionId string `query:"processDefinitionId"`
+	// Filter by process definition key
+	ProcessDefinitionKey string `query:"processDefinitionKey"`
+	// Only include historic external task log entries which belong to one of the passed tenant ids
+	TenantIdIn []string `query:"tenantIdIn"`
+	// Only include historic external task log entries that belong to no tenant
+	WithoutTenantId bool `query:"withoutTenantId"`
+	// Only include logs for which the associated external task had a priority lower than or equal to the given value
+	PriorityLowerThanOrEquals *int64 `query:"priorityLowerThanOrEquals"`
+	// Only include logs for which the associated external task had a priority higher than or equal to the given value
+	PriorityHigherThanOrEquals *int64 `query:"priorityHigherThanOrEquals"`
+	// Only include creation logs
+	CreationLog bool `query:"creationLog"`
+	// Only include failure logs
+	FailureLog bool `query:"failureLog"`
+	// Only include success logs
+	SuccessLog bool `query:"successLog"`
+	// Only include deletion logs
+	DeletionLog bool `query:"deletionLog"`
+}
+
+// Params returns query parameters
+func (q *QueryHistoryExternalTaskLogList) Params() map[string]string {
+	return encodeQuery(q)
+}
+
+// GetExternalTaskLogList queries for historic external task logs that fulfill the given parameters.
+// https://docs.camunda.org/manual/latest/reference/rest/history/external-task-log/get-external-task-log-query/#query-parameters
+func (h *History) GetExternalTaskLogList(query map[string]string) (externalTaskLogs []*ResHistoryExternalTaskLog, err error) {
+	res, err := h.client.doGet("/history/external-task-log", query)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, &externalTaskLogs)
+	return
+}
+
+// GetExternalTaskLogListPager returns a Pager over historic external task logs that fulfill the given parameters.
+// Without sortBy in query the engine orders results by log id, so pages do not overlap. The API has no unique
+// sort criterion for external task logs, so pages sorted by sortBy may overlap on equal values
+func (h *History) GetExternalTaskLogListPager(query map[string]string, pageSize int) *Pager[*ResHistoryExternalTaskLog] {
+	return NewPager(func(ctx context.Context, firstResult, maxResults int) ([]*ResHistoryExternalTaskLog, error) {
+		return h.client.WithContext(ctx).History.GetExternalTaskLogList(pageQuery(query, firstResult, maxResults))
+	}, pageSize)
+}
+
+// GetExternalTaskLogCount queries for the number of historic external task logs that fulfill the given parameters.
+// https://docs.camunda.org/manual/latest/reference/rest/history/external-task-log/get-external-task-log-query-count/#query-parameters
+func (h *History) GetExternalTaskLogCount(query map[string]string) (count int, err error) {
+	resCount := ResCount{}
+	res, err := h.client.doGet("/history/external-task-log/count", query)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, &resCount)
+	return resCount.Count, err
+}
+
+// GetExternalTaskLog retrieves a historic external task log by id
+func (h *History) GetExternalTaskLog(id string) (externalTaskLog *ResHistoryExternalTaskLog, err error) {
+	externalTaskLog = &ResHistoryExternalTaskLog{}
+	res, err := h.client.doGet("/history/external-task-log/"+id, nil)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, externalTaskLog)
+	return
+}
+
+// GetExternalTaskLogErrorDetails retrieves the error details reported by a worker with a failure
+// of the passed historic external task log id
+func (h *History) GetExternalTaskLogErrorDetails(id string) (errorDetails string, err error) {
+	res, err := h.client.doGet("/history/external-task-log/"+id+"/error-details", nil)
+	if err != nil {
+		return
+	}
+
+	defer res.Body.Close()
+	rawData, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+
+	return string(rawData), nil
+}
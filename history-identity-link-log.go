@@ -0,0 +1,98 @@
+package camunda_client_go
+
+import (
+	"time"
+)
+
+// ResHistoryIdentityLinkLog a JSON object corresponding to the HistoricIdentityLinkLog interface in the engine
+type ResHistoryIdentityLinkLog struct {
+	// The id of the identity link log entry
+	Id string `json:"id"`
+	// The time when this log occurred. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	Time string `json:"time"`
+	// The type of identity link, e.g. assignee, owner or candidate
+	Type string `json:"type"`
+	// The id of the user/assignee
+	UserId string `json:"userId"`
+	// The id of the group
+	GroupId string `json:"groupId"`
+	// The id of the task
+	TaskId string `json:"taskId"`
+	// The id of the process definition
+	ProcessDefinitionId string `json:"processDefinitionId"`
+	// The key of the process definition
+	ProcessDefinitionKey string `json:"processDefinitionKey"`
+	// The type of identity link history, add or delete
+	OperationType string `json:"operationType"`
+	// The id of the assigner
+	AssignerId string `json:"assignerId"`
+	// The id of the tenant
+	TenantId string `json:"tenantId"`
+	// The time after which the identity link should be removed by the History Cleanup job.
+	// Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	RemovalTime string `json:"removalTime"`
+	// The process instance id of the root process instance that initiated the process containing this identity link
+	RootProcessInstanceId string `json:"rootProcessInstanceId"`
+}
+
+// QueryHistoryIdentityLinkLogList a typed query for GetIdentityLinkLogList and GetIdentityLinkLogCount,
+// use Params to get query parameters
+// https://docs.camunda.org/manual/latest/reference/rest/history/identity-links/get-identity-link-query/#query-parameters
+type QueryHistoryIdentityLinkLogList struct {
+	QuerySorting
+	QueryPagination
+	// Restricts to identity links that have the specified type, e.g. assignee, owner or candidate
+	Type string `query:"type"`
+	// Restricts to identity links that have the specified user id
+	UserId string `query:"userId"`
+	// Restricts to identity links that have the specified group id
+	GroupId string `query:"groupId"`
+	// Restricts to identity links that were added before the given date
+	DateBefore time.Time `query:"dateBefore"`
+	// Restricts to identity links that were added after the given date
+	DateAfter time.Time `query:"dateAfter"`
+	// Restricts to identity links that have the specified task id
+	TaskId string `query:"taskId"`
+	// Restricts to identity links that have the specified process definition id
+	ProcessDefinitionId string `query:"processDefinitionId"`
+	// Restricts to identity links that have the specified process definition key
+	ProcessDefinitionKey string `query:"processDefinitionKey"`
+	// Restricts to identity links that have the specified operation type, add or delete
+	OperationType string `query:"operationType"`
+	// Restricts to identity links that have the specified assigner id
+	AssignerId string `query:"assignerId"`
+	// Filter by a list of tenant ids
+	TenantIdIn []string `query:"tenantIdIn"`
+	// Only include identity links that belong to no tenant
+	WithoutTenantId bool `query:"withoutTenantId"`
+}
+
+// Params returns query parameters
+func (q *QueryHistoryIdentityLinkLogList) Params() map[string]string {
+	return encodeQuery(q)
+}
+
+// GetIdentityLinkLogList queries for historic identity link logs that fulfill the given parameters.
+// https://docs.camunda.org/manual/latest/reference/rest/history/identity-links/get-identity-link-query/#query-parameters
+func (h *History) GetIdentityLinkLogList(query map[string]string) (identityLinkLogs []*ResHistoryIdentityLinkLog, err error) {
+	res, err := h.client.doGet("/history/identity-link-log", query)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, &identityLinkLogs)
+	return
+}
+
+// GetIdentityLinkLogCount queries for the number of historic identity link logs that fulfill the given parameters.
+// https://docs.camunda.org/manual/latest/reference/rest/history/identity-links/get-identity-link-query-count/#query-parameters
+func (h *History) GetIdentityLinkLogCount(query map[string]string) (count int, err error) {
+	resCount := ResCount{}
+	res, err := h.client.doGet("/history/identity-link-log/count", query)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, &resCount)
+	return resCount.Count, err
+}
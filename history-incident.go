@@ -0,0 +1,159 @@
+package camunda_client_go
+
+import (
+	"context"
+	"time"
+)
+
+// ResHistoryIncident a JSON object corresponding to the HistoricIncident interface in the engine
+type ResHistoryIncident struct {
+	// The id of the incident
+	Id string `json:"id"`
+	// The key of the process definition this incident is associated with
+	ProcessDefinitionKey string `json:"processDefinitionKey"`
+	// The id of the process definition this incident is associated with
+	ProcessDefinitionId string `json:"processDefinitionId"`
+	// The id of the process instance this incident is associated with
+	ProcessInstanceId string `json:"processInstanceId"`
+	// The id of the execution this incident is associated with
+	ExecutionId string `json:"executionId"`
+	// The process instance id of the root process instance that initiated the process containing this incident
+	RootProcessInstanceId string `json:"rootProcessInstanceId"`
+	// The time this incident happened. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	CreateTime string `json:"createTime"`
+	// The time this incident has been deleted or resolved. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	EndTime string `json:"endTime"`
+	// The time after which the incident should be removed by the History Cleanup job.
+	// Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	RemovalTime string `json:"removalTime"`
+	// The type of incident, for example: failedJob will be returned in case of an incident which identified
+	// a failed job during the execution of a process instance
+	IncidentType string `json:"incidentType"`
+	// The id of the activity this incident is associated with
+	ActivityId string `json:"activityId"`
+	// The id of the activity on which the last exception occurred
+	FailedActivityId string `json:"failedActivityId"`
+	// The id of the associated cause incident which has been triggered
+	CauseIncidentId string `json:"causeIncidentId"`
+	// The id of the associated root cause incident which has been triggered
+	RootCauseIncidentId string `json:"rootCauseIncidentId"`
+	// The payload of this incident, e.g. the id of the failed job or external task
+	Configuration string `json:"configuration"`
+	// The payload of this incident at the time when it occurred, e.g. the id of the job log entry of the failure
+	HistoryConfiguration string `json:"historyConfiguration"`
+	// The message of this incident
+	IncidentMessage string `json:"incidentMessage"`
+	// The id of the tenant this incident is associated with
+	TenantId string `json:"tenantId"`
+	// The job definition id the incident is associated with
+	JobDefinitionId string `json:"jobDefinitionId"`
+	// If true, this incident is open
+	Open bool `json:"open"`
+	// If true, this incident has been deleted
+	Deleted bool `json:"deleted"`
+	// If true, this incident has been resolved
+	Resolved bool `json:"resolved"`
+	// The annotation set to the incident
+	Annotation string `json:"annotation"`
+}
+
+// QueryHistoryIncidentList a typed query for GetIncidentList and GetIncidentCount, use Params to get query parameters
+// https://docs.camunda.org/manual/latest/reference/rest/history/incident/get-incident-query/#query-parameters
+type QueryHistoryIncidentList struct {
+	QuerySorting
+	QueryPagination
+	// Restricts to incidents that have the given id
+	IncidentId string `query:"incidentId"`
+	// Restricts to incidents that belong to the given incident type
+	IncidentType string `query:"incidentType"`
+	// Restricts to incidents that have the given incident message
+	IncidentMessage string `query:"incidentMessage"`
+	// Restricts to incidents that incidents message is a substring of the given value
+	IncidentMessageLike string `query:"incidentMessageLike"`
+	// Restricts to incidents that belong to a process definition with the given id
+	ProcessDefinitionId string `query:"processDefinitionId"`
+	// Restricts to incidents that belong to a process definition with the given key
+	ProcessDefinitionKey string `query:"processDefinitionKey"`
+	// Restricts to incidents that belong to a process definition with one of the given keys
+	ProcessDefinitionKeyIn []string `query:"processDefinitionKeyIn"`
+	// Restricts to incidents that belong to a process instance with the given id
+	ProcessInstanceId string `query:"processInstanceId"`
+	// Restricts to incidents that belong to an execution with the given id
+	ExecutionId string `query:"executionId"`
+	// Restricts to incidents that were created before the given date
+	CreateTimeBefore time.Time `query:"createTimeBefore"`
+	// Restricts to incidents that were created after the given date
+	CreateTimeAfter time.Time `query:"createTimeAfter"`
+	// Restricts to incidents that were resolved or deleted before the given date
+	EndTimeBefore time.Time `query:"endTimeBefore"`
+	// Restricts to incidents that were resolved or deleted after the given date
+	EndTimeAfter time.Time `query:"endTimeAfter"`
+	// Restricts to incidents that belong to an activity with the given id
+	ActivityId string `query:"activityId"`
+	// Restricts to incidents that were created due to the failure of an activity with the given id
+	FailedActivityId string `query:"failedActivityId"`
+	// Restricts to incidents that have the given incident id as cause incident
+	CauseIncidentId string `query:"causeIncidentId"`
+	// Restricts to incidents that have the given incident id as root cause incident
+	RootCauseIncidentId string `query:"rootCauseIncidentId"`
+	// Restricts to incidents that have the given parameter set as configuration
+	Configuration string `query:"configuration"`
+	// Restricts to incidents that have the given parameter set as history configuration
+	HistoryConfiguration string `query:"historyConfiguration"`
+	// Restricts to incidents that are open
+	Open bool `query:"open"`
+	// Restricts to incidents that are resolved
+	Resolved bool `query:"resolved"`
+	// Restricts to incidents that are deleted
+	Deleted bool `query:"deleted"`
+	// Restricts to incidents that have one of the given tenant ids
+	TenantIdIn []string `query:"tenantIdIn"`
+	// Only include historic incidents that belong to no tenant
+	WithoutTenantId bool `query:"withoutTenantId"`
+	// Restricts to incidents that have one of the given job definition ids
+	JobDefinitionIdIn []string `query:"jobDefinitionIdIn"`
+}
+
+// Params returns query parameters
+func (q *QueryHistoryIncidentList) Params() map[string]string {
+	return encodeQuery(q)
+}
+
+// GetIncidentList queries for historic incidents that fulfill the given parameters.
+// https://docs.camunda.org/manual/latest/reference/rest/history/incident/get-incident-query/#query-parameters
+func (h *History) GetIncidentList(query map[string]string) (incidents []*ResHistoryIncident, err error) {
+	res, err := h.client.doGet("/history/incident", query)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, &incidents)
+	return
+}
+
+// GetIncidentListPager returns a Pager over historic incidents that fulfill the given parameters.
+// Results are sorted by incident id unless sortBy is set in query
+func (h *History) GetIncidentListPager(query map[string]string, pageSize int) *Pager[*ResHistoryIncident] {
+	if query["sortBy"] == "" {
+		query = pageQuery(query, 0, 0)
+		query["sortBy"] = "incidentId"
+		query["sortOrder"] = "asc"
+	}
+
+	return NewPager(func(ctx context.Context, firstResult, maxResults int) ([]*ResHistoryIncident, error) {
+		return h.client.WithContext(ctx).History.GetIncidentList(pageQuery(query, firstResult, maxResults))
+	}, pageSize)
+}
+
+// GetIncidentCount queries for the number of historic incidents that fulfill the given parameters.
+// https://docs.camunda.org/manual/latest/reference/rest/history/incident/get-incident-query-count/#query-parameters
+func (h *History) GetIncidentCount(query map[string]string) (count int, err error) {
+	resCount := ResCount{}
+	res, err := h.client.doGet("/history/incident/count", query)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, &resCount)
+	return resCount.Count, err
+}
@@ -0,0 +1,180 @@
+package camunda_client_go
+
+import (
+	"context"
+	"io/ioutil"
+)
+
+// ResHistoryJobLog a JSON object corresponding to the HistoricJobLog interface in the engine
+type ResHistoryJobLog struct {
+	// The id of the log entry
+	Id string `json:"id"`
+	// The time when the log entry has been written. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	Timestamp string `json:"timestamp"`
+	// The time after which the log entry should be removed by the History Cleanup job.
+	// Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	RemovalTime string `json:"removalTime"`
+	// The id of the associated job
+	JobId string `json:"jobId"`
+	// The date on which the associated job is supposed to be processed. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	JobDueDate string `json:"jobDueDate"`
+	// The number of retries the associated job has left
+	JobRetries int `json:"jobRetries"`
+	// The execution priority the job had when the log entry was created
+	JobPriority int64 `json:"jobPriority"`
+	// The message of the exception that occurred by executing the associated job
+	JobExceptionMessage string `json:"jobExceptionMessage"`
+	// The id of the activity on which the last exception occurred by executing the associated job
+	FailedActivityId string `json:"failedActivityId"`
+	// The id of the job definition on which the associated job was created
+	JobDefinitionId string `json:"jobDefinitionId"`
+	// The job definition type of the associated job, e.g. async-continuation or timer-intermediate-transition
+	JobDefinitionType string `json:"jobDefinitionType"`
+	// The job definition configuration type of the associated job
+	JobDefinitionConfiguration string `json:"jobDefinitionConfiguration"`
+	// The id of the activity on which the associated job was created
+	ActivityId string `json:"activityId"`
+	// The id of the execution on which the associated job was created
+	ExecutionId string `json:"executionId"`
+	// The id of the process instance on which the associated job was created
+	ProcessInstanceId string `json:"processInstanceId"`
+	// The id of the process definition which the associated job belongs to
+	ProcessDefinitionId string `json:"processDefinitionId"`
+	// The key of the process definition which the associated job belongs to
+	ProcessDefinitionKey string `json:"processDefinitionKey"`
+	// The id of the deployment which the associated job belongs to
+	DeploymentId string `json:"deploymentId"`
+	// The process instance id of the root process instance that initiated the process containing this log
+	RootProcessInstanceId string `json:"rootProcessInstanceId"`
+	// The id of the tenant that this historic job log entry belongs to
+	TenantId string `json:"tenantId"`
+	// The name of the host of the Process Engine where the job of this historic job log entry was executed
+	Hostname string `json:"hostname"`
+	// If true, this log represents the creation of the associated job
+	CreationLog bool `json:"creationLog"`
+	// If true, this log represents the failed execution of the associated job
+	FailureLog bool `json:"failureLog"`
+	// If true, this log represents the successful execution of the associated job
+	SuccessLog bool `json:"successLog"`
+	// If true, this log represents the deletion of the associated job
+	DeletionLog bool `json:"deletionLog"`
+}
+
+// QueryHistoryJobLogList a typed query for GetJobLogList and GetJobLogCount, use Params to get query parameters
+// https://docs.camunda.org/manual/latest/reference/rest/history/job-log/get-job-log-query/#query-parameters
+type QueryHistoryJobLogList struct {
+	QuerySorting
+	QueryPagination
+	// Filter by historic job log id
+	LogId string `query:"logId"`
+	// Filter by job id
+	JobId string `query:"jobId"`
+	// Filter by job exception message
+	JobExceptionMessage string `query:"jobExceptionMessage"`
+	// Filter by job definition id
+	JobDefinitionId string `query:"jobDefinitionId"`
+	// Filter by job definition type
+	JobDefinitionType string `query:"jobDefinitionType"`
+	// Filter by job definition configuration
+	JobDefinitionConfiguration string `query:"jobDefinitionConfiguration"`
+	// Only include historic job log entries which belong to one of the passed activity ids
+	ActivityIdIn []string `query:"activityIdIn"`
+	// Only include historic job log entries which belong to failures of one of the passed activity ids
+	FailedActivityIdIn []string `query:"failedActivityIdIn"`
+	// Only include historic job log entries which belong to one of the passed execution ids
+	ExecutionIdIn []string `query:"executionIdIn"`
+	// Filter by process instance id
+	ProcessInstanceId string `query:"processInstanceId"`
+	// Filter by process definition id
+	ProcessDefinitionId string `query:"processDefinitionId"`
+	// Filter by process definition key
+	ProcessDefinitionKey string `query:"processDefinitionKey"`
+	// Filter by deployment id
+	DeploymentId string `query:"deploymentId"`
+	// Only include historic job log entries which belong to one of the passed tenant ids
+	TenantIdIn []string `query:"tenantIdIn"`
+	// Only include historic job log entries that belong to no tenant
+	WithoutTenantId bool `query:"withoutTenantId"`
+	// Filter by hostname
+	Hostname string `query:"hostname"`
+	// Only include logs for which the associated job had a priority lower than or equal to the given value
+	JobPriorityLowerThanOrEquals *int64 `query:"jobPriorityLowerThanOrEquals"`
+	// Only include logs for which the associated job had a priority higher than or equal to the given value
+	JobPriorityHigherThanOrEquals *int64 `query:"jobPriorityHigherThanOrEquals"`
+	// Only include creation logs
+	CreationLog bool `query:"creationLog"`
+	// Only include failure logs
+	FailureLog bool `query:"failureLog"`
+	// Only include success logs
+	SuccessLog bool `query:"successLog"`
+	// Only include deletion logs
+	DeletionLog bool `query:"deletionLog"`
+}
+
+// Params returns query parameters
+func (q *QueryHistoryJobLogList) Params() map[string]string {
+	return encodeQuery(q)
+}
+
+// GetJobLogList queries for historic job logs that fulfill the given parameters.
+// https://docs.camunda.org/manual/latest/reference/rest/history/job-log/get-job-log-query/#query-parameters
+func (h *History) GetJobLogList(query map[string]string) (jobLogs []*ResHistoryJobLog, err error) {
+	res, err := h.client.doGet("/history/job-log", query)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, &jobLogs)
+	return
+}
+
+// GetJobLogListPager returns a Pager over historic job logs that fulfill the given parameters.
+// Without sortBy in query the engine orders results by log id, so pages do not overlap. The API has no unique
+// sort criterion for job logs, so pages sorted by sortBy may overlap on equal values
+func (h *History) GetJobLogListPager(query map[string]string, pageSize int) *Pager[*ResHistoryJobLog] {
+	return NewPager(func(ctx context.Context, firstResult, maxResults int) ([]*ResHistoryJobLog, error) {
+		return h.client.WithContext(ctx).History.GetJobLogList(pageQuery(query, firstResult, maxResults))
+	}, pageSize)
+}
+
+// GetJobLogCount queries for the number of historic job logs that fulfill the given parameters.
+// https://docs.camunda.org/manual/latest/reference/rest/history/job-log/get-job-log-query-count/#query-parameters
+func (h *History) GetJobLogCount(query map[string]string) (count int, err error) {
+	resCount := ResCount{}
+	res, err := h.client.doGet("/history/job-log/count", query)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, &resCount)
+	return resCount.Count, err
+}
+
+// GetJobLog retrieves a historic job log by id
+func (h *History) GetJobLog(id string) (jobLog *ResHistoryJobLog, err error) {
+	jobLog = &ResHistoryJobLog{}
+	res, err := h.client.doGet("/history/job-log/"+id, nil)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, jobLog)
+	return
+}
+
+// GetJobLogStacktrace retrieves the corresponding exception stacktrace to the passed historic job log id
+// of a failure log
+func (h *History) GetJobLogStacktrace(id string) (stacktrace string, err error) {
+	res, err := h.client.doGet("/history/job-log/"+id+"/stacktrace", nil)
+	if err != nil {
+		return
+	}
+
+	defer res.Body.Close()
+	rawData, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return
+	}
+
+	return string(rawData), nil
+}
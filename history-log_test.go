@@ -0,0 +1,141 @@
+package camunda_client_go
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryActivityInstanceListPost(t *testing.T) {
+	var req ReqHistoryActivityInstanceQuery
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/history/activity-instance", r.URL.Path)
+		assert.Equal(t, "10", r.URL.Query().Get("maxResults"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id": "a1", "activityId": "send-receipt", "activityType": "serviceTask", "canceled": true}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOptions{EndpointUrl: server.URL})
+	processInstanceId := "p1"
+	activityInstances, err := client.History.GetActivityInstanceListPost(map[string]string{"maxResults": "10"}, ReqHistoryActivityInstanceQuery{
+		ProcessInstanceId: &processInstanceId,
+		Sorting:           []ReqSort{{SortBy: "startTime", SortOrder: "asc"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, activityInstances, 1)
+	assert.Equal(t, "send-receipt", activityInstances[0].ActivityId)
+	assert.True(t, activityInstances[0].Canceled)
+	assert.Equal(t, "p1", *req.ProcessInstanceId)
+	assert.Equal(t, []ReqSort{{SortBy: "startTime", SortOrder: "asc"}}, req.Sorting)
+}
+
+func TestHistoryLogs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/history/incident":
+			assert.Equal(t, "p1", r.URL.Query().Get("processInstanceId"))
+			assert.Equal(t, "true", r.URL.Query().Get("resolved"))
+			_, _ = w.Write([]byte(`[{"id": "i1", "incidentType": "failedExternalTask", "failedActivityId": "send-receipt", "resolved": true}]`))
+		case "/history/job-log/count":
+			assert.Equal(t, "true", r.URL.Query().Get("failureLog"))
+			assert.Equal(t, "0", r.URL.Query().Get("jobPriorityHigherThanOrEquals"))
+			_, _ = w.Write([]byte(`{"count": 3}`))
+		case "/history/job-log/j1/stacktrace":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte("java.lang.RuntimeException: timeout"))
+		case "/history/external-task-log":
+			assert.Equal(t, "send-receipt", r.URL.Query().Get("activityIdIn"))
+			_, _ = w.Write([]byte(`[{"id": "l1", "retries": 2, "errorMessage": "timeout", "failureLog": true}]`))
+		case "/history/external-task-log/l1/error-details":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte("connection refused"))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOptions{EndpointUrl: server.URL})
+	incidents, err := client.History.GetIncidentList((&QueryHistoryIncidentList{ProcessInstanceId: "p1", Resolved: true}).Params())
+	require.NoError(t, err)
+	require.Len(t, incidents, 1)
+	assert.Equal(t, "send-receipt", incidents[0].FailedActivityId)
+
+	priority := int64(0)
+	count, err := client.History.GetJobLogCount((&QueryHistoryJobLogList{FailureLog: true, JobPriorityHigherThanOrEquals: &priority}).Params())
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	stacktrace, err := client.History.GetJobLogStacktrace("j1")
+	require.NoError(t, err)
+	assert.Equal(t, "java.lang.RuntimeException: timeout", stacktrace)
+
+	logs, err := client.History.GetExternalTaskLogList((&QueryHistoryExternalTaskLogList{ActivityIdIn: []string{"send-receipt"}}).Params())
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	assert.Equal(t, 2, *logs[0].Retries)
+	assert.True(t, logs[0].FailureLog)
+
+	details, err := client.History.GetExternalTaskLogErrorDetails("l1")
+	require.NoError(t, err)
+	assert.Equal(t, "connection refused", details)
+}
+
+func TestHistoryDetailAndIdentityLinkLog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/history/detail":
+			assert.Equal(t, "p1", r.URL.Query().Get("processInstanceId"))
+			assert.Equal(t, "true", r.URL.Query().Get("variableUpdates"))
+			assert.Equal(t, "true", r.URL.Query().Get("excludeTaskDetails"))
+			assert.Equal(t, "false", r.URL.Query().Get("deserializeValues"))
+			assert.Equal(t, "2026-10-01T00:00:00.000+0000", r.URL.Query().Get("occurredAfter"))
+			assert.Equal(t, "occurrence", r.URL.Query().Get("sortBy"))
+			_, _ = w.Write([]byte(`[
+				{"id": "d1", "type": "variableUpdate", "variableName": "amount", "variableType": "Integer", "value": 10, "initial": true, "revision": 0},
+				{"id": "d2", "type": "variableUpdate", "variableName": "amount", "variableType": "Integer", "value": 20, "revision": 1}
+			]`))
+		case "/history/identity-link-log/count":
+			assert.Equal(t, "t1", r.URL.Query().Get("taskId"))
+			assert.Equal(t, "assignee", r.URL.Query().Get("type"))
+			_, _ = w.Write([]byte(`{"count": 2}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOptions{EndpointUrl: server.URL})
+	deserializeValues := false
+	details, err := client.History.GetDetailList((&QueryHistoryDetailList{
+		QuerySorting:       QuerySorting{SortBy: "occurrence", SortOrder: "asc"},
+		ProcessInstanceId:  "p1",
+		VariableUpdates:    true,
+		ExcludeTaskDetails: true,
+		OccurredAfter:      time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		DeserializeValues:  &deserializeValues,
+	}).Params())
+	require.NoError(t, err)
+	require.Len(t, details, 2)
+	assert.Equal(t, HistoryDetailTypeVariableUpdate, details[0].Type)
+	assert.True(t, details[0].Initial)
+	assert.Equal(t, float64(20), details[1].Value)
+	assert.Equal(t, 1, details[1].Revision)
+
+	count, err := client.History.GetIdentityLinkLogCount((&QueryHistoryIdentityLinkLogList{TaskId: "t1", Type: "assignee"}).Params())
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
@@ -0,0 +1,282 @@
+package camunda_client_go
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Period units of duration reports
+const (
+	ReportPeriodUnitMonth   = "month"
+	ReportPeriodUnitQuarter = "quarter"
+)
+
+// Groupings of the historic task count report, the engine does not group historic tasks by candidate group
+const (
+	TaskReportGroupByTaskName          = "taskName"
+	TaskReportGroupByProcessDefinition = "processDefinition"
+)
+
+// QueryHistoryProcessInstanceReport a typed query for GetProcessInstanceReport, use Params to get
+// query parameters
+// https://docs.camunda.org/manual/latest/reference/rest/history/process-instance/get-duration-report/#query-parameters
+type QueryHistoryProcessInstanceReport struct {
+	// The period unit of the report, month or quarter
+	PeriodUnit string `query:"periodUnit"`
+	// Filter by process definition ids
+	ProcessDefinitionIdIn []string `query:"processDefinitionIdIn"`
+	// Filter by process definition keys
+	ProcessDefinitionKeyIn []string `query:"processDefinitionKeyIn"`
+	// Restrict to instances that were started before the given date
+	StartedBefore time.Time `query:"startedBefore"`
+	// Restrict to instances that were started after the given date
+	StartedAfter time.Time `query:"startedAfter"`
+}
+
+// Params returns query parameters
+func (q *QueryHistoryProcessInstanceReport) Params() map[string]string {
+	return encodeQuery(q)
+}
+
+// QueryHistoryTaskReport a typed query for GetTaskCountReport and GetTaskDurationReport, use Params to get
+// query parameters
+// https://docs.camunda.org/manual/latest/reference/rest/history/task/get-task-report/#query-parameters
+type QueryHistoryTaskReport struct {
+	// Groups the task count report by taskName or processDefinition, only used by GetTaskCountReport
+	GroupBy string `query:"groupBy"`
+	// The period unit of the report, month or quarter, only used by GetTaskDurationReport
+	PeriodUnit string `query:"periodUnit"`
+	// Restrict to tasks that were completed before the given date
+	CompletedBefore time.Time `query:"completedBefore"`
+	// Restrict to tasks that were completed after the given date
+	CompletedAfter time.Time `query:"completedAfter"`
+}
+
+// Params returns query parameters
+func (q *QueryHistoryTaskReport) Params() map[string]string {
+	return encodeQuery(q)
+}
+
+// QueryCleanableProcessInstanceReport a typed query for GetCleanableProcessInstanceReport and
+// GetCleanableProcessInstanceReportCount, use Params to get query parameters
+// https://docs.camunda.org/manual/latest/reference/rest/history/process-definition/get-cleanable-process-instance-report/#query-parameters
+type QueryCleanableProcessInstanceReport struct {
+	QuerySorting
+	QueryPagination
+	// Filter by process definition ids
+	ProcessDefinitionIdIn []string `query:"processDefinitionIdIn"`
+	// Filter by process definition keys
+	ProcessDefinitionKeyIn []string `query:"processDefinitionKeyIn"`
+	// Filter by a list of tenant ids
+	TenantIdIn []string `query:"tenantIdIn"`
+	// Only include process definitions which belong to no tenant
+	WithoutTenantId bool `query:"withoutTenantId"`
+	// Only include process definitions which have more than zero finished instances
+	Compact bool `query:"compact"`
+}
+
+// Params returns query parameters
+func (q *QueryCleanableProcessInstanceReport) Params() map[string]string {
+	return encodeQuery(q)
+}
+
+// ResDurationReport a response object of the duration reports of process instances and tasks
+type ResDurationReport struct {
+	// Specifies a timespan within a year.
+	// The period must be interpreted in conjunction with the returned periodUnit.
+	Period int `json:"period"`
+	// The unit of the given period. Possible values are MONTH and QUARTER.
+	PeriodUnit string `json:"periodUnit"`
+	// The greatest duration in milliseconds of all completed process instances or tasks of the given period.
+	Maximum int64 `json:"maximum"`
+	// The smallest duration in milliseconds of all completed process instances or tasks of the given period.
+	Minimum int64 `json:"minimum"`
+	// The average duration in milliseconds of all completed process instances or tasks of the given period.
+	Average int64 `json:"average"`
+}
+
+// ResHistoryTaskCountReport a response object of the historic task count report
+type ResHistoryTaskCountReport struct {
+	// The name of the task or the id of the process definition, depending on the grouping
+	Definition string `json:"definition"`
+	// The id of the process definition
+	ProcessDefinitionId string `json:"processDefinitionId"`
+	// The key of the process definition
+	ProcessDefinitionKey string `json:"processDefinitionKey"`
+	// The name of the process definition
+	ProcessDefinitionName string `json:"processDefinitionName"`
+	// The name of the task. Only set when grouped by taskName
+	TaskName string `json:"taskName"`
+	// The tenant id of the task
+	TenantId string `json:"tenantId"`
+	// The number of completed tasks which have the given definition
+	Count int `json:"count"`
+}
+
+// ResCleanableProcessInstanceReport a response object of the cleanable process instance report
+type ResCleanableProcessInstanceReport struct {
+	// The id of the process definition
+	ProcessDefinitionId string `json:"processDefinitionId"`
+	// The key of the process definition
+	ProcessDefinitionKey string `json:"processDefinitionKey"`
+	// The name of the process definition
+	ProcessDefinitionName string `json:"processDefinitionName"`
+	// The version of the process definition
+	ProcessDefinitionVersion int `json:"processDefinitionVersion"`
+	// The history time to live of the process definition, nil if it is not set
+	HistoryTimeToLive *int `json:"historyTimeToLive"`
+	// The count of the finished historic process instances
+	FinishedProcessInstanceCount int `json:"finishedProcessInstanceCount"`
+	// The count of the cleanable historic process instances, referring to history time to live
+	CleanableProcessInstanceCount int `json:"cleanableProcessInstanceCount"`
+	// The tenant id of the process definition
+	TenantId string `json:"tenantId"`
+}
+
+// GetProcessInstanceReport retrieves a report about the duration of completed process instances,
+// grouped by the period unit of query
+// https://docs.camunda.org/manual/latest/reference/rest/history/process-instance/get-duration-report/
+func (h *History) GetProcessInstanceReport(query QueryHistoryProcessInstanceReport) (reports []*ResDurationReport, err error) {
+	return h.GetProcessInstanceDurationReport(query.Params())
+}
+
+// GetTaskCountReport retrieves a report of completed tasks, counted by task name or process definition
+// https://docs.camunda.org/manual/latest/reference/rest/history/task/get-task-report/
+func (h *History) GetTaskCountReport(query QueryHistoryTaskReport) (reports []*ResHistoryTaskCountReport, err error) {
+	params := query.Params()
+	params["reportType"] = "count"
+	res, err := h.client.doGet("/history/task/report", params)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, &reports)
+	return
+}
+
+// GetTaskDurationReport retrieves a report about the duration of completed tasks, grouped by the period unit of query
+// https://docs.camunda.org/manual/latest/reference/rest/history/task/get-task-report/
+func (h *History) GetTaskDurationReport(query QueryHistoryTaskReport) (reports []*ResDurationReport, err error) {
+	params := query.Params()
+	params["reportType"] = "duration"
+	res, err := h.client.doGet("/history/task/report", params)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, &reports)
+	return
+}
+
+// GetCleanableProcessInstanceReport retrieves a report about process definitions and finished process instances
+// relevant to history cleanup
+// https://docs.camunda.org/manual/latest/reference/rest/history/process-definition/get-cleanable-process-instance-report/
+func (h *History) GetCleanableProcessInstanceReport(query QueryCleanableProcessInstanceReport) (reports []*ResCleanableProcessInstanceReport, err error) {
+	res, err := h.client.doGet("/history/process-definition/cleanable-process-instance-report", query.Params())
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, &reports)
+	return
+}
+
+// GetCleanableProcessInstanceReportCount queries for the number of report results about process definitions
+// and finished process instances relevant to history cleanup
+// https://docs.camunda.org/manual/latest/reference/rest/history/process-definition/get-cleanable-process-instance-report-count/
+func (h *History) GetCleanableProcessInstanceReportCount(query QueryCleanableProcessInstanceReport) (count int, err error) {
+	resCount := ResCount{}
+	res, err := h.client.doGet("/history/process-definition/cleanable-process-instance-report/count", query.Params())
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, &resCount)
+	return resCount.Count, err
+}
+
+// WriteDurationReportCSV writes duration reports as CSV with a header row to w
+func WriteDurationReportCSV(w io.Writer, reports []*ResDurationReport) error {
+	return writeCSV(w, []string{"period", "periodUnit", "minimum", "maximum", "average"}, len(reports), func(i int) []string {
+		r := reports[i]
+		return []string{
+			strconv.Itoa(r.Period),
+			r.PeriodUnit,
+			strconv.FormatInt(r.Minimum, 10),
+			strconv.FormatInt(r.Maximum, 10),
+			strconv.FormatInt(r.Average, 10),
+		}
+	})
+}
+
+// WriteTaskCountReportCSV writes task count reports as CSV with a header row to w
+func WriteTaskCountReportCSV(w io.Writer, reports []*ResHistoryTaskCountReport) error {
+	header := []string{"definition", "processDefinitionId", "processDefinitionKey", "processDefinitionName", "taskName", "tenantId", "count"}
+	return writeCSV(w, header, len(reports), func(i int) []string {
+		r := reports[i]
+		return []string{
+			r.Definition,
+			r.ProcessDefinitionId,
+			r.ProcessDefinitionKey,
+			r.ProcessDefinitionName,
+			r.TaskName,
+			r.TenantId,
+			strconv.Itoa(r.Count),
+		}
+	})
+}
+
+// WriteCleanableProcessInstanceReportCSV writes cleanable process instance reports as CSV with a header row to w,
+// the historyTimeToLive column is empty if it is not set
+func WriteCleanableProcessInstanceReportCSV(w io.Writer, reports []*ResCleanableProcessInstanceReport) error {
+	header := []string{
+		"processDefinitionId", "processDefinitionKey", "processDefinitionName", "processDefinitionVersion",
+		"historyTimeToLive", "finishedProcessInstanceCount", "cleanableProcessInstanceCount", "tenantId",
+	}
+	return writeCSV(w, header, len(reports), func(i int) []string {
+		r := reports[i]
+		historyTimeToLive := ""
+		if r.HistoryTimeToLive != nil {
+			historyTimeToLive = strconv.Itoa(*r.HistoryTimeToLive)
+		}
+
+		return []string{
+			r.ProcessDefinitionId,
+			r.ProcessDefinitionKey,
+			r.ProcessDefinitionName,
+			strconv.Itoa(r.ProcessDefinitionVersion),
+			historyTimeToLive,
+			strconv.Itoa(r.FinishedProcessInstanceCount),
+			strconv.Itoa(r.CleanableProcessInstanceCount),
+			r.TenantId,
+		}
+	})
+}
+
+// WriteCandidateGroupCountReportCSV writes candidate group count reports as CSV with a header row to w.
+// The engine has no historic candidate group report, the counts come from runtime data of
+// UserTask.GetCandidateGroupCountReport and cover only open tasks
+func WriteCandidateGroupCountReportCSV(w io.Writer, reports []*ResTaskCandidateGroupCount) error {
+	return writeCSV(w, []string{"groupName", "taskCount"}, len(reports), func(i int) []string {
+		return []string{reports[i].GroupName, strconv.Itoa(reports[i].TaskCount)}
+	})
+}
+
+// writeCSV writes header and n records returned by record as CSV to w
+func writeCSV(w io.Writer, header []string, n int, record func(i int) []string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for i := 0; i < n; i++ {
+		if err := cw.Write(record(i)); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,91 @@
+package camunda_client_go
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryReports(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		q := r.URL.Query()
+		switch r.URL.Path {
+		case "/history/process-instance/report":
+			assert.Equal(t, "duration", q.Get("reportType"))
+			assert.Equal(t, "quarter", q.Get("periodUnit"))
+			assert.Equal(t, "order,invoice", q.Get("processDefinitionKeyIn"))
+			assert.Equal(t, "2026-01-01T00:00:00.000+0000", q.Get("startedAfter"))
+			_, _ = w.Write([]byte(`[{"period": 1, "periodUnit": "QUARTER", "minimum": 10, "maximum": 500, "average": 120}]`))
+		case "/history/task/report":
+			if q.Get("reportType") == "count" {
+				assert.Equal(t, "taskName", q.Get("groupBy"))
+				_, _ = w.Write([]byte(`[{"definition": "Approve", "processDefinitionKey": "order", "taskName": "Approve", "count": 7}]`))
+				return
+			}
+			assert.Equal(t, "duration", q.Get("reportType"))
+			assert.Equal(t, "month", q.Get("periodUnit"))
+			_, _ = w.Write([]byte(`[{"period": 10, "periodUnit": "MONTH", "minimum": 1, "maximum": 2, "average": 1}]`))
+		case "/history/process-definition/cleanable-process-instance-report":
+			assert.Equal(t, "true", q.Get("compact"))
+			assert.Equal(t, "order", q.Get("processDefinitionKeyIn"))
+			_, _ = w.Write([]byte(`[
+				{"processDefinitionId": "order:1", "processDefinitionKey": "order", "processDefinitionVersion": 1, "historyTimeToLive": 30, "finishedProcessInstanceCount": 100, "cleanableProcessInstanceCount": 40},
+				{"processDefinitionId": "invoice:2", "processDefinitionKey": "invoice", "processDefinitionVersion": 2, "historyTimeToLive": null, "finishedProcessInstanceCount": 5}
+			]`))
+		case "/task/report/candidate-group-count":
+			_, _ = w.Write([]byte(`[{"groupName": null, "taskCount": 1}, {"groupName": "sales", "taskCount": 4}]`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOptions{EndpointUrl: server.URL})
+	durations, err := client.History.GetProcessInstanceReport(QueryHistoryProcessInstanceReport{
+		PeriodUnit:             ReportPeriodUnitQuarter,
+		ProcessDefinitionKeyIn: []string{"order", "invoice"},
+		StartedAfter:           time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	buf := &bytes.Buffer{}
+	require.NoError(t, WriteDurationReportCSV(buf, durations))
+	assert.Equal(t, "period,periodUnit,minimum,maximum,average\n1,QUARTER,10,500,120\n", buf.String())
+
+	counts, err := client.History.GetTaskCountReport(QueryHistoryTaskReport{GroupBy: TaskReportGroupByTaskName})
+	require.NoError(t, err)
+	buf.Reset()
+	require.NoError(t, WriteTaskCountReportCSV(buf, counts))
+	assert.Equal(t, "definition,processDefinitionId,processDefinitionKey,processDefinitionName,taskName,tenantId,count\n"+
+		"Approve,,order,,Approve,,7\n", buf.String())
+
+	taskDurations, err := client.History.GetTaskDurationReport(QueryHistoryTaskReport{PeriodUnit: ReportPeriodUnitMonth})
+	require.NoError(t, err)
+	buf.Reset()
+	require.NoError(t, WriteDurationReportCSV(buf, taskDurations))
+	assert.Equal(t, "period,periodUnit,minimum,maximum,average\n10,MONTH,1,2,1\n", buf.String())
+
+	cleanable, err := client.History.GetCleanableProcessInstanceReport(QueryCleanableProcessInstanceReport{
+		ProcessDefinitionKeyIn: []string{"order"},
+		Compact:                true,
+	})
+	require.NoError(t, err)
+	buf.Reset()
+	require.NoError(t, WriteCleanableProcessInstanceReportCSV(buf, cleanable))
+	assert.Equal(t, "processDefinitionId,processDefinitionKey,processDefinitionName,processDefinitionVersion,"+
+		"historyTimeToLive,finishedProcessInstanceCount,cleanableProcessInstanceCount,tenantId\n"+
+		"order:1,order,,1,30,100,40,\n"+
+		"invoice:2,invoice,,2,,5,0,\n", buf.String())
+
+	groups, err := client.UserTask.GetCandidateGroupCountReport()
+	require.NoError(t, err)
+	buf.Reset()
+	require.NoError(t, WriteCandidateGroupCountReportCSV(buf, groups))
+	assert.Equal(t, "groupName,taskCount\n,1\nsales,4\n", buf.String())
+}
@@ -0,0 +1,176 @@
+package camunda_client_go
+
+import (
+	"context"
+	"time"
+)
+
+// ResHistoryUserOperation a JSON object corresponding to the UserOperationLogEntry interface in the engine
+type ResHistoryUserOperation struct {
+	// The unique identifier of this log entry
+	Id string `json:"id"`
+	// The user who performed this operation
+	UserId string `json:"userId"`
+	// Timestamp of this operation. Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	Timestamp string `json:"timestamp"`
+	// The unique identifier of this operation. A composite operation that changes multiple properties
+	// has a common operationId
+	OperationId string `json:"operationId"`
+	// The type of this operation, e.g. Assign, Claim, Suspend and so on
+	OperationType string `json:"operationType"`
+	// The type of the entity on which this operation was executed, e.g. Task or Attachment
+	EntityType string `json:"entityType"`
+	// The name of the category this operation was associated with, e.g. TaskWorker, Admin or Operator
+	Category string `json:"category"`
+	// An arbitrary annotation set by a user for auditing reasons
+	Annotation string `json:"annotation"`
+	// The property changed by this operation
+	Property string `json:"property"`
+	// The original value of the changed property
+	OrgValue string `json:"orgValue"`
+	// The new value of the changed property
+	NewValue string `json:"newValue"`
+	// If not null, the operation is restricted to entities in relation to this deployment
+	DeploymentId string `json:"deploymentId"`
+	// If not null, the operation is restricted to entities in relation to this process definition
+	ProcessDefinitionId string `json:"processDefinitionId"`
+	// If not null, the operation is restricted to entities in relation to process definitions with this key
+	ProcessDefinitionKey string `json:"processDefinitionKey"`
+	// If not null, the operation is restricted to entities in relation to this process instance
+	ProcessInstanceId string `json:"processInstanceId"`
+	// If not null, the operation is restricted to entities in relation to this execution
+	ExecutionId string `json:"executionId"`
+	// If not null, the operation is restricted to entities in relation to this case definition
+	CaseDefinitionId string `json:"caseDefinitionId"`
+	// If not null, the operation is restricted to entities in relation to this case instance
+	CaseInstanceId string `json:"caseInstanceId"`
+	// If not null, the operation is restricted to entities in relation to this case execution
+	CaseExecutionId string `json:"caseExecutionId"`
+	// If not null, the operation is restricted to entities in relation to this task
+	TaskId string `json:"taskId"`
+	// If not null, the operation is restricted to entities in relation to this external task
+	ExternalTaskId string `json:"externalTaskId"`
+	// If not null, the operation is restricted to entities in relation to this batch
+	BatchId string `json:"batchId"`
+	// If not null, the operation is restricted to entities in relation to this job
+	JobId string `json:"jobId"`
+	// If not null, the operation is restricted to entities in relation to this job definition
+	JobDefinitionId string `json:"jobDefinitionId"`
+	// The time after which the entry should be removed by the History Cleanup job.
+	// Default format* yyyy-MM-dd'T'HH:mm:ss.SSSZ.
+	RemovalTime string `json:"removalTime"`
+	// The process instance id of the root process instance that initiated the process containing this entry
+	RootProcessInstanceId string `json:"rootProcessInstanceId"`
+}
+
+// QueryHistoryUserOperationList a typed query for GetUserOperationList and GetUserOperationCount,
+// use Params to get query parameters
+// https://docs.camunda.org/manual/latest/reference/rest/history/user-operation-log/get-user-operation-log-query/#query-parameters
+type QueryHistoryUserOperationList struct {
+	QuerySorting
+	QueryPagination
+	// Filter by deployment id
+	DeploymentId string `query:"deploymentId"`
+	// Filter by process definition id
+	ProcessDefinitionId string `query:"processDefinitionId"`
+	// Filter by process definition key
+	ProcessDefinitionKey string `query:"processDefinitionKey"`
+	// Filter by process instance id
+	ProcessInstanceId string `query:"processInstanceId"`
+	// Filter by execution id
+	ExecutionId string `query:"executionId"`
+	// Filter by case definition id
+	CaseDefinitionId string `query:"caseDefinitionId"`
+	// Filter by case instance id
+	CaseInstanceId string `query:"caseInstanceId"`
+	// Filter by case execution id
+	CaseExecutionId string `query:"caseExecutionId"`
+	// Only include operations on this task
+	TaskId string `query:"taskId"`
+	// Only include operations on this external task
+	ExternalTaskId string `query:"externalTaskId"`
+	// Only include operations on this batch
+	BatchId string `query:"batchId"`
+	// Filter by job id
+	JobId string `query:"jobId"`
+	// Filter by job definition id
+	JobDefinitionId string `query:"jobDefinitionId"`
+	// Only include operations of this user
+	UserId string `query:"userId"`
+	// Filter by the id of the operation. This allows fetching of multiple entries which are part of a composite operation
+	OperationId string `query:"operationId"`
+	// Filter by the type of the operation like Claim or Delegate
+	OperationType string `query:"operationType"`
+	// Filter by the type of the entity that was affected by this operation, possible values are Task, Attachment
+	// or IdentityLink
+	EntityType string `query:"entityType"`
+	// Filter by a list of types of the entities that was affected by this operation
+	EntityTypeIn []string `query:"entityTypeIn"`
+	// Filter by the category that this operation is associated with, possible values are TaskWorker, Admin
+	// and Operator
+	Category string `query:"category"`
+	// Filter by a list of categories that this operation is associated with
+	CategoryIn []string `query:"categoryIn"`
+	// Only include operations that changed this property, e.g. owner or assignee
+	Property string `query:"property"`
+	// Restrict to entries that were created after the given timestamp
+	AfterTimestamp time.Time `query:"afterTimestamp"`
+	// Restrict to entries that were created before the given timestamp
+	BeforeTimestamp time.Time `query:"beforeTimestamp"`
+}
+
+// Params returns query parameters
+func (q *QueryHistoryUserOperationList) Params() map[string]string {
+	return encodeQuery(q)
+}
+
+// GetUserOperationList queries for user operation log entries that fulfill the given parameters.
+// https://docs.camunda.org/manual/latest/reference/rest/history/user-operation-log/get-user-operation-log-query/#query-parameters
+func (h *History) GetUserOperationList(query map[string]string) (userOperations []*ResHistoryUserOperation, err error) {
+	res, err := h.client.doGet("/history/user-operation", query)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, &userOperations)
+	return
+}
+
+// GetUserOperationListPager returns a Pager over user operation log entries that fulfill the given parameters.
+// Results are sorted by timestamp unless sortBy is set in query
+func (h *History) GetUserOperationListPager(query map[string]string, pageSize int) *Pager[*ResHistoryUserOperation] {
+	if query["sortBy"] == "" {
+		query = pageQuery(query, 0, 0)
+		query["sortBy"] = "timestamp"
+		query["sortOrder"] = "asc"
+	}
+
+	return NewPager(func(ctx context.Context, firstResult, maxResults int) ([]*ResHistoryUserOperation, error) {
+		return h.client.WithContext(ctx).History.GetUserOperationList(pageQuery(query, firstResult, maxResults))
+	}, pageSize)
+}
+
+// GetUserOperationCount queries for the number of user operation log entries that fulfill the given parameters.
+// https://docs.camunda.org/manual/latest/reference/rest/history/user-operation-log/get-user-operation-log-query-count/#query-parameters
+func (h *History) GetUserOperationCount(query map[string]string) (count int, err error) {
+	resCount := ResCount{}
+	res, err := h.client.doGet("/history/user-operation/count", query)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, &resCount)
+	return resCount.Count, err
+}
+
+// SetUserOperationAnnotation sets the annotation of all user operation log entries of an operation id
+func (h *History) SetUserOperationAnnotation(operationId, annotation string) error {
+	return h.client.doPutJson("/history/user-operation/"+operationId+"/set-annotation", map[string]string{}, map[string]string{
+		"annotation": annotation,
+	})
+}
+
+// ClearUserOperationAnnotation clears the annotation of all user operation log entries of an operation id
+func (h *History) ClearUserOperationAnnotation(operationId string) error {
+	return h.client.doPutJson("/history/user-operation/"+operationId+"/clear-annotation", map[string]string{}, map[string]string{})
+}
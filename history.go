@@ -1,6 +1,10 @@
 package camunda_client_go
 
-import "io/ioutil"
+import (
+	"context"
+	"io/ioutil"
+	"time"
+)
 
 type History struct {
 	client *Client
@@ -142,6 +146,23 @@ type ReqHistoryDeleteProcessInstance struct {
 	DeleteReason *string `json:"deleteReason,omitempty"`
 }
 
+// ReqHistoryProcessInstanceRemovalTime a JSON object of SetProcessInstanceRemovalTimeAsync,
+// exactly one of AbsoluteRemovalTime, ClearedRemovalTime and CalculatedRemovalTime must be given
+type ReqHistoryProcessInstanceRemovalTime struct {
+	// The date for which the historic process instances shall be removed
+	AbsoluteRemovalTime *Time `json:"absoluteRemovalTime,omitempty"`
+	// Sets the removal time to null
+	ClearedRemovalTime *bool `json:"clearedRemovalTime,omitempty"`
+	// The removal time is calculated based on the engine's configuration settings
+	CalculatedRemovalTime *bool `json:"calculatedRemovalTime,omitempty"`
+	// Sets the removal time to all historic process instances in the hierarchy
+	Hierarchical *bool `json:"hierarchical,omitempty"`
+	// A list of historic process instance ids
+	HistoricProcessInstanceIds []string `json:"historicProcessInstanceIds,omitempty"`
+	// A historic process instance query
+	HistoricProcessInstanceQuery *ReqHistoryProcessInstanceQuery `json:"historicProcessInstanceQuery,omitempty"`
+}
+
 // ReqHistoryTaskQuery a JSON object with the following properties: (at least an empty JSON object {}
 // or an empty request body)
 // https://docs.camunda.org/manual/7.15/reference/rest/history/task/get-task-query/#query-parameters
@@ -363,6 +384,9 @@ type ReqHistoryVariableInstanceQuery struct {
 	TaskIdIn             []string    `json:"taskIdIn"`
 	ActivityInstanceIdIn []string    `json:"activityInstanceIdIn"`
 	TenantIdIn           []string    `json:"tenantIdIn"`
+	// A JSON array of criteria to sort the result by. Valid values of sortBy are instanceId, variableName
+	// and tenantId
+	Sorting []ReqSort `json:"sorting,omitempty"`
 }
 
 // ResHistoryProcessInstance a response object for process instance
@@ -413,19 +437,7 @@ type ResHistoryProcessInstance struct {
 }
 
 // ResHistoryProcessInstanceDurationReport a response object for history process instance duration report
-type ResHistoryProcessInstanceDurationReport struct {
-	// Specifies a timespan within a year.
-	// The period must be interpreted in conjunction with the returned periodUnit.
-	Period int `json:"period"`
-	// The unit of the given period. Possible values are MONTH and QUARTER.
-	PeriodUnit string `json:"periodUnit"`
-	// The greatest duration in milliseconds of all completed process instances which were started in the given period.
-	Maximum int64 `json:"maximum"`
-	// The smallest duration in milliseconds of all completed process instances which were started in the given period.
-	Minimum int64 `json:"minimum"`
-	// The average duration in milliseconds of all completed process instances which were started in the given period.
-	Average int64 `json:"average"`
-}
+type ResHistoryProcessInstanceDurationReport = ResDurationReport
 
 // ResHistoryVariableInstance a response object for history variable instance
 type ResHistoryVariableInstance struct {
@@ -465,6 +477,290 @@ type ResHistoryVariableInstance struct {
 	ErrorMessage string `json:"errorMessage"`
 }
 
+// QueryHistoryProcessInstanceList a typed query for GetProcessInstanceList and GetProcessInstanceCount,
+// use Params to get query parameters
+// https://docs.camunda.org/manual/latest/reference/rest/history/process-instance/get-process-instance-query/#query-parameters
+type QueryHistoryProcessInstanceList struct {
+	QuerySorting
+	QueryPagination
+	// Filter by process instance id
+	ProcessInstanceId string `query:"processInstanceId"`
+	// Filter by a list of process instance ids
+	ProcessInstanceIds []string `query:"processInstanceIds"`
+	// Filter by the process definition the instances run on
+	ProcessDefinitionId string `query:"processDefinitionId"`
+	// Filter by the key of the process definition the instances run on
+	ProcessDefinitionKey string `query:"processDefinitionKey"`
+	// Filter by a list of process definition keys
+	ProcessDefinitionKeyIn []string `query:"processDefinitionKeyIn"`
+	// Filter by the name of the process definition the instances run on
+	ProcessDefinitionName string `query:"processDefinitionName"`
+	// Filter by process definition names that the parameter is a substring of
+	ProcessDefinitionNameLike string `query:"processDefinitionNameLike"`
+	// Exclude instances that belong to a set of process definitions
+	ProcessDefinitionKeyNotIn []string `query:"processDefinitionKeyNotIn"`
+	// Filter by process instance business key
+	ProcessInstanceBusinessKey string `query:"processInstanceBusinessKey"`
+	// Filter by process instance business key that the parameter is a substring of
+	ProcessInstanceBusinessKeyLike string `query:"processInstanceBusinessKeyLike"`
+	// Restrict the query to all process instances that are top level process instances
+	RootProcessInstances bool `query:"rootProcessInstances"`
+	// Only include finished process instances
+	Finished bool `query:"finished"`
+	// Only include unfinished process instances
+	Unfinished bool `query:"unfinished"`
+	// Only include process instances which have an incident
+	WithIncidents bool `query:"withIncidents"`
+	// Only include process instances which have a root incident
+	WithRootIncidents bool `query:"withRootIncidents"`
+	// Filter by the incident type
+	IncidentType string `query:"incidentType"`
+	// Only include process instances which have an incident in status either open or resolved
+	IncidentStatus string `query:"incidentStatus"`
+	// Filter by the incident message. Exact match
+	IncidentMessage string `query:"incidentMessage"`
+	// Filter by the incident message that the parameter is a substring of
+	IncidentMessageLike string `query:"incidentMessageLike"`
+	// Restrict to instances that were started before the given date
+	StartedBefore time.Time `query:"startedBefore"`
+	// Restrict to instances that were started after the given date
+	StartedAfter time.Time `query:"startedAfter"`
+	// Restrict to instances that were finished before the given date
+	FinishedBefore time.Time `query:"finishedBefore"`
+	// Restrict to instances that were finished after the given date
+	FinishedAfter time.Time `query:"finishedAfter"`
+	// Restrict to instances that executed an activity after the given date
+	ExecutedActivityAfter time.Time `query:"executedActivityAfter"`
+	// Restrict to instances that executed an activity before the given date
+	ExecutedActivityBefore time.Time `query:"executedActivityBefore"`
+	// Restrict to instances that executed a job after the given date
+	ExecutedJobAfter time.Time `query:"executedJobAfter"`
+	// Restrict to instances that executed a job before the given date
+	ExecutedJobBefore time.Time `query:"executedJobBefore"`
+	// Only include process instances that were started by the given user
+	StartedBy string `query:"startedBy"`
+	// Restrict query to all process instances that are sub process instances of the given process instance
+	SuperProcessInstanceId string `query:"superProcessInstanceId"`
+	// Restrict query to one process instance that has a sub process instance with the given id
+	SubProcessInstanceId string `query:"subProcessInstanceId"`
+	// Restrict query to all process instances that are sub process instances of the given case instance
+	SuperCaseInstanceId string `query:"superCaseInstanceId"`
+	// Restrict query to one process instance that has a sub case instance with the given id
+	SubCaseInstanceId string `query:"subCaseInstanceId"`
+	// Restrict query to all process instances that are sub process instances of the given case instance
+	CaseInstanceId string `query:"caseInstanceId"`
+	// Filter by a list of tenant ids. A process instance must have one of the given tenant ids
+	TenantIdIn []string `query:"tenantIdIn"`
+	// Only include historic process instances which belong to no tenant
+	WithoutTenantId bool `query:"withoutTenantId"`
+	// Restrict to instances that executed an activity with one of given ids
+	ExecutedActivityIdIn []string `query:"executedActivityIdIn"`
+	// Restrict to instances that have an active activity with one of given ids
+	ActiveActivityIdIn []string `query:"activeActivityIdIn"`
+	// Restrict to instances that are active
+	Active bool `query:"active"`
+	// Restrict to instances that are suspended
+	Suspended bool `query:"suspended"`
+	// Restrict to instances that are completed
+	Completed bool `query:"completed"`
+	// Restrict to instances that are externally terminated
+	ExternallyTerminated bool `query:"externallyTerminated"`
+	// Restrict to instances that are internally terminated
+	InternallyTerminated bool `query:"internallyTerminated"`
+	// Only include process instances that have/had variables with certain values.
+	// Key and value may not contain underscore or comma characters
+	Variables []VariableFilterExpression `query:"variables"`
+	// Match all variable names in this query case-insensitively
+	VariableNamesIgnoreCase bool `query:"variableNamesIgnoreCase"`
+	// Match all variable values in this query case-insensitively
+	VariableValuesIgnoreCase bool `query:"variableValuesIgnoreCase"`
+}
+
+// Params returns query parameters
+func (q *QueryHistoryProcessInstanceList) Params() map[string]string {
+	return encodeQuery(q)
+}
+
+// QueryHistoryVariableInstanceList a typed query for GetVariableInstanceList and GetVariableInstanceCount,
+// use Params to get query parameters
+// https://docs.camunda.org/manual/latest/reference/rest/history/variable-instance/get-variable-instance-query/#query-parameters
+type QueryHistoryVariableInstanceList struct {
+	QuerySorting
+	QueryPagination
+	// Filter by variable name
+	VariableName string `query:"variableName"`
+	// Restrict to variables with a name like the parameter
+	VariableNameLike string `query:"variableNameLike"`
+	// Filter by variable value. Is treated as a String object on server side
+	VariableValue string `query:"variableValue"`
+	// Match the variable name provided in VariableName and VariableNameLike case-insensitively
+	VariableNamesIgnoreCase bool `query:"variableNamesIgnoreCase"`
+	// Match the variable value provided in VariableValue case-insensitively
+	VariableValuesIgnoreCase bool `query:"variableValuesIgnoreCase"`
+	// Only include historic variable instances which belong to one of the passed variable types
+	VariableTypeIn []string `query:"variableTypeIn"`
+	// Include variables that has already been deleted during the execution
+	IncludeDeleted bool `query:"includeDeleted"`
+	// Filter by the process instance the variable belongs to
+	ProcessInstanceId string `query:"processInstanceId"`
+	// Only include historic variable instances which belong to one of the passed process instance ids
+	ProcessInstanceIdIn []string `query:"processInstanceIdIn"`
+	// Filter by the process definition the variable belongs to
+	ProcessDefinitionId string `query:"processDefinitionId"`
+	// Only include historic variable instances which belong to one of the passed execution ids
+	ExecutionIdIn []string `query:"executionIdIn"`
+	// Filter by the case instance the variable belongs to
+	CaseInstanceId string `query:"caseInstanceId"`
+	// Only include historic variable instances which belong to one of the passed case execution ids
+	CaseExecutionIdIn []string `query:"caseExecutionIdIn"`
+	// Only include historic variable instances which belong to one of the passed case activity ids
+	CaseActivityIdIn []string `query:"caseActivityIdIn"`
+	// Only include historic variable instances which belong to one of the passed task ids
+	TaskIdIn []string `query:"taskIdIn"`
+	// Only include historic variable instances which belong to one of the passed activity instance ids
+	ActivityInstanceIdIn []string `query:"activityInstanceIdIn"`
+	// Filter by a list of tenant ids. A variable instance must have one of the given tenant ids
+	TenantIdIn []string `query:"tenantIdIn"`
+	// Only include historic variable instances which belong to no tenant
+	WithoutTenantId bool `query:"withoutTenantId"`
+	// Determines whether serializable variable values (typically variables that store custom Java objects)
+	// should be deserialized on server side (default true)
+	DeserializeValues *bool `query:"deserializeValues"`
+}
+
+// Params returns query parameters
+func (q *QueryHistoryVariableInstanceList) Params() map[string]string {
+	return encodeQuery(q)
+}
+
+// QueryHistoryTaskList a typed query for GetTaskList and GetTaskCount, use Params to get query parameters
+// https://docs.camunda.org/manual/latest/reference/rest/history/task/get-task-query/#query-parameters
+type QueryHistoryTaskList struct {
+	QuerySorting
+	QueryPagination
+	// Filter by task id
+	TaskId string `query:"taskId"`
+	// Filter by parent task id
+	TaskParentTaskId string `query:"taskParentTaskId"`
+	// Filter by process instance id
+	ProcessInstanceId string `query:"processInstanceId"`
+	// Filter by process instance business key
+	ProcessInstanceBusinessKey string `query:"processInstanceBusinessKey"`
+	// Filter by process instances with one of the give business keys
+	ProcessInstanceBusinessKeyIn []string `query:"processInstanceBusinessKeyIn"`
+	// Filter by process instance business key that has the parameter value as a substring
+	ProcessInstanceBusinessKeyLike string `query:"processInstanceBusinessKeyLike"`
+	// Filter by the id of the execution that executed the task
+	ExecutionId string `query:"executionId"`
+	// Filter by process definition id
+	ProcessDefinitionId string `query:"processDefinitionId"`
+	// Restrict to tasks that belong to a process definition with the given key
+	ProcessDefinitionKey string `query:"processDefinitionKey"`
+	// Restrict to tasks that belong to a process definition with the given name
+	ProcessDefinitionName string `query:"processDefinitionName"`
+	// Filter by case instance id
+	CaseInstanceId string `query:"caseInstanceId"`
+	// Filter by the id of the case execution that executed the task
+	CaseExecutionId string `query:"caseExecutionId"`
+	// Filter by case definition id
+	CaseDefinitionId string `query:"caseDefinitionId"`
+	// Restrict to tasks that belong to a case definition with the given key
+	CaseDefinitionKey string `query:"caseDefinitionKey"`
+	// Restrict to tasks that belong to a case definition with the given name
+	CaseDefinitionName string `query:"caseDefinitionName"`
+	// Only include tasks which belong to one of the passed activity instance ids
+	ActivityInstanceIdIn []string `query:"activityInstanceIdIn"`
+	// Restrict to tasks that have the given name
+	TaskName string `query:"taskName"`
+	// Restrict to tasks that have a name with the given parameter value as substring
+	TaskNameLike string `query:"taskNameLike"`
+	// Restrict to tasks that have the given description
+	TaskDescription string `query:"taskDescription"`
+	// Restrict to tasks that have a description that has the parameter value as a substring
+	TaskDescriptionLike string `query:"taskDescriptionLike"`
+	// Restrict to tasks that have the given key
+	TaskDefinitionKey string `query:"taskDefinitionKey"`
+	// Restrict to tasks that have one of the passed keys
+	TaskDefinitionKeyIn []string `query:"taskDefinitionKeyIn"`
+	// Restrict to tasks that have the given delete reason
+	TaskDeleteReason string `query:"taskDeleteReason"`
+	// Restrict to tasks that have a delete reason that has the parameter value as a substring
+	TaskDeleteReasonLike string `query:"taskDeleteReasonLike"`
+	// Restrict to tasks that the given user is assigned to
+	TaskAssignee string `query:"taskAssignee"`
+	// Restrict to tasks that are assigned to users with the parameter value as a substring
+	TaskAssigneeLike string `query:"taskAssigneeLike"`
+	// Restrict to tasks that the given user owns
+	TaskOwner string `query:"taskOwner"`
+	// Restrict to tasks that are owned by users with the parameter value as a substring
+	TaskOwnerLike string `query:"taskOwnerLike"`
+	// Restrict to tasks that have the given priority
+	TaskPriority *int `query:"taskPriority"`
+	// Only include tasks which are assigned to a user
+	Assigned bool `query:"assigned"`
+	// Only include tasks which are not assigned to any user
+	Unassigned bool `query:"unassigned"`
+	// Only include finished tasks
+	Finished bool `query:"finished"`
+	// Only include unfinished tasks
+	Unfinished bool `query:"unfinished"`
+	// Only include tasks of finished processes
+	ProcessFinished bool `query:"processFinished"`
+	// Only include tasks of unfinished processes
+	ProcessUnfinished bool `query:"processUnfinished"`
+	// Restrict to tasks that are due on the given date
+	TaskDueDate time.Time `query:"taskDueDate"`
+	// Restrict to tasks that are due before the given date
+	TaskDueDateBefore time.Time `query:"taskDueDateBefore"`
+	// Restrict to tasks that are due after the given date
+	TaskDueDateAfter time.Time `query:"taskDueDateAfter"`
+	// Restrict to tasks that have a followUp date on the given date
+	TaskFollowUpDate time.Time `query:"taskFollowUpDate"`
+	// Restrict to tasks that have a followUp date before the given date
+	TaskFollowUpDateBefore time.Time `query:"taskFollowUpDateBefore"`
+	// Restrict to tasks that have a followUp date after the given date
+	TaskFollowUpDateAfter time.Time `query:"taskFollowUpDateAfter"`
+	// Restrict to tasks that were started before the given date
+	StartedBefore time.Time `query:"startedBefore"`
+	// Restrict to tasks that were started after the given date
+	StartedAfter time.Time `query:"startedAfter"`
+	// Restrict to tasks that were finished before the given date
+	FinishedBefore time.Time `query:"finishedBefore"`
+	// Restrict to tasks that were finished after the given date
+	FinishedAfter time.Time `query:"finishedAfter"`
+	// Filter by a list of tenant ids. A task instance must have one of the given tenant ids
+	TenantIdIn []string `query:"tenantIdIn"`
+	// Only include historic task instances which belong to no tenant
+	WithoutTenantId bool `query:"withoutTenantId"`
+	// Only include tasks that have variables with certain values.
+	// Key and value may not contain underscore or comma characters
+	TaskVariables []VariableFilterExpression `query:"taskVariables"`
+	// Only include tasks that belong to process instances that have variables with certain values.
+	// Key and value may not contain underscore or comma characters
+	ProcessVariables []VariableFilterExpression `query:"processVariables"`
+	// Match the variable names provided in TaskVariables and ProcessVariables case-insensitively
+	VariableNamesIgnoreCase bool `query:"variableNamesIgnoreCase"`
+	// Match the variable values provided in TaskVariables and ProcessVariables case-insensitively
+	VariableValuesIgnoreCase bool `query:"variableValuesIgnoreCase"`
+	// Only include tasks with which the given user was involved
+	TaskInvolvedUser string `query:"taskInvolvedUser"`
+	// Only include tasks with which the given group was involved
+	TaskInvolvedGroup string `query:"taskInvolvedGroup"`
+	// Only include tasks which had the given user as a candidate user
+	TaskHadCandidateUser string `query:"taskHadCandidateUser"`
+	// Only include tasks which had the given group as a candidate group
+	TaskHadCandidateGroup string `query:"taskHadCandidateGroup"`
+	// Only include tasks which have a candidate group
+	WithCandidateGroups bool `query:"withCandidateGroups"`
+	// Only include tasks which have no candidate group
+	WithoutCandidateGroups bool `query:"withoutCandidateGroups"`
+}
+
+// Params returns query parameters
+func (q *QueryHistoryTaskList) Params() map[string]string {
+	return encodeQuery(q)
+}
+
 // GetProcessInstanceCount queries for the number of historic process instances that fulfill the given parameters.
 // https://docs.camunda.org/manual/latest/reference/rest/history/process-instance/get-process-instance-query-count/#query-parameters
 func (h *History) GetProcessInstanceCount(query map[string]string) (count int, err error) {
@@ -490,6 +786,20 @@ func (h *History) GetProcessInstanceList(query map[string]string) (processInstan
 	return
 }
 
+// GetProcessInstanceListPager returns a Pager over historic process instances that fulfill the given parameters.
+// Results are sorted by instanceId unless sortBy is set in query
+func (h *History) GetProcessInstanceListPager(query map[string]string, pageSize int) *Pager[*ResHistoryProcessInstance] {
+	if query["sortBy"] == "" {
+		query = pageQuery(query, 0, 0)
+		query["sortBy"] = "instanceId"
+		query["sortOrder"] = "asc"
+	}
+
+	return NewPager(func(ctx context.Context, firstResult, maxResults int) ([]*ResHistoryProcessInstance, error) {
+		return h.client.WithContext(ctx).History.GetProcessInstanceList(pageQuery(query, firstResult, maxResults))
+	}, pageSize)
+}
+
 // GetProcessInstance Retrieves a historic process instance by id, according to the HistoricProcessInstance interface in the engine.
 func (h *History) GetProcessInstance(id string) (processInstance *ResHistoryProcessInstance, err error) {
 	processInstance = &ResHistoryProcessInstance{}
@@ -526,6 +836,15 @@ func (h *History) GetProcessInstanceListPost(query map[string]string, req ReqHis
 	return
 }
 
+// GetProcessInstanceListPostPager returns a Pager over historic process instances that fulfill the given parameters.
+// Results are sorted by instanceId after the criteria of req, so pages do not overlap
+func (h *History) GetProcessInstanceListPostPager(query map[string]string, req ReqHistoryProcessInstanceQuery, pageSize int) *Pager[*ResHistoryProcessInstance] {
+	req.Sorting = stableSorting(req.Sorting, "instanceId")
+	return NewPager(func(ctx context.Context, firstResult, maxResults int) ([]*ResHistoryProcessInstance, error) {
+		return h.client.WithContext(ctx).History.GetProcessInstanceListPost(pageQuery(query, firstResult, maxResults), req)
+	}, pageSize)
+}
+
 // DeleteProcessInstance deletes a historic process instance by id, according to the HistoricProcessInstance interface in the engine.
 func (h *History) DeleteProcessInstance(id string) error {
 	return h.client.doDelete("/history/process-instance/"+id, nil)
@@ -543,6 +862,19 @@ func (h *History) DeleteProcessInstanceAsync(req ReqHistoryDeleteProcessInstance
 	return
 }
 
+// SetProcessInstanceRemovalTimeAsync sets the removal time of multiple historic process instances
+// asynchronously (batch)
+func (h *History) SetProcessInstanceRemovalTimeAsync(req ReqHistoryProcessInstanceRemovalTime) (batch *ResBatch, err error) {
+	batch = &ResBatch{}
+	res, err := h.client.doPostJson("/history/process-instance/set-removal-time", nil, req)
+	if err != nil {
+		return
+	}
+
+	err = h.client.readJsonResponse(res, batch)
+	return
+}
+
 // GetProcessInstanceDurationReport retrieves a report about the duration of completed process instances, grouped by a period.
 // These reports include the maximum, minimum and average duration of all completed process instances which were started in a given period.
 // This only includes historic data.
@@ -620,6 +952,15 @@ func (h *History) GetTaskListPost(query map[string]string, req ReqHistoryTaskQue
 	return
 }
 
+// GetTaskListPostPager returns a Pager over historic task instances that fulfill the given parameters.
+// Results are sorted by taskId after the criteria of req, so pages do not overlap
+func (h *History) GetTaskListPostPager(query map[string]string, req ReqHistoryTaskQuery, pageSize int) *Pager[*ResHistoryTaskInstance] {
+	req.Sorting = stableSorting(req.Sorting, "taskId")
+	return NewPager(func(ctx context.Context, firstResult, maxResults int) ([]*ResHistoryTaskInstance, error) {
+		return h.client.WithContext(ctx).History.GetTaskListPost(pageQuery(query, firstResult, maxResults), req)
+	}, pageSize)
+}
+
 // GetVariableInstanceList queries for historic variable instances that fulfill the given parameters.
 // https://docs.camunda.org/manual/latest/reference/rest/history/variable-instance/get-variable-instance-query/#query-parameters
 func (h *History) GetVariableInstanceList(query map[string]string) (variableInstances []*ResHistoryVariableInstance, err error) {
@@ -632,6 +973,15 @@ func (h *History) GetVariableInstanceList(query map[string]string) (variableInst
 	return
 }
 
+// GetVariableInstanceListPager returns a Pager over historic variable instances that fulfill the given parameters.
+// Without sortBy in query the engine orders results by variable instance id, so pages do not overlap. The API has
+// no unique sort criterion for variable instances, so pages sorted by sortBy may overlap on equal values
+func (h *History) GetVariableInstanceListPager(query map[string]string, pageSize int) *Pager[*ResHistoryVariableInstance] {
+	return NewPager(func(ctx context.Context, firstResult, maxResults int) ([]*ResHistoryVariableInstance, error) {
+		return h.client.WithContext(ctx).History.GetVariableInstanceList(pageQuery(query, firstResult, maxResults))
+	}, pageSize)
+}
+
 // GetVariableInstance retrieves a historic variable by id.
 // https://docs.camunda.org/manual/latest/reference/rest/history/variable-instance/get-variable-instance/#query-parameters
 func (h *History) GetVariableInstance(id string, query map[string]string) (variableInstance *ResHistoryVariableInstance, err error) {
@@ -657,6 +1007,12 @@ func (h *History) GetVariableInstanceBinaryData(id string) (data []byte, err err
 	return ioutil.ReadAll(res.Body)
 }
 
+// DownloadVariableInstanceData retrieves the content of a historic Bytes or File variable by id without buffering it.
+// The returned data must be closed
+func (h *History) DownloadVariableInstanceData(id string) (data *ResVariableData, err error) {
+	return h.client.downloadVariableData("/history/variable-instance/" + id + "/data")
+}
+
 // GetVariableInstanceCountPost queries for historic variable instances that fulfill the given parameters.
 func (h *History) GetVariableInstanceCountPost(req ReqHistoryVariableInstanceQuery) (count int, err error) {
 	resCount := ResCount{}
@@ -680,3 +1036,12 @@ func (h *History) GetVariableInstanceListPost(query map[string]string, req ReqHi
 	err = h.client.readJsonResponse(res, &variableInstances)
 	return
 }
+
+// GetVariableInstanceListPostPager returns a Pager over historic variable instances that fulfill the given parameters.
+// Without sorting in req the engine orders results by variable instance id, so pages do not overlap. The API has
+// no unique sort criterion for variable instances, so pages sorted by req.Sorting may overlap on equal values
+func (h *History) GetVariableInstanceListPostPager(query map[string]string, req ReqHistoryVariableInstanceQuery, pageSize int) *Pager[*ResHistoryVariableInstance] {
+	return NewPager(func(ctx context.Context, firstResult, maxResults int) ([]*ResHistoryVariableInstance, error) {
+		return h.client.WithContext(ctx).History.GetVariableInstanceListPost(pageQuery(query, firstResult, maxResults), req)
+	}, pageSize)
+}
@@ -0,0 +1,162 @@
+package camunda_client_go
+
+import (
+	"context"
+	"time"
+)
+
+// Incident a client for Incident API
+type Incident struct {
+	client *Client
+}
+
+// ResIncident a JSON object corresponding to the Incident interface in the engine
+type ResIncident struct {
+	// The id of the incident
+	Id string `json:"id"`
+	// The id of the process definition this incident is associated with
+	ProcessDefinitionId string `json:"processDefinitionId"`
+	// The id of the process instance this incident is associated with
+	ProcessInstanceId string `json:"processInstanceId"`
+	// The id of the execution this incident is associated with
+	ExecutionId string `json:"executionId"`
+	// The time this incident happened
+	IncidentTimestamp Time `json:"incidentTimestamp"`
+	// The type of incident, for example: failedJob will be returned in case of an incident which identified
+	// a failed job during the execution of a process instance
+	IncidentType string `json:"incidentType"`
+	// The id of the activity this incident is associated with
+	ActivityId string `json:"activityId"`
+	// The id of the activity on which the last exception occurred
+	FailedActivityId string `json:"failedActivityId"`
+	// The id of the associated cause incident which has been triggered
+	CauseIncidentId string `json:"causeIncidentId"`
+	// The id of the associated root cause incident which has been triggered
+	RootCauseIncidentId string `json:"rootCauseIncidentId"`
+	// The payload of this incident, e.g. the id of the failed job or external task
+	Configuration string `json:"configuration"`
+	// The id of the tenant this incident is associated with
+	TenantId string `json:"tenantId"`
+	// The message of this incident
+	IncidentMessage string `json:"incidentMessage"`
+	// The job definition id the incident is associated with
+	JobDefinitionId string `json:"jobDefinitionId"`
+	// The annotation set to the incident
+	Annotation string `json:"annotation"`
+}
+
+// QueryIncidentList a typed query for GetList and GetCount, use Params to get query parameters
+// https://docs.camunda.org/manual/latest/reference/rest/incident/get-query/#query-parameters
+type QueryIncidentList struct {
+	QuerySorting
+	QueryPagination
+	// Restricts to incidents that have the given id
+	IncidentId string `query:"incidentId"`
+	// Restricts to incidents that belong to the given incident type
+	IncidentType string `query:"incidentType"`
+	// Restricts to incidents that have the given incident message
+	IncidentMessage string `query:"incidentMessage"`
+	// Restricts to incidents that incidents message is a substring of the given value
+	IncidentMessageLike string `query:"incidentMessageLike"`
+	// Restricts to incidents that belong to a process definition with the given id
+	ProcessDefinitionId string `query:"processDefinitionId"`
+	// Restricts to incidents that belong to a process definition with the given keys
+	ProcessDefinitionKeyIn []string `query:"processDefinitionKeyIn"`
+	// Restricts to incidents that belong to a process instance with the given id
+	ProcessInstanceId string `query:"processInstanceId"`
+	// Restricts to incidents that belong to an execution with the given id
+	ExecutionId string `query:"executionId"`
+	// Restricts to incidents that have an incidentTimestamp date before the given date
+	IncidentTimestampBefore time.Time `query:"incidentTimestampBefore"`
+	// Restricts to incidents that have an incidentTimestamp date after the given date
+	IncidentTimestampAfter time.Time `query:"incidentTimestampAfter"`
+	// Restricts to incidents that belong to an activity with the given id
+	ActivityId string `query:"activityId"`
+	// Restricts to incidents that were created due to the failure of an activity with the given id
+	FailedActivityId string `query:"failedActivityId"`
+	// Restricts to incidents that have the given incident id as cause incident
+	CauseIncidentId string `query:"causeIncidentId"`
+	// Restricts to incidents that have the given incident id as root cause incident
+	RootCauseIncidentId string `query:"rootCauseIncidentId"`
+	// Restricts to incidents that have the given parameter set as configuration
+	Configuration string `query:"configuration"`
+	// Restricts to incidents that have one of the given tenant ids
+	TenantIdIn []string `query:"tenantIdIn"`
+	// Restricts to incidents that have one of the given job definition ids
+	JobDefinitionIdIn []string `query:"jobDefinitionIdIn"`
+}
+
+// Params returns query parameters
+func (q *QueryIncidentList) Params() map[string]string {
+	return encodeQuery(q)
+}
+
+// GetList queries for incidents that fulfill given parameters.
+// The size of the result set can be retrieved by using the GetCount method.
+// https://docs.camunda.org/manual/latest/reference/rest/incident/get-query/#query-parameters
+func (i *Incident) GetList(query map[string]string) (incidents []*ResIncident, err error) {
+	res, err := i.client.doGet("/incident", query)
+	if err != nil {
+		return
+	}
+
+	err = i.client.readJsonResponse(res, &incidents)
+	return
+}
+
+// GetListPager returns a Pager over incidents that fulfill given parameters.
+// Results are sorted by incident id unless sortBy is set in query
+func (i *Incident) GetListPager(query map[string]string, pageSize int) *Pager[*ResIncident] {
+	if query["sortBy"] == "" {
+		query = pageQuery(query, 0, 0)
+		query["sortBy"] = "incidentId"
+		query["sortOrder"] = "asc"
+	}
+
+	return NewPager(func(ctx context.Context, firstResult, maxResults int) ([]*ResIncident, error) {
+		return i.client.WithContext(ctx).Incident.GetList(pageQuery(query, firstResult, maxResults))
+	}, pageSize)
+}
+
+// GetCount queries for the number of incidents that fulfill given parameters.
+// Takes the same parameters as the GetList method
+func (i *Incident) GetCount(query map[string]string) (count int, err error) {
+	resCount := ResCount{}
+	res, err := i.client.doGet("/incident/count", query)
+	if err != nil {
+		return
+	}
+
+	err = i.client.readJsonResponse(res, &resCount)
+	return resCount.Count, err
+}
+
+// Get retrieves an incident by id
+func (i *Incident) Get(id string) (incident *ResIncident, err error) {
+	incident = &ResIncident{}
+	res, err := i.client.doGet("/incident/"+id, nil)
+	if err != nil {
+		return
+	}
+
+	err = i.client.readJsonResponse(res, incident)
+	return
+}
+
+// Resolve resolves an incident by id. Only custom incidents can be resolved, incidents of failed jobs and
+// external tasks are resolved by setting retries of the job or the external task
+func (i *Incident) Resolve(id string) error {
+	return i.client.doDelete("/incident/"+id, nil)
+}
+
+// SetAnnotation sets the annotation of an incident by id
+func (i *Incident) SetAnnotation(id, annotation string) error {
+	return i.client.doPutJson("/incident/"+id+"/annotation", map[string]string{}, map[string]string{
+		"annotation": annotation,
+	})
+}
+
+// ClearAnnotation clears the annotation of an incident by id
+func (i *Incident) ClearAnnotation(id string) error {
+	return i.client.doDelete("/incident/"+id+"/annotation", nil)
+}
@@ -0,0 +1,15 @@
+package camunda_client_go
+
+// Job a client for Job API
+type Job struct {
+	client *Client
+}
+
+// SetRetries sets the number of retries left to execute a job by id. If retries are set to 0, an incident is
+// created, if retries of a failed job are set above 0, the engine resolves its incident
+// https://docs.camunda.org/manual/latest/reference/rest/job/put-set-job-retries/
+func (j *Job) SetRetries(id string, retries int) error {
+	return j.client.doPutJson("/job/"+id+"/retries", map[string]string{}, map[string]int{
+		"retries": retries,
+	})
+}
@@ -0,0 +1,134 @@
+package camunda_client_go
+
+import (
+	"context"
+	"errors"
+	"strconv"
+)
+
+// DefaultPageSize a number of items fetched by a Pager with a single request by default
+const DefaultPageSize = 100
+
+// ErrCollectLimit returned by CollectAll when there are more items than the limit
+var ErrCollectLimit = errors.New("collect limit exceeded")
+
+// PageFunc fetches at most maxResults items starting from firstResult
+type PageFunc[T any] func(ctx context.Context, firstResult, maxResults int) ([]T, error)
+
+// Pager an iterator over all items of a list endpoint, which fetches pages with firstResult and maxResults.
+// Use it in a loop:
+//
+//	for pager.Next(ctx) {
+//		item := pager.Item()
+//	}
+//	if err := pager.Err(); err != nil {
+//		...
+//	}
+type Pager[T any] struct {
+	fetch       PageFunc[T]
+	pageSize    int
+	firstResult int
+	page        []T
+	index       int
+	last        bool
+	item        T
+	err         error
+}
+
+// NewPager a create new instance Pager, pageSize is DefaultPageSize if not positive
+func NewPager[T any](fetch PageFunc[T], pageSize int) *Pager[T] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	return &Pager[T]{
+		fetch:    fetch,
+		pageSize: pageSize,
+	}
+}
+
+// Next advances to the next item fetching a new page if needed. It returns false when there are no more items
+// or an error occurred, see Err
+func (p *Pager[T]) Next(ctx context.Context) bool {
+	if p.err != nil {
+		return false
+	}
+
+	if p.index >= len(p.page) {
+		if p.last {
+			return false
+		}
+
+		if err := ctx.Err(); err != nil {
+			p.err = err
+			return false
+		}
+
+		page, err := p.fetch(ctx, p.firstResult, p.pageSize)
+		if err != nil {
+			p.err = err
+			return false
+		}
+
+		p.page = page
+		p.index = 0
+		p.firstResult += len(page)
+		// a short page is the last one, so an extra request is not needed
+		p.last = len(page) < p.pageSize
+		if len(page) == 0 {
+			return false
+		}
+	}
+
+	p.item = p.page[p.index]
+	p.index++
+	return true
+}
+
+// Item returns the current item
+func (p *Pager[T]) Item() T {
+	return p.item
+}
+
+// Err returns the error which stopped the iteration
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// CollectAll returns all remaining items of the pager. If limit is positive and there are more items,
+// the first limit items are returned with ErrCollectLimit
+func CollectAll[T any](ctx context.Context, pager *Pager[T], limit int) ([]T, error) {
+	var items []T
+	for pager.Next(ctx) {
+		if limit > 0 && len(items) == limit {
+			return items, ErrCollectLimit
+		}
+
+		items = append(items, pager.Item())
+	}
+
+	return items, pager.Err()
+}
+
+// pageQuery returns a copy of query parameters with a page
+func pageQuery(query map[string]string, firstResult, maxResults int) map[string]string {
+	q := make(map[string]string, len(query)+2)
+	for k, v := range query {
+		q[k] = v
+	}
+
+	q["firstResult"] = strconv.Itoa(firstResult)
+	q["maxResults"] = strconv.Itoa(maxResults)
+	return q
+}
+
+// stableSorting returns sorting with a unique criterion added, so pages do not overlap
+func stableSorting(sorting []ReqSort, uniqueBy string) []ReqSort {
+	for _, s := range sorting {
+		if s.SortBy == uniqueBy {
+			return sorting
+		}
+	}
+
+	return append(append([]ReqSort(nil), sorting...), ReqSort{SortBy: uniqueBy, SortOrder: "asc"})
+}
@@ -0,0 +1,204 @@
+package camunda_client_go
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func numbersPager(total, pageSize int, requests *int) *Pager[int] {
+	return NewPager(func(ctx context.Context, firstResult, maxResults int) ([]int, error) {
+		*requests++
+		var page []int
+		for i := firstResult; i < total && len(page) < maxResults; i++ {
+			page = append(page, i)
+		}
+		return page, nil
+	}, pageSize)
+}
+
+func TestPager(t *testing.T) {
+	tests := []struct {
+		total    int
+		pageSize int
+		requests int
+	}{
+		{total: 0, pageSize: 10, requests: 1},
+		{total: 5, pageSize: 10, requests: 1},
+		{total: 10, pageSize: 10, requests: 2},
+		{total: 11, pageSize: 10, requests: 2},
+		{total: 25, pageSize: 5, requests: 6},
+	}
+
+	for _, test := range tests {
+		requests := 0
+		items, err := CollectAll(context.Background(), numbersPager(test.total, test.pageSize, &requests), 0)
+		assert.NoError(t, err)
+		assert.Len(t, items, test.total)
+		for i, item := range items {
+			assert.Equal(t, i, item)
+		}
+		assert.Equal(t, test.requests, requests, "total %d, page size %d", test.total, test.pageSize)
+	}
+}
+
+func TestPagerCollectLimit(t *testing.T) {
+	requests := 0
+	items, err := CollectAll(context.Background(), numbersPager(25, 10, &requests), 20)
+	assert.ErrorIs(t, err, ErrCollectLimit)
+	assert.Len(t, items, 20)
+
+	items, err = CollectAll(context.Background(), numbersPager(20, 10, &requests), 20)
+	assert.NoError(t, err)
+	assert.Len(t, items, 20)
+}
+
+func TestPagerError(t *testing.T) {
+	fail := errors.New("fail")
+	pager := NewPager(func(ctx context.Context, firstResult, maxResults int) ([]int, error) {
+		if firstResult > 0 {
+			return nil, fail
+		}
+		return []int{1, 2}, nil
+	}, 2)
+
+	items, err := CollectAll(context.Background(), pager, 0)
+	assert.ErrorIs(t, err, fail)
+	assert.Equal(t, []int{1, 2}, items)
+	assert.False(t, pager.Next(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	requests := 0
+	pager = numbersPager(1, 1, &requests)
+	assert.False(t, pager.Next(ctx))
+	assert.ErrorIs(t, pager.Err(), context.Canceled)
+	assert.Equal(t, 0, requests)
+}
+
+func TestProcessInstanceGetListPostPager(t *testing.T) {
+	var sorting [][]ReqSort
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := ReqProcessInstanceQuery{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		sorting = append(sorting, req.Sorting)
+
+		firstResult, _ := strconv.Atoi(r.URL.Query().Get("firstResult"))
+		maxResults, _ := strconv.Atoi(r.URL.Query().Get("maxResults"))
+		assert.Equal(t, "true", r.URL.Query().Get("active"))
+
+		var page []*ResProcessInstance
+		for i := firstResult; i < 3 && len(page) < maxResults; i++ {
+			page = append(page, &ResProcessInstance{Id: strconv.Itoa(i)})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOptions{EndpointUrl: server.URL})
+	query := map[string]string{"active": "true"}
+	pager := client.ProcessInstance.GetListPostPager(query, ReqProcessInstanceQuery{
+		Sorting: []ReqSort{{SortBy: "businessKey", SortOrder: "desc"}},
+	}, 2)
+
+	var ids []string
+	for pager.Next(context.Background()) {
+		ids = append(ids, pager.Item().Id)
+	}
+	assert.NoError(t, pager.Err())
+	assert.Equal(t, []string{"0", "1", "2"}, ids)
+	assert.Equal(t, map[string]string{"active": "true"}, query)
+	assert.Len(t, sorting, 2)
+	assert.Equal(t, []ReqSort{
+		{SortBy: "businessKey", SortOrder: "desc"},
+		{SortBy: "instanceId", SortOrder: "asc"},
+	}, sorting[0])
+}
+
+func TestExternalTaskGetListPostPager(t *testing.T) {
+	var sorting []ReqSort
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := struct {
+			Sorting []ReqSort `json:"sorting"`
+		}{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		sorting = req.Sorting
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id": "t1"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOptions{EndpointUrl: server.URL})
+	pager := client.ExternalTask.GetListPostPager(nil, QueryGetListPost{
+		Sorting: &QueryListPostSorting{SortBy: "taskPriority", SortOrder: "desc"},
+	}, 10)
+	tasks, err := CollectAll(context.Background(), pager, 0)
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+	assert.Equal(t, []ReqSort{
+		{SortBy: "taskPriority", SortOrder: "desc"},
+		{SortBy: "id", SortOrder: "asc"},
+	}, sorting)
+
+	pager = client.ExternalTask.GetListPostPager(nil, QueryGetListPost{
+		Sorting: &QueryListPostSorting{SortBy: "taskPriority", SortOrder: "desc"},
+		SortingList: []QueryListPostSorting{
+			{SortBy: "processInstanceId", SortOrder: "asc"},
+			{SortBy: "id", SortOrder: "desc"},
+		},
+	}, 10)
+	_, err = CollectAll(context.Background(), pager, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []ReqSort{
+		{SortBy: "processInstanceId", SortOrder: "asc"},
+		{SortBy: "id", SortOrder: "desc"},
+	}, sorting)
+}
+
+func TestGetListPagers(t *testing.T) {
+	sortBy := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "0", r.URL.Query().Get("firstResult"))
+		assert.Equal(t, "5", r.URL.Query().Get("maxResults"))
+		sortBy[r.URL.Path] = r.URL.Query().Get("sortBy")
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id": "1"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOptions{EndpointUrl: server.URL})
+	ctx := context.Background()
+	_, err := CollectAll(ctx, client.ProcessDefinition.GetListPager(nil, 5), 0)
+	assert.NoError(t, err)
+	_, err = CollectAll(ctx, client.ProcessInstance.GetListPager(nil, 5), 0)
+	assert.NoError(t, err)
+	_, err = CollectAll(ctx, client.ExternalTask.GetListPager(map[string]string{"sortBy": "taskPriority"}, 5), 0)
+	assert.NoError(t, err)
+	_, err = CollectAll(ctx, client.History.GetProcessInstanceListPager(nil, 5), 0)
+	assert.NoError(t, err)
+	_, err = CollectAll(ctx, client.History.GetVariableInstanceListPager(nil, 5), 0)
+	assert.NoError(t, err)
+	_, err = CollectAll(ctx, client.History.GetJobLogListPager(nil, 5), 0)
+	assert.NoError(t, err)
+	_, err = CollectAll(ctx, client.History.GetExternalTaskLogListPager(map[string]string{"sortBy": "timestamp"}, 5), 0)
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"/process-definition":        "id",
+		"/process-instance":          "instanceId",
+		"/external-task":             "taskPriority",
+		"/history/process-instance":  "instanceId",
+		"/history/variable-instance": "",
+		"/history/job-log":           "",
+		"/history/external-task-log": "timestamp",
+	}, sortBy)
+}
@@ -1,6 +1,9 @@
 package camunda_client_go
 
-import "io/ioutil"
+import (
+	"context"
+	"io/ioutil"
+)
 
 // ProcessDefinition a client for ProcessDefinition
 type ProcessDefinition struct {
@@ -298,6 +301,78 @@ func (p *ProcessDefinition) GetStartFormVariables(by QueryProcessDefinitionBy, q
 	return
 }
 
+// QueryProcessDefinitionList a typed query for GetList and GetListCount, use Params to get query parameters
+// https://docs.camunda.org/manual/latest/reference/rest/process-definition/get-query/#query-parameters
+type QueryProcessDefinitionList struct {
+	QuerySorting
+	QueryPagination
+	// Filter by process definition id
+	ProcessDefinitionId string `query:"processDefinitionId"`
+	// Filter by a list of process definition ids
+	ProcessDefinitionIdIn []string `query:"processDefinitionIdIn"`
+	// Filter by process definition name
+	Name string `query:"name"`
+	// Filter by process definition names that the parameter is a substring of
+	NameLike string `query:"nameLike"`
+	// Filter by the deployment the id belongs to
+	DeploymentId string `query:"deploymentId"`
+	// Filter by process definition key, i.e., the id in the BPMN 2.0 XML. Exact match
+	Key string `query:"key"`
+	// Filter by a list of process definition keys
+	KeysIn []string `query:"keysIn"`
+	// Filter by process definition keys that the parameter is a substring of
+	KeyLike string `query:"keyLike"`
+	// Filter by process definition category. Exact match
+	Category string `query:"category"`
+	// Filter by process definition categories that the parameter is a substring of
+	CategoryLike string `query:"categoryLike"`
+	// Filter by process definition version
+	Version *int `query:"version"`
+	// Only include those process definitions that are latest versions
+	LatestVersion bool `query:"latestVersion"`
+	// Filter by the name of the process definition resource. Exact match
+	ResourceName string `query:"resourceName"`
+	// Filter by names of those process definition resources that the parameter is a substring of
+	ResourceNameLike string `query:"resourceNameLike"`
+	// Filter by a user name who is allowed to start the process
+	StartableBy string `query:"startableBy"`
+	// Only include active process definitions
+	Active bool `query:"active"`
+	// Only include suspended process definitions
+	Suspended bool `query:"suspended"`
+	// Filter by the incident id
+	IncidentId string `query:"incidentId"`
+	// Filter by the incident type
+	IncidentType string `query:"incidentType"`
+	// Filter by the incident message. Exact match
+	IncidentMessage string `query:"incidentMessage"`
+	// Filter by the incident message that the parameter is a substring of
+	IncidentMessageLike string `query:"incidentMessageLike"`
+	// Filter by a list of tenant ids. A process definition must have one of the given tenant ids
+	TenantIdIn []string `query:"tenantIdIn"`
+	// Only include process definitions which belong to no tenant
+	WithoutTenantId bool `query:"withoutTenantId"`
+	// Include process definitions which belong to no tenant. Can be used in combination with TenantIdIn
+	IncludeProcessDefinitionsWithoutTenantId bool `query:"includeProcessDefinitionsWithoutTenantId"`
+	// Filter by the version tag
+	VersionTag string `query:"versionTag"`
+	// Filter by the version tag that the parameter is a substring of
+	VersionTagLike string `query:"versionTagLike"`
+	// Only include process definitions without a version tag
+	WithoutVersionTag bool `query:"withoutVersionTag"`
+	// Filter by process definitions which are startable in Tasklist
+	StartableInTasklist bool `query:"startableInTasklist"`
+	// Filter by process definitions which are not startable in Tasklist
+	NotStartableInTasklist bool `query:"notStartableInTasklist"`
+	// Filter by process definitions which the user is allowed to start in Tasklist
+	StartablePermissionCheck bool `query:"startablePermissionCheck"`
+}
+
+// Params returns query parameters
+func (q *QueryProcessDefinitionList) Params() map[string]string {
+	return encodeQuery(q)
+}
+
 // GetListCount requests the number of process definitions that fulfill the query criteria.
 // Takes the same filtering parameters as the Get Definitions method
 // https://docs.camunda.org/manual/latest/reference/rest/process-definition/get-query-count/#query-parameters
@@ -326,6 +401,20 @@ func (p *ProcessDefinition) GetList(query map[string]string) (processDefinitions
 	return
 }
 
+// GetListPager returns a Pager over process definitions that fulfill given parameters.
+// Results are sorted by id unless sortBy is set in query
+func (p *ProcessDefinition) GetListPager(query map[string]string, pageSize int) *Pager[*ResProcessDefinition] {
+	if query["sortBy"] == "" {
+		query = pageQuery(query, 0, 0)
+		query["sortBy"] = "id"
+		query["sortOrder"] = "asc"
+	}
+
+	return NewPager(func(ctx context.Context, firstResult, maxResults int) ([]*ResProcessDefinition, error) {
+		return p.client.WithContext(ctx).ProcessDefinition.GetList(pageQuery(query, firstResult, maxResults))
+	}, pageSize)
+}
+
 // GetRenderedStartForm retrieves the rendered form for a process definition.
 // This method can be used for getting the HTML rendering of a Generated Task Form
 func (p *ProcessDefinition) GetRenderedStartForm(by QueryProcessDefinitionBy) (htmlForm string, err error) {
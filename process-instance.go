@@ -1,6 +1,9 @@
 package camunda_client_go
 
-import "io/ioutil"
+import (
+	"context"
+	"io/ioutil"
+)
 
 // ProcessInstance a client for ProcessInstance API
 type ProcessInstance struct {
@@ -414,6 +417,23 @@ func (p *ProcessInstance) GetBinaryProcessVariableData(by QueryProcessInstanceVa
 	return ioutil.ReadAll(res.Body)
 }
 
+// DownloadProcessVariableData retrieves the content of a Bytes or File Process Variable by the Process Instance id
+// and the Process Variable name without buffering it. The returned data must be closed
+func (p *ProcessInstance) DownloadProcessVariableData(by QueryProcessInstanceVariableBy) (data *ResVariableData, err error) {
+	return p.client.downloadVariableData(by.String() + "/data")
+}
+
+// UploadProcessVariableData sets the content of a Bytes or File Process Variable by the Process Instance id
+// and the Process Variable name, the content is streamed as multipart form data
+func (p *ProcessInstance) UploadProcessVariableData(by QueryProcessInstanceVariableBy, req ReqBinaryVariable) error {
+	variableName := ""
+	if by.VariableName != nil {
+		variableName = *by.VariableName
+	}
+
+	return p.client.uploadVariableData(by.String()+"/data", variableName, req)
+}
+
 // GetProcessVariable retrieves a variable of a given process instance by id.
 // https://docs.camunda.org/manual/latest/reference/rest/process-instance/variables/get-variable/#query-parameters
 func (p *ProcessInstance) GetProcessVariable(by QueryProcessInstanceVariableBy, query map[string]string) (processVariable *ResProcessVariable, err error) {
@@ -473,6 +493,77 @@ func (p *ProcessInstance) GetActivityInstance(id string) (instance *ResProcessAc
 	return
 }
 
+// QueryProcessInstanceList a typed query for GetList and GetCount, use Params to get query parameters
+// https://docs.camunda.org/manual/latest/reference/rest/process-instance/get-query/#query-parameters
+type QueryProcessInstanceList struct {
+	QuerySorting
+	QueryPagination
+	// Filter by a list of process instance ids
+	ProcessInstanceIds []string `query:"processInstanceIds"`
+	// Filter by process instance business key
+	BusinessKey string `query:"businessKey"`
+	// Filter by process instance business key that the parameter is a substring of
+	BusinessKeyLike string `query:"businessKeyLike"`
+	// Filter by case instance id
+	CaseInstanceId string `query:"caseInstanceId"`
+	// Filter by the process definition the instances run on
+	ProcessDefinitionId string `query:"processDefinitionId"`
+	// Filter by the key of the process definition the instances run on
+	ProcessDefinitionKey string `query:"processDefinitionKey"`
+	// Filter by a list of process definition keys
+	ProcessDefinitionKeyIn []string `query:"processDefinitionKeyIn"`
+	// Exclude instances by a list of process definition keys
+	ProcessDefinitionKeyNotIn []string `query:"processDefinitionKeyNotIn"`
+	// Filter by the deployment the id belongs to
+	DeploymentId string `query:"deploymentId"`
+	// Restrict query to all process instances that are sub process instances of the given process instance
+	SuperProcessInstance string `query:"superProcessInstance"`
+	// Restrict query to all process instances that have the given process instance as a sub process instance
+	SubProcessInstance string `query:"subProcessInstance"`
+	// Restrict query to all process instances that are sub process instances of the given case instance
+	SuperCaseInstance string `query:"superCaseInstance"`
+	// Restrict query to all process instances that have the given case instance as a sub case instance
+	SubCaseInstance string `query:"subCaseInstance"`
+	// Only include active process instances
+	Active bool `query:"active"`
+	// Only include suspended process instances
+	Suspended bool `query:"suspended"`
+	// Filter by presence of incidents
+	WithIncident bool `query:"withIncident"`
+	// Filter by the incident id
+	IncidentId string `query:"incidentId"`
+	// Filter by the incident type
+	IncidentType string `query:"incidentType"`
+	// Filter by the incident message. Exact match
+	IncidentMessage string `query:"incidentMessage"`
+	// Filter by the incident message that the parameter is a substring of
+	IncidentMessageLike string `query:"incidentMessageLike"`
+	// Filter by a list of tenant ids. A process instance must have one of the given tenant ids
+	TenantIdIn []string `query:"tenantIdIn"`
+	// Only include process instances which belong to no tenant
+	WithoutTenantId bool `query:"withoutTenantId"`
+	// Only include process instances which process definition has no tenant id
+	ProcessDefinitionWithoutTenantId bool `query:"processDefinitionWithoutTenantId"`
+	// Filter by a list of activity ids. A process instance must currently wait in a leaf activity with one of the given activity ids
+	ActivityIdIn []string `query:"activityIdIn"`
+	// Restrict the query to all process instances that are top level process instances
+	RootProcessInstances bool `query:"rootProcessInstances"`
+	// Restrict the query to all process instances that are leaf instances
+	LeafProcessInstances bool `query:"leafProcessInstances"`
+	// Only include process instances that have variables with certain values.
+	// Key and value may not contain underscore or comma characters
+	Variables []VariableFilterExpression `query:"variables"`
+	// Match all variable names in this query case-insensitively
+	VariableNamesIgnoreCase bool `query:"variableNamesIgnoreCase"`
+	// Match all variable values in this query case-insensitively
+	VariableValuesIgnoreCase bool `query:"variableValuesIgnoreCase"`
+}
+
+// Params returns query parameters
+func (q *QueryProcessInstanceList) Params() map[string]string {
+	return encodeQuery(q)
+}
+
 // GetCount queries for the number of process instances that fulfill given parameters.
 // https://docs.camunda.org/manual/latest/reference/rest/process-instance/get-query-count/#query-parameters
 func (p *ProcessInstance) GetCount(query map[string]string) (count int, err error) {
@@ -500,6 +591,20 @@ func (p *ProcessInstance) GetList(query map[string]string) (processInstances []*
 	return
 }
 
+// GetListPager returns a Pager over process instances that fulfill given parameters.
+// Results are sorted by instanceId unless sortBy is set in query
+func (p *ProcessInstance) GetListPager(query map[string]string, pageSize int) *Pager[*ResProcessInstance] {
+	if query["sortBy"] == "" {
+		query = pageQuery(query, 0, 0)
+		query["sortBy"] = "instanceId"
+		query["sortOrder"] = "asc"
+	}
+
+	return NewPager(func(ctx context.Context, firstResult, maxResults int) ([]*ResProcessInstance, error) {
+		return p.client.WithContext(ctx).ProcessInstance.GetList(pageQuery(query, firstResult, maxResults))
+	}, pageSize)
+}
+
 // Get retrieves a process instance by id, according to the ProcessInstance interface in the engine.
 func (p *ProcessInstance) Get(id string) (processInstance *ResProcessInstance, err error) {
 	processInstance = &ResProcessInstance{}
@@ -598,6 +703,15 @@ func (p *ProcessInstance) GetListPost(query map[string]string, req ReqProcessIns
 	return
 }
 
+// GetListPostPager returns a Pager over process instances that fulfill the given parameters.
+// Results are sorted by instanceId after the criteria of req, so pages do not overlap
+func (p *ProcessInstance) GetListPostPager(query map[string]string, req ReqProcessInstanceQuery, pageSize int) *Pager[*ResProcessInstance] {
+	req.Sorting = stableSorting(req.Sorting, "instanceId")
+	return NewPager(func(ctx context.Context, firstResult, maxResults int) ([]*ResProcessInstance, error) {
+		return p.client.WithContext(ctx).ProcessInstance.GetListPost(pageQuery(query, firstResult, maxResults), req)
+	}, pageSize)
+}
+
 // SetJobRetriesAsync creates a batch to set retries of jobs associated with given processes asynchronously.
 func (p *ProcessInstance) SetJobRetriesAsync(req ReqProcessInstanceJobRetries) (batch *ResBatch, err error) {
 	batch = &ResBatch{}
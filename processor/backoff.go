@@ -0,0 +1,51 @@
+package processor
+
+import "time"
+
+// Backoff a delay strategy for retrying failed fetch and lock requests
+type Backoff interface {
+	// Next returns a delay before the next request after the given number of consecutive failures (starting from 1)
+	Next(failures int) time.Duration
+}
+
+// BackoffFunc an adapter to use ordinary functions as Backoff
+type BackoffFunc func(failures int) time.Duration
+
+// Next returns f(failures)
+func (f BackoffFunc) Next(failures int) time.Duration {
+	return f(failures)
+}
+
+// LinearBackoff returns a Backoff which increases the delay by step after each failure up to max
+func LinearBackoff(step, max time.Duration) Backoff {
+	return BackoffFunc(func(failures int) time.Duration {
+		delay := time.Duration(failures) * step
+		if delay > max || delay < 0 {
+			return max
+		}
+
+		return delay
+	})
+}
+
+// ExponentialBackoff returns a Backoff which doubles the delay after each failure starting from initial up to max
+func ExponentialBackoff(initial, max time.Duration) Backoff {
+	return BackoffFunc(func(failures int) time.Duration {
+		delay := initial
+		for i := 1; i < failures; i++ {
+			delay *= 2
+			if delay > max || delay <= 0 {
+				return max
+			}
+		}
+
+		if delay > max {
+			return max
+		}
+
+		return delay
+	})
+}
+
+// defaultBackoff increases the delay by a second up to a minute
+var defaultBackoff = LinearBackoff(time.Second, time.Minute)
@@ -0,0 +1,24 @@
+package processor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinearBackoff(t *testing.T) {
+	backoff := LinearBackoff(time.Second, 3*time.Second)
+	assert.Equal(t, time.Second, backoff.Next(1))
+	assert.Equal(t, 2*time.Second, backoff.Next(2))
+	assert.Equal(t, 3*time.Second, backoff.Next(5))
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(100*time.Millisecond, time.Second)
+	assert.Equal(t, 100*time.Millisecond, backoff.Next(1))
+	assert.Equal(t, 200*time.Millisecond, backoff.Next(2))
+	assert.Equal(t, 800*time.Millisecond, backoff.Next(4))
+	assert.Equal(t, time.Second, backoff.Next(5))
+	assert.Equal(t, time.Second, backoff.Next(1000))
+}
@@ -0,0 +1,304 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+)
+
+// subscription a handler with its topics and workers
+type subscription struct {
+	topics   []*camundaclientgo.QueryFetchAndLockTopic
+	handler  Handler
+	limit    int
+	inFlight int
+	tasks    chan fetchedTask
+	loop     *fetchLoop
+}
+
+// fetchedTask a task queued for a worker with the context of the fetch request
+type fetchedTask struct {
+	task     *camundaclientgo.ResLockedExternalTask
+	fetchCtx context.Context
+}
+
+// fetchLoop pulls tasks of its subscriptions with a single long polling request
+type fetchLoop struct {
+	subscriptions []*subscription
+	stopped       bool
+	wakeup        chan struct{}
+}
+
+// subscribe register a handler in a fetch loop: a new one or the shared one with SingleFetchLoop.
+// It panics if a topic is already subscribed in the shared loop, since tasks are dispatched by topic name
+func (p *Processor) subscribe(topics []*camundaclientgo.QueryFetchAndLockTopic, handler Handler, maxParallelTasks int) {
+	// a single fetch can bring up to maxTasks tasks for the handler, so sending never blocks
+	sub := &subscription{
+		topics:  topics,
+		handler: handler,
+		limit:   maxParallelTasks,
+		tasks:   make(chan fetchedTask, maxParallelTasks+p.maxTasks()),
+	}
+
+	p.mu.Lock()
+	if p.ctx.Err() != nil {
+		p.mu.Unlock()
+		return
+	}
+
+	loop := p.singleLoop
+	startLoop := loop == nil
+	if startLoop {
+		loop = &fetchLoop{wakeup: make(chan struct{}, 1)}
+		p.loops = append(p.loops, loop)
+		if p.options.SingleFetchLoop {
+			p.singleLoop = loop
+		}
+	}
+
+	if loop.stopped {
+		p.mu.Unlock()
+		return
+	}
+
+	if topicName := subscribedTopic(loop, topics); topicName != "" {
+		p.mu.Unlock()
+		panic(fmt.Sprintf("processor: topic %s is already subscribed by another handler", topicName))
+	}
+
+	sub.loop = loop
+	loop.subscriptions = append(loop.subscriptions, sub)
+	p.mu.Unlock()
+
+	// create worker pool
+	p.mu.Lock()
+	p.workers += maxParallelTasks
+	p.mu.Unlock()
+	for i := 0; i < maxParallelTasks; i++ {
+		p.workerGroup.Add(1)
+		go p.runWorker(sub)
+	}
+
+	if startLoop {
+		go p.runFetchLoop(loop)
+		return
+	}
+
+	// a running long polling request is not aborted, since tasks it has already locked would be lost,
+	// topics of the new handler are fetched by the next request. Wake up the loop if it waits for free workers
+	select {
+	case loop.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+// subscribedTopic returns the name of a topic which is already subscribed in the loop, p.mu must be held
+func subscribedTopic(loop *fetchLoop, topics []*camundaclientgo.QueryFetchAndLockTopic) string {
+	for _, sub := range loop.subscriptions {
+		for _, subscribed := range sub.topics {
+			for _, topic := range topics {
+				if topic.TopicName == subscribed.TopicName {
+					return topic.TopicName
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+// runFetchLoop fetch tasks for free workers of the loop subscriptions and dispatch them
+func (p *Processor) runFetchLoop(loop *fetchLoop) {
+	defer p.stopFetchLoop(loop)
+
+	failures := 0
+	for {
+		if p.ctx.Err() != nil {
+			return
+		}
+
+		topics, maxTasks := p.freeCapacity(loop)
+		if maxTasks == 0 {
+			// all workers are busy, wait until one of them is released
+			select {
+			case <-p.ctx.Done():
+			case <-loop.wakeup:
+			}
+			continue
+		}
+
+		spanCtx, endFetch := p.tracer.StartFetch(p.ctx)
+		fetchStarted := time.Now()
+		tasks, err := p.client.WithContext(spanCtx).ExternalTask.FetchAndLock(p.fetchAndLockQuery(topics, maxTasks))
+		fetchDuration := time.Since(fetchStarted)
+		endFetch(len(tasks), err)
+
+		if err != nil {
+			if p.ctx.Err() != nil {
+				// the request was aborted by a shutdown
+				continue
+			}
+
+			failures++
+			delay := p.backoff.Next(failures)
+			p.observer.OnFetchError(err, fetchDuration)
+			p.logger(fmt.Errorf("failed pull: %w, sleeping: %s", err, delay))
+			select {
+			case <-p.ctx.Done():
+			case <-time.After(delay):
+			}
+			continue
+		}
+		failures = 0
+		p.observer.OnFetch(len(tasks), fetchDuration)
+
+		for _, task := range tasks {
+			p.observer.OnTaskFetched(task)
+			p.dispatch(loop, fetchedTask{task: task, fetchCtx: valueContext{spanCtx}})
+		}
+	}
+}
+
+// stopFetchLoop close task channels of the loop subscriptions, so workers stop after the queued tasks.
+// Queued tasks are unlocked right away if required, without waiting for busy workers
+func (p *Processor) stopFetchLoop(loop *fetchLoop) {
+	p.mu.Lock()
+	loop.stopped = true
+	subscriptions := loop.subscriptions
+	p.mu.Unlock()
+
+	if p.unlockQueued() {
+		for _, sub := range subscriptions {
+			p.unlockAll(sub)
+		}
+	}
+
+	for _, sub := range subscriptions {
+		close(sub.tasks)
+	}
+}
+
+// unlockAll unlock all queued tasks of the subscription
+func (p *Processor) unlockAll(sub *subscription) {
+	for {
+		select {
+		case fetched := <-sub.tasks:
+			p.unlock(sub, fetched.task)
+		default:
+			return
+		}
+	}
+}
+
+func (p *Processor) fetchAndLockQuery(topics []*camundaclientgo.QueryFetchAndLockTopic, maxTasks int) camundaclientgo.QueryFetchAndLock {
+	var asyncResponseTimeout *int
+	if p.options.AsyncResponseTimeout != nil {
+		asyncResponseTimeout = p.options.AsyncResponseTimeout
+	} else if p.options.LongPollingTimeout.Nanoseconds() > 0 {
+		msValue := int(p.options.LongPollingTimeout.Nanoseconds() / int64(time.Millisecond))
+		asyncResponseTimeout = &msValue
+	}
+
+	return camundaclientgo.QueryFetchAndLock{
+		WorkerId:             p.options.WorkerId,
+		MaxTasks:             maxTasks,
+		UsePriority:          p.options.UsePriority,
+		AsyncResponseTimeout: asyncResponseTimeout,
+		Topics:               topics,
+	}
+}
+
+func (p *Processor) maxTasks() int {
+	if p.options.MaxTasks < 1 {
+		return 1
+	}
+
+	return p.options.MaxTasks
+}
+
+// freeCapacity returns topics of handlers with free workers and a number of tasks that can be processed right now
+func (p *Processor) freeCapacity(loop *fetchLoop) ([]*camundaclientgo.QueryFetchAndLockTopic, int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var topics []*camundaclientgo.QueryFetchAndLockTopic
+	free := 0
+	for _, sub := range loop.subscriptions {
+		if n := sub.limit - sub.inFlight; n > 0 {
+			free += n
+			topics = append(topics, sub.topics...)
+		}
+	}
+
+	if p.options.MaxParallelTasks > 0 && p.options.MaxParallelTasks-p.inFlight < free {
+		free = p.options.MaxParallelTasks - p.inFlight
+	}
+
+	if p.maxTasks() < free {
+		free = p.maxTasks()
+	}
+
+	if free <= 0 {
+		return nil, 0
+	}
+
+	return topics, free
+}
+
+// dispatch send a task to the handler subscribed to its topic
+func (p *Processor) dispatch(loop *fetchLoop, fetched fetchedTask) {
+	task := fetched.task
+	sub := p.subscriptionByTopic(loop, task.TopicName)
+	if sub == nil {
+		p.logger(fmt.Errorf("no handler for task %s with topic %s, unlocking", task.Id, task.TopicName))
+		if err := p.client.ExternalTask.Unlock(task.Id); err != nil {
+			p.logger(fmt.Errorf("error unlock task %s: %w", task.Id, err))
+		}
+		return
+	}
+
+	p.mu.Lock()
+	sub.inFlight++
+	p.inFlight++
+	p.mu.Unlock()
+
+	sub.tasks <- fetched
+}
+
+func (p *Processor) subscriptionByTopic(loop *fetchLoop, topicName string) *subscription {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, sub := range loop.subscriptions {
+		for _, topic := range sub.topics {
+			if topic.TopicName == topicName {
+				return sub
+			}
+		}
+	}
+
+	return nil
+}
+
+// release free a worker slot of the subscription and wake up fetch loops waiting for it. The task of the slot
+// is counted by counter of the shutdown report, if it is not nil and the report was not returned yet
+func (p *Processor) release(sub *subscription, counter *int) {
+	p.mu.Lock()
+	sub.inFlight--
+	p.inFlight--
+	if counter != nil && !p.reported {
+		*counter++
+	}
+	// the global limit is shared by all loops, so each of them can fetch now
+	loops := p.loops
+	p.mu.Unlock()
+
+	for _, loop := range loops {
+		select {
+		case loop.wakeup <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package processor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+	"github.com/citilinkru/camunda-client-go/v3/camundatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreeCapacity(t *testing.T) {
+	proc := NewProcessor(nil, &Options{MaxTasks: 10, MaxParallelTasks: 6}, func(err error) {})
+	hello := &subscription{topics: []*camundaclientgo.QueryFetchAndLockTopic{{TopicName: "PrintHello"}}, limit: 3}
+	world := &subscription{topics: []*camundaclientgo.QueryFetchAndLockTopic{{TopicName: "PrintWorld"}}, limit: 5}
+	loop := &fetchLoop{subscriptions: []*subscription{hello, world}}
+
+	topics, maxTasks := proc.freeCapacity(loop)
+	assert.Len(t, topics, 2)
+	assert.Equal(t, 6, maxTasks, "global limit")
+
+	hello.inFlight, world.inFlight, proc.inFlight = 3, 1, 4
+	topics, maxTasks = proc.freeCapacity(loop)
+	assert.Equal(t, []*camundaclientgo.QueryFetchAndLockTopic{{TopicName: "PrintWorld"}}, topics)
+	assert.Equal(t, 2, maxTasks)
+
+	hello.inFlight, world.inFlight, proc.inFlight = 1, 5, 6
+	topics, maxTasks = proc.freeCapacity(loop)
+	assert.Nil(t, topics)
+	assert.Equal(t, 0, maxTasks, "saturated")
+
+	proc.options.MaxParallelTasks = 0
+	hello.inFlight, world.inFlight, proc.inFlight = 0, 0, 0
+	proc.options.MaxTasks = 4
+	_, maxTasks = proc.freeCapacity(loop)
+	assert.Equal(t, 4, maxTasks, "limited by MaxTasks")
+}
+
+func TestSingleFetchLoopDispatch(t *testing.T) {
+	server := camundatest.NewServer()
+	defer server.Close()
+
+	proc := NewProcessor(server.Client(), &Options{
+		WorkerId:                  "worker",
+		LockDuration:              time.Minute,
+		MaxTasks:                  10,
+		MaxParallelTaskPerHandler: 2,
+		LongPollingTimeout:        time.Minute,
+		SingleFetchLoop:           true,
+	}, func(err error) {})
+	defer proc.Shutdown()
+
+	var mu sync.Mutex
+	handled := map[string][]string{}
+	handler := func(name string) Handler {
+		return func(ctx *Context) error {
+			mu.Lock()
+			handled[name] = append(handled[name], ctx.Task.TopicName)
+			mu.Unlock()
+			return ctx.Complete(QueryComplete{})
+		}
+	}
+
+	proc.AddHandler([]*camundaclientgo.QueryFetchAndLockTopic{{TopicName: "PrintHello"}}, handler("hello"))
+	// wait for the long polling request of the first handler, a new handler must not abort it
+	time.Sleep(100 * time.Millisecond)
+	proc.AddHandler([]*camundaclientgo.QueryFetchAndLockTopic{{TopicName: "PrintWorld"}}, handler("world"))
+	assert.PanicsWithValue(t, "processor: topic PrintHello is already subscribed by another handler", func() {
+		proc.AddHandler([]*camundaclientgo.QueryFetchAndLockTopic{{TopicName: "PrintHello"}}, handler("duplicate"))
+	})
+
+	tasks := []camundatest.ExternalTask{
+		server.AddExternalTask(camundatest.ExternalTask{TopicName: "PrintHello"}),
+		server.AddExternalTask(camundatest.ExternalTask{TopicName: "PrintWorld"}),
+		server.AddExternalTask(camundatest.ExternalTask{TopicName: "PrintHello"}),
+		server.AddExternalTask(camundatest.ExternalTask{TopicName: "PrintWorld"}),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, server.Wait(ctx, func() bool {
+		for _, task := range server.ExternalTasks() {
+			if task.State != camundatest.TaskStateCompleted {
+				return false
+			}
+		}
+		return true
+	}))
+
+	for _, task := range tasks {
+		server.AssertExternalTaskCompleted(t, task.Id)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, map[string][]string{
+		"hello": {"PrintHello", "PrintHello"},
+		"world": {"PrintWorld", "PrintWorld"},
+	}, handled)
+}
+
+func TestUnlockOnShutdown(t *testing.T) {
+	server := camundatest.NewServer()
+	defer server.Close()
+
+	client := server.Client()
+	for i := 0; i < 3; i++ {
+		server.AddExternalTask(camundatest.ExternalTask{TopicName: "PrintHello"})
+	}
+	tasks, err := client.ExternalTask.FetchAndLock(camundaclientgo.QueryFetchAndLock{
+		WorkerId: "worker",
+		MaxTasks: 3,
+		Topics:   []*camundaclientgo.QueryFetchAndLockTopic{{TopicName: "PrintHello", LockDuration: 60000}},
+	})
+	require.NoError(t, err)
+	require.Len(t, tasks, 3)
+
+	proc := NewProcessor(client, &Options{WorkerId: "worker", UnlockOnShutdown: true}, func(err error) {})
+	sub := &subscription{limit: 1, tasks: make(chan fetchedTask, len(tasks))}
+	loop := &fetchLoop{subscriptions: []*subscription{sub}}
+	for _, task := range tasks {
+		sub.tasks <- fetchedTask{task: task, fetchCtx: context.Background()}
+	}
+	sub.inFlight, proc.inFlight = len(tasks), len(tasks)
+
+	proc.cancel()
+	proc.stopFetchLoop(loop)
+
+	for _, task := range server.ExternalTasks() {
+		assert.Empty(t, task.WorkerId, task.Id)
+		assert.True(t, task.LockExpirationTime.IsZero(), task.Id)
+	}
+	assert.Equal(t, 0, sub.inFlight)
+	assert.Equal(t, 0, proc.inFlight)
+	assert.Equal(t, ShutdownReport{Unlocked: 3}, proc.report)
+	_, open := <-sub.tasks
+	assert.False(t, open, "workers stop after the queued tasks")
+}
@@ -0,0 +1,79 @@
+package processor
+
+import (
+	"testing"
+	"time"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+	"github.com/citilinkru/camunda-client-go/v3/camundatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type lockObserver struct {
+	NopObserver
+	lost []string
+}
+
+func (o *lockObserver) OnLockLost(task *camundaclientgo.ResLockedExternalTask, _ error) {
+	o.lost = append(o.lost, task.Id)
+}
+
+func TestCheckLock(t *testing.T) {
+	server := camundatest.NewServer()
+	defer server.Close()
+
+	client := server.Client()
+	lockedByOther := server.AddExternalTask(camundatest.ExternalTask{
+		TopicName:          "PrintHello",
+		WorkerId:           "other-worker",
+		LockExpirationTime: time.Now().Add(time.Hour),
+	})
+	locked := server.AddExternalTask(camundatest.ExternalTask{
+		TopicName:          "PrintHello",
+		WorkerId:           "worker",
+		LockExpirationTime: time.Now().Add(time.Hour),
+	})
+
+	observer := &lockObserver{}
+	newContext := func(id string) *Context {
+		return &Context{
+			Task:     &camundaclientgo.ResLockedExternalTask{Id: id, WorkerId: "worker"},
+			client:   client,
+			observer: observer,
+		}
+	}
+
+	var apiErr *camundaclientgo.Error
+	err := newContext(lockedByOther.Id).Complete(QueryComplete{})
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, 400, apiErr.StatusCode)
+	assert.Equal(t, []string{lockedByOther.Id}, observer.lost)
+
+	err = newContext("unknown").ExtendLock(1000)
+	assert.ErrorIs(t, err, camundaclientgo.ErrorNotFound)
+	assert.Equal(t, []string{lockedByOther.Id, "unknown"}, observer.lost)
+
+	// the engine rejects a worker with InvalidRequestException, like invalid requests
+	ctx := newContext(locked.Id)
+	_ = ctx.checkLock(&camundaclientgo.Error{
+		Type:       "InvalidRequestException",
+		Message:    "External Task " + locked.Id + " cannot be failed by worker 'worker'. It is locked by worker 'null'.",
+		StatusCode: 400,
+	})
+	_ = ctx.checkLock(&camundaclientgo.Error{
+		Type:       "InvalidRequestException",
+		Message:    "Cannot extend a lock that expired: lockExpirationTime is before now",
+		StatusCode: 400,
+	})
+	assert.Equal(t, []string{lockedByOther.Id, "unknown", locked.Id, locked.Id}, observer.lost)
+
+	// other errors are not a lost lock, even though their messages mention the lock
+	observer.lost = nil
+	_ = ctx.checkLock(&camundaclientgo.Error{Type: "InvalidRequestException", Message: "Cannot deserialize variable lock", StatusCode: 400})
+	_ = ctx.checkLock(&camundaclientgo.Error{Type: "ProcessEngineException", Message: "Optimistic locking exception", StatusCode: 500})
+	assert.Empty(t, observer.lost)
+
+	require.NoError(t, ctx.Complete(QueryComplete{}))
+	server.AssertExternalTaskCompleted(t, locked.Id)
+}
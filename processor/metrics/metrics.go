@@ -0,0 +1,234 @@
+// Package metrics provides Prometheus metrics for the external task processor
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+	"github.com/citilinkru/camunda-client-go/v3/processor"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Options options for Observer
+type Options struct {
+	// namespace of metrics (default: `camunda`)
+	Namespace string
+	// subsystem of metrics (default: `processor`)
+	Subsystem string
+	// labels added to all metrics, e.g. a worker id
+	ConstLabels prometheus.Labels
+	// buckets of duration histograms in seconds (default: prometheus.DefBuckets)
+	Buckets []float64
+}
+
+// Observer a processor.Observer which collects Prometheus metrics.
+// Register it in a prometheus.Registerer and pass to processor.Options
+type Observer struct {
+	fetchRequests   prometheus.Counter
+	fetchErrors     prometheus.Counter
+	fetchDuration   prometheus.Histogram
+	tasksFetched    *prometheus.CounterVec
+	tasksStarted    *prometheus.CounterVec
+	handlerDuration *prometheus.HistogramVec
+	tasksCompleted  *prometheus.CounterVec
+	tasksFailed     *prometheus.CounterVec
+	bpmnErrors      *prometheus.CounterVec
+	lockExtensions  *prometheus.CounterVec
+	lockLost        *prometheus.CounterVec
+	panics          *prometheus.CounterVec
+	workers         *prometheus.Desc
+	idleWorkers     *prometheus.Desc
+	tasksInFlight   *prometheus.Desc
+	tasksQueued     *prometheus.Desc
+
+	// mu guards processorForStat, which is set by Watch and read by Collect on scrapes
+	mu               sync.Mutex
+	processorForStat func() processor.Stats
+}
+
+// NewObserver a create new instance Observer
+func NewObserver(options Options) *Observer {
+	if options.Namespace == "" {
+		options.Namespace = "camunda"
+	}
+	if options.Subsystem == "" {
+		options.Subsystem = "processor"
+	}
+	if options.Buckets == nil {
+		options.Buckets = prometheus.DefBuckets
+	}
+
+	counter := func(name, help string) prometheus.Counter {
+		return prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   options.Namespace,
+			Subsystem:   options.Subsystem,
+			Name:        name,
+			Help:        help,
+			ConstLabels: options.ConstLabels,
+		})
+	}
+	counterVec := func(name, help string, labels ...string) *prometheus.CounterVec {
+		return prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   options.Namespace,
+			Subsystem:   options.Subsystem,
+			Name:        name,
+			Help:        help,
+			ConstLabels: options.ConstLabels,
+		}, labels)
+	}
+	gauge := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(
+			prometheus.BuildFQName(options.Namespace, options.Subsystem, name),
+			help,
+			nil,
+			options.ConstLabels,
+		)
+	}
+
+	return &Observer{
+		fetchRequests: counter("fetch_requests_total", "Number of fetch and lock requests."),
+		fetchErrors:   counter("fetch_errors_total", "Number of failed fetch and lock requests."),
+		fetchDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace:   options.Namespace,
+			Subsystem:   options.Subsystem,
+			Name:        "fetch_duration_seconds",
+			Help:        "Duration of fetch and lock requests including long polling.",
+			ConstLabels: options.ConstLabels,
+			Buckets:     options.Buckets,
+		}),
+		tasksFetched: counterVec("tasks_fetched_total", "Number of fetched tasks.", "topic"),
+		tasksStarted: counterVec("tasks_started_total", "Number of started handlers.", "topic"),
+		handlerDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   options.Namespace,
+			Subsystem:   options.Subsystem,
+			Name:        "handler_duration_seconds",
+			Help:        "Duration from a handler start until a task result is reported.",
+			ConstLabels: options.ConstLabels,
+			Buckets:     options.Buckets,
+		}, []string{"topic", "result"}),
+		tasksCompleted: counterVec("tasks_completed_total", "Number of completed tasks.", "topic"),
+		tasksFailed:    counterVec("tasks_failed_total", "Number of reported task failures.", "topic"),
+		bpmnErrors:     counterVec("bpmn_errors_total", "Number of reported BPMN errors.", "topic", "error_code"),
+		lockExtensions: counterVec("lock_extensions_total", "Number of extended task locks.", "topic"),
+		lockLost:       counterVec("lock_lost_total", "Number of task results rejected because the lock was lost.", "topic"),
+		panics:         counterVec("panics_total", "Number of panics in handlers.", "topic"),
+		workers:        gauge("workers", "Number of started workers."),
+		idleWorkers:    gauge("idle_workers", "Number of workers waiting for a task."),
+		tasksInFlight:  gauge("tasks_in_flight", "Number of tasks processed by handlers right now."),
+		tasksQueued:    gauge("tasks_queued", "Number of fetched tasks waiting for a free worker."),
+	}
+}
+
+// Watch collect worker gauges of the processor
+func (o *Observer) Watch(p *processor.Processor) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.processorForStat = p.Stats
+}
+
+// Describe implements prometheus.Collector
+func (o *Observer) Describe(ch chan<- *prometheus.Desc) {
+	for _, c := range o.collectors() {
+		c.Describe(ch)
+	}
+
+	ch <- o.workers
+	ch <- o.idleWorkers
+	ch <- o.tasksInFlight
+	ch <- o.tasksQueued
+}
+
+// Collect implements prometheus.Collector
+func (o *Observer) Collect(ch chan<- prometheus.Metric) {
+	for _, c := range o.collectors() {
+		c.Collect(ch)
+	}
+
+	o.mu.Lock()
+	processorForStat := o.processorForStat
+	o.mu.Unlock()
+	if processorForStat == nil {
+		return
+	}
+
+	stats := processorForStat()
+	ch <- prometheus.MustNewConstMetric(o.workers, prometheus.GaugeValue, float64(stats.Workers))
+	ch <- prometheus.MustNewConstMetric(o.idleWorkers, prometheus.GaugeValue, float64(stats.Workers-stats.Busy))
+	ch <- prometheus.MustNewConstMetric(o.tasksInFlight, prometheus.GaugeValue, float64(stats.Busy))
+	ch <- prometheus.MustNewConstMetric(o.tasksQueued, prometheus.GaugeValue, float64(stats.Queued))
+}
+
+func (o *Observer) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		o.fetchRequests,
+		o.fetchErrors,
+		o.fetchDuration,
+		o.tasksFetched,
+		o.tasksStarted,
+		o.handlerDuration,
+		o.tasksCompleted,
+		o.tasksFailed,
+		o.bpmnErrors,
+		o.lockExtensions,
+		o.lockLost,
+		o.panics,
+	}
+}
+
+// OnFetch implements processor.Observer
+func (o *Observer) OnFetch(_ int, duration time.Duration) {
+	o.fetchRequests.Inc()
+	o.fetchDuration.Observe(duration.Seconds())
+}
+
+// OnFetchError implements processor.Observer
+func (o *Observer) OnFetchError(_ error, duration time.Duration) {
+	o.fetchRequests.Inc()
+	o.fetchErrors.Inc()
+	o.fetchDuration.Observe(duration.Seconds())
+}
+
+// OnTaskFetched implements processor.Observer
+func (o *Observer) OnTaskFetched(task *camundaclientgo.ResLockedExternalTask) {
+	o.tasksFetched.WithLabelValues(task.TopicName).Inc()
+}
+
+// OnTaskStart implements processor.Observer
+func (o *Observer) OnTaskStart(task *camundaclientgo.ResLockedExternalTask) {
+	o.tasksStarted.WithLabelValues(task.TopicName).Inc()
+}
+
+// OnTaskComplete implements processor.Observer
+func (o *Observer) OnTaskComplete(task *camundaclientgo.ResLockedExternalTask, duration time.Duration) {
+	o.tasksCompleted.WithLabelValues(task.TopicName).Inc()
+	o.handlerDuration.WithLabelValues(task.TopicName, "complete").Observe(duration.Seconds())
+}
+
+// OnTaskFailure implements processor.Observer
+func (o *Observer) OnTaskFailure(task *camundaclientgo.ResLockedExternalTask, _ error, duration time.Duration) {
+	o.tasksFailed.WithLabelValues(task.TopicName).Inc()
+	o.handlerDuration.WithLabelValues(task.TopicName, "failure").Observe(duration.Seconds())
+}
+
+// OnBPMNError implements processor.Observer
+func (o *Observer) OnBPMNError(task *camundaclientgo.ResLockedExternalTask, errorCode string, duration time.Duration) {
+	o.bpmnErrors.WithLabelValues(task.TopicName, errorCode).Inc()
+	o.handlerDuration.WithLabelValues(task.TopicName, "bpmn_error").Observe(duration.Seconds())
+}
+
+// OnLockExtended implements processor.Observer
+func (o *Observer) OnLockExtended(task *camundaclientgo.ResLockedExternalTask, _ time.Duration) {
+	o.lockExtensions.WithLabelValues(task.TopicName).Inc()
+}
+
+// OnPanic implements processor.Observer
+func (o *Observer) OnPanic(task *camundaclientgo.ResLockedExternalTask, _ interface{}, _ []byte) {
+	o.panics.WithLabelValues(task.TopicName).Inc()
+}
+
+// OnLockLost implements processor.Observer
+func (o *Observer) OnLockLost(task *camundaclientgo.ResLockedExternalTask, _ error) {
+	o.lockLost.WithLabelValues(task.TopicName).Inc()
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+	"github.com/citilinkru/camunda-client-go/v3/processor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserver(t *testing.T) {
+	observer := NewObserver(Options{ConstLabels: prometheus.Labels{"worker": "test"}})
+	registry := prometheus.NewRegistry()
+	assert.NoError(t, registry.Register(observer))
+
+	task := &camundaclientgo.ResLockedExternalTask{Id: "1", TopicName: "topic"}
+	observer.OnFetch(1, time.Second)
+	observer.OnFetchError(errors.New("fail"), time.Second)
+	observer.OnTaskFetched(task)
+	observer.OnTaskStart(task)
+	observer.OnLockExtended(task, time.Minute)
+	observer.OnTaskComplete(task, time.Second)
+	observer.OnTaskFailure(task, errors.New("fail"), time.Second)
+	observer.OnBPMNError(task, "code", time.Second)
+	observer.OnPanic(task, "boom", nil)
+	observer.OnLockLost(task, errors.New("lost"))
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(observer.fetchRequests))
+	assert.Equal(t, 1.0, testutil.ToFloat64(observer.fetchErrors))
+	assert.Equal(t, 1.0, testutil.ToFloat64(observer.tasksFetched.WithLabelValues("topic")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(observer.tasksCompleted.WithLabelValues("topic")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(observer.bpmnErrors.WithLabelValues("topic", "code")))
+	assert.Equal(t, 3, testutil.CollectAndCount(observer.handlerDuration))
+
+	expected := `
+# HELP camunda_processor_panics_total Number of panics in handlers.
+# TYPE camunda_processor_panics_total counter
+camunda_processor_panics_total{topic="topic",worker="test"} 1
+`
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected), "camunda_processor_panics_total"))
+}
+
+func TestObserverWatch(t *testing.T) {
+	observer := NewObserver(Options{})
+	assert.Equal(t, 0, testutil.CollectAndCount(observer, "camunda_processor_workers"))
+
+	observer.processorForStat = func() processor.Stats { return processor.Stats{Workers: 3, Busy: 1, Queued: 2} }
+	assert.Equal(t, 1, testutil.CollectAndCount(observer, "camunda_processor_workers"))
+	assert.Equal(t, 2.0, gaugeValue(t, observer, "camunda_processor_idle_workers"))
+	assert.Equal(t, 2.0, gaugeValue(t, observer, "camunda_processor_tasks_queued"))
+
+	// a scrape may run while the processor is watched
+	proc := processor.NewProcessor(nil, &processor.Options{}, func(err error) {})
+	watched := make(chan struct{})
+	go func() {
+		defer close(watched)
+		observer.Watch(proc)
+	}()
+	testutil.CollectAndCount(observer)
+	<-watched
+	assert.Equal(t, 0.0, gaugeValue(t, observer, "camunda_processor_workers"))
+}
+
+func gaugeValue(t *testing.T, c prometheus.Collector, name string) float64 {
+	registry := prometheus.NewPedanticRegistry()
+	assert.NoError(t, registry.Register(c))
+	families, err := registry.Gather()
+	assert.NoError(t, err)
+	for _, f := range families {
+		if f.GetName() == name {
+			return f.GetMetric()[0].GetGauge().GetValue()
+		}
+	}
+
+	t.Fatalf("metric %s not found", name)
+	return 0
+}
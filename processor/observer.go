@@ -0,0 +1,67 @@
+package processor
+
+import (
+	"time"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+)
+
+// Observer receives events of a Processor, e.g. to collect metrics or write structured logs.
+// Methods are called concurrently from workers and must not block.
+// Embed NopObserver to implement only the needed methods
+type Observer interface {
+	// OnFetch is called after a successful fetch and lock request
+	OnFetch(count int, duration time.Duration)
+	// OnFetchError is called after a failed fetch and lock request
+	OnFetchError(err error, duration time.Duration)
+	// OnTaskFetched is called for each fetched task before it is queued for a handler
+	OnTaskFetched(task *camundaclientgo.ResLockedExternalTask)
+	// OnTaskStart is called before a handler is started
+	OnTaskStart(task *camundaclientgo.ResLockedExternalTask)
+	// OnTaskComplete is called when a task was completed, duration is counted from the handler start
+	OnTaskComplete(task *camundaclientgo.ResLockedExternalTask, duration time.Duration)
+	// OnTaskFailure is called when a failure of a task was reported, duration is counted from the handler start
+	OnTaskFailure(task *camundaclientgo.ResLockedExternalTask, err error, duration time.Duration)
+	// OnBPMNError is called when a BPMN error of a task was reported, duration is counted from the handler start
+	OnBPMNError(task *camundaclientgo.ResLockedExternalTask, errorCode string, duration time.Duration)
+	// OnLockExtended is called when a lock of a task was extended
+	OnLockExtended(task *camundaclientgo.ResLockedExternalTask, newDuration time.Duration)
+	// OnPanic is called when a handler panics
+	OnPanic(task *camundaclientgo.ResLockedExternalTask, recovered interface{}, stack []byte)
+	// OnLockLost is called when the engine rejects a request of a handler because the task is not locked
+	// by the worker anymore, e.g. the lock has expired and the task was fetched by another worker
+	OnLockLost(task *camundaclientgo.ResLockedExternalTask, err error)
+}
+
+// NopObserver an Observer which ignores all events
+type NopObserver struct{}
+
+// OnFetch does nothing
+func (NopObserver) OnFetch(int, time.Duration) {}
+
+// OnFetchError does nothing
+func (NopObserver) OnFetchError(error, time.Duration) {}
+
+// OnTaskFetched does nothing
+func (NopObserver) OnTaskFetched(*camundaclientgo.ResLockedExternalTask) {}
+
+// OnTaskStart does nothing
+func (NopObserver) OnTaskStart(*camundaclientgo.ResLockedExternalTask) {}
+
+// OnTaskComplete does nothing
+func (NopObserver) OnTaskComplete(*camundaclientgo.ResLockedExternalTask, time.Duration) {}
+
+// OnTaskFailure does nothing
+func (NopObserver) OnTaskFailure(*camundaclientgo.ResLockedExternalTask, error, time.Duration) {}
+
+// OnBPMNError does nothing
+func (NopObserver) OnBPMNError(*camundaclientgo.ResLockedExternalTask, string, time.Duration) {}
+
+// OnLockExtended does nothing
+func (NopObserver) OnLockExtended(*camundaclientgo.ResLockedExternalTask, time.Duration) {}
+
+// OnPanic does nothing
+func (NopObserver) OnPanic(*camundaclientgo.ResLockedExternalTask, interface{}, []byte) {}
+
+// OnLockLost does nothing
+func (NopObserver) OnLockLost(*camundaclientgo.ResLockedExternalTask, error) {}
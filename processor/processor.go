@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"net/http"
 	"runtime/debug"
+	"strings"
 	"sync"
 	"time"
 
@@ -14,14 +16,31 @@ import (
 
 // Processor external task processor
 type Processor struct {
-	client  *camundaclientgo.Client
-	options *Options
-	logger  func(err error)
+	client   *camundaclientgo.Client
+	options  *Options
+	logger   func(err error)
+	observer Observer
+	backoff  Backoff
+	tracer   Tracer
 
 	// shutdown support
 	workerGroup *sync.WaitGroup
 	ctx         context.Context
 	cancel      context.CancelFunc
+	taskCtx     context.Context
+	cancelTasks context.CancelFunc
+
+	// fetch loops and worker slots, guarded by mu
+	mu         sync.Mutex
+	workers    int
+	running    int
+	inFlight   int
+	loops      []*fetchLoop
+	singleLoop *fetchLoop
+	draining   bool
+	report     ShutdownReport
+	// the report was returned by ShutdownContext, so it is not updated anymore
+	reported bool
 }
 
 // Options options for Processor
@@ -42,6 +61,29 @@ type Options struct {
 	AsyncResponseTimeout *int
 	// long polling timeout
 	LongPollingTimeout time.Duration
+	// fetch tasks of all handlers with a single request instead of a request per handler.
+	// Tasks are dispatched to handlers by topic name, so a topic can be subscribed by one handler only.
+	// Topics of a handler added during a long polling request are fetched by the next request
+	SingleFetchLoop bool
+	// maximum running parallel tasks of all handlers (default: unlimited)
+	MaxParallelTasks int
+	// unlock fetched tasks which were not started before shutdown,
+	// so they become available to other workers immediately instead of after lock expiration
+	UnlockOnShutdown bool
+	// delay strategy for retrying failed fetch requests (default: plus a second after each failure up to a minute)
+	Backoff Backoff
+	// receiver of processor events, e.g. for metrics
+	Observer Observer
+	// starts spans of fetch requests and handlers, e.g. for OpenTelemetry
+	Tracer Tracer
+	// return a *ValidationError from Validate instead of passing it to the logger
+	StrictValidation bool
+}
+
+// HandlerOptions options for a single handler
+type HandlerOptions struct {
+	// maximum running parallel tasks of the handler (default: Options.MaxParallelTaskPerHandler)
+	MaxParallelTasks int
 }
 
 // NewProcessor a create new instance Processor
@@ -52,16 +94,38 @@ func NewProcessor(client *camundaclientgo.Client, options *Options, logger func(
 		options.WorkerId = fmt.Sprintf("worker-%d", rand.Int())
 	}
 
+	var observer Observer = NopObserver{}
+	if options.Observer != nil {
+		observer = options.Observer
+	}
+
+	backoff := defaultBackoff
+	if options.Backoff != nil {
+		backoff = options.Backoff
+	}
+
+	var tracer Tracer = NopTracer{}
+	if options.Tracer != nil {
+		tracer = options.Tracer
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
+	taskCtx, cancelTasks := context.WithCancel(context.Background())
 	workerGroup := new(sync.WaitGroup)
 
 	return &Processor{
 		client:      client,
 		options:     options,
 		logger:      logger,
+		observer:    observer,
+		backoff:     backoff,
+		tracer:      tracer,
 		workerGroup: workerGroup,
 		ctx:         ctx,
-		cancel:      cancel}
+		cancel:      cancel,
+		taskCtx:     taskCtx,
+		cancelTasks: cancelTasks,
+	}
 }
 
 // Handler a handler for external task
@@ -69,46 +133,184 @@ type Handler func(ctx *Context) error
 
 // Context external task context
 type Context struct {
-	Task   *camundaclientgo.ResLockedExternalTask
-	client *camundaclientgo.Client
+	Task     *camundaclientgo.ResLockedExternalTask
+	client   *camundaclientgo.Client
+	ctx      context.Context
+	observer Observer
+	started  time.Time
+}
+
+// Context returns a context of the task processing.
+// It is cancelled when the deadline of ShutdownContext is exceeded
+func (c *Context) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+
+	return c.ctx
+}
+
+// DecodeVariables stores variables of the task in fields of a struct with the `camunda:"name"` tag,
+// see camundaclientgo.DecodeVariables
+func (c *Context) DecodeVariables(dst interface{}) error {
+	return camundaclientgo.DecodeVariables(c.Task.Variables, dst)
 }
 
 // Complete a mark external task is complete
 func (c *Context) Complete(query QueryComplete) error {
-	return c.client.ExternalTask.Complete(c.Task.Id, camundaclientgo.QueryComplete{
+	err := c.client.ExternalTask.Complete(c.Task.Id, camundaclientgo.QueryComplete{
 		WorkerId:       &c.Task.WorkerId,
 		Variables:      query.Variables,
 		LocalVariables: query.LocalVariables,
 	})
+	if err == nil {
+		c.getObserver().OnTaskComplete(c.Task, time.Since(c.started))
+	}
+
+	return c.checkLock(err)
+}
+
+// CompleteWithBinaryVariables a mark external task is complete with Bytes and File variables,
+// which content is streamed without buffering
+func (c *Context) CompleteWithBinaryVariables(query QueryComplete, variables, localVariables map[string]camundaclientgo.ReqBinaryVariable) error {
+	err := c.client.ExternalTask.CompleteWithBinaryVariables(c.Task.Id, camundaclientgo.QueryComplete{
+		WorkerId:       &c.Task.WorkerId,
+		Variables:      query.Variables,
+		LocalVariables: query.LocalVariables,
+	}, variables, localVariables)
+	if err == nil {
+		c.getObserver().OnTaskComplete(c.Task, time.Since(c.started))
+	}
+
+	return c.checkLock(err)
 }
 
 // Extend the lock for a new duration
 func (c *Context) ExtendLock(newDurationMS int) error {
-	return c.client.ExternalTask.ExtendLock(c.Task.Id, camundaclientgo.QueryExtendLock{
+	err := c.client.ExternalTask.ExtendLock(c.Task.Id, camundaclientgo.QueryExtendLock{
 		NewDuration: &newDurationMS,
 		WorkerId:    &c.Task.WorkerId,
 	})
+	if err == nil {
+		c.getObserver().OnLockExtended(c.Task, time.Duration(newDurationMS)*time.Millisecond)
+	}
+
+	return c.checkLock(err)
 }
 
 // HandleBPMNError handle external task BPMN error
 func (c *Context) HandleBPMNError(query QueryHandleBPMNError) error {
-	return c.client.ExternalTask.HandleBPMNError(c.Task.Id, camundaclientgo.QueryHandleBPMNError{
+	err := c.client.ExternalTask.HandleBPMNError(c.Task.Id, camundaclientgo.QueryHandleBPMNError{
 		WorkerId:     &c.Task.WorkerId,
 		ErrorCode:    query.ErrorCode,
 		ErrorMessage: query.ErrorMessage,
 		Variables:    query.Variables,
 	})
+	if err == nil {
+		errorCode := ""
+		if query.ErrorCode != nil {
+			errorCode = *query.ErrorCode
+		}
+		c.getObserver().OnBPMNError(c.Task, errorCode, time.Since(c.started))
+	}
+
+	return c.checkLock(err)
 }
 
 // HandleFailure handle external task failure
 func (c *Context) HandleFailure(query QueryHandleFailure) error {
-	return c.client.ExternalTask.HandleFailure(c.Task.Id, camundaclientgo.QueryHandleFailure{
+	err := c.client.ExternalTask.HandleFailure(c.Task.Id, camundaclientgo.QueryHandleFailure{
 		WorkerId:     &c.Task.WorkerId,
 		ErrorMessage: query.ErrorMessage,
 		ErrorDetails: query.ErrorDetails,
 		Retries:      query.Retries,
 		RetryTimeout: query.RetryTimeout,
 	})
+	if err == nil {
+		errorMessage := ""
+		if query.ErrorMessage != nil {
+			errorMessage = *query.ErrorMessage
+		}
+		c.getObserver().OnTaskFailure(c.Task, errors.New(errorMessage), time.Since(c.started))
+	}
+
+	return c.checkLock(err)
+}
+
+// lostLockMessages parts of messages of the engine rejecting a worker which does not hold the lock of a task
+// with 400 Bad Request. Other errors of the same status and type, e.g. of invalid variables, are not a lost lock
+var lostLockMessages = []string{
+	// complete, failure, bpmnError or extendLock of a task locked by another worker or not locked at all:
+	// "External Task <id> cannot be completed by worker '<worker>'. It is locked by worker '<other worker>'."
+	"It is locked by worker '",
+	// extendLock after the lock expired
+	"Cannot extend a lock that expired",
+}
+
+// checkLock notify the observer if err means the task is not locked by the worker anymore: the task does not
+// exist (404) or the engine rejected the worker (400) because the most recent lock of the task was not acquired by it
+func (c *Context) checkLock(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var apiErr *camundaclientgo.Error
+	if errors.Is(err, camundaclientgo.ErrorNotFound) ||
+		(errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest && isLostLockMessage(apiErr.Message)) {
+		c.getObserver().OnLockLost(c.Task, err)
+	}
+
+	return err
+}
+
+func isLostLockMessage(message string) bool {
+	for _, m := range lostLockMessages {
+		if strings.Contains(message, m) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Context) getObserver() Observer {
+	if c.observer == nil {
+		return NopObserver{}
+	}
+
+	return c.observer
+}
+
+// Stats a current state of processor workers
+type Stats struct {
+	// number of started workers of all handlers
+	Workers int
+	// number of workers running a handler
+	Busy int
+	// number of fetched tasks waiting for a free worker
+	Queued int
+}
+
+// Stats returns a current state of processor workers
+func (p *Processor) Stats() Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return Stats{
+		Workers: p.workers,
+		Busy:    p.running,
+		Queued:  p.inFlight - p.running,
+	}
+}
+
+// ShutdownReport a result of ShutdownContext
+type ShutdownReport struct {
+	// number of tasks processed by handlers after the shutdown was started
+	Completed int
+	// number of fetched tasks which were unlocked without processing
+	Unlocked int
+	// number of tasks which were still processing or locked when the deadline was exceeded
+	Abandoned int
 }
 
 // Shutdown stop this processor and wait for running handlers to complete in-flight processing.
@@ -118,8 +320,51 @@ func (p *Processor) Shutdown() {
 	p.workerGroup.Wait()
 }
 
+// ShutdownContext stop this processor gracefully: abort in-flight fetches, unlock fetched tasks which were not started
+// and wait for running handlers until ctx is done. If ctx is done first, contexts of running handlers are cancelled
+// and ctx.Err() is returned along with the report. Tasks of the cancelled handlers are unlocked instead of reported
+// as failures when their handlers return an error. The Processor cannot be reused after shutdown.
+func (p *Processor) ShutdownContext(ctx context.Context) (*ShutdownReport, error) {
+	p.mu.Lock()
+	p.draining = true
+	p.mu.Unlock()
+	p.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		p.workerGroup.Wait()
+		close(done)
+	}()
+
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+		p.cancelTasks()
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	report := p.report
+	if err != nil {
+		report.Abandoned += p.inFlight
+	}
+	p.reported = true
+
+	return &report, err
+}
+
 // AddHandler register an external task handler and start pulling for work. Calling this after a Shutdown has no effect.
 func (p *Processor) AddHandler(topics []*camundaclientgo.QueryFetchAndLockTopic, handler Handler) {
+	p.AddHandlerWithOptions(topics, handler, HandlerOptions{})
+}
+
+// AddHandlerWithOptions register an external task handler with its own options and start pulling for work.
+// With SingleFetchLoop a topic must be subscribed by one handler only, it panics otherwise.
+// Calling this after a Shutdown has no effect.
+func (p *Processor) AddHandlerWithOptions(topics []*camundaclientgo.QueryFetchAndLockTopic, handler Handler, options HandlerOptions) {
 	if topics != nil && p.options.LockDuration != 0 {
 		for _, v := range topics {
 			if v.LockDuration <= 0 {
@@ -128,79 +373,87 @@ func (p *Processor) AddHandler(topics []*camundaclientgo.QueryFetchAndLockTopic,
 		}
 	}
 
-	var asyncResponseTimeout *int
-	if p.options.AsyncResponseTimeout != nil {
-		asyncResponseTimeout = p.options.AsyncResponseTimeout
-	} else if p.options.LongPollingTimeout.Nanoseconds() > 0 {
-		msValue := int(p.options.LongPollingTimeout.Nanoseconds() / int64(time.Millisecond))
-		asyncResponseTimeout = &msValue
+	maxParallelTasks := options.MaxParallelTasks
+	if maxParallelTasks < 1 {
+		maxParallelTasks = p.options.MaxParallelTaskPerHandler
+	}
+	if maxParallelTasks < 1 {
+		maxParallelTasks = 1
 	}
 
-	p.startPuller(camundaclientgo.QueryFetchAndLock{
-		WorkerId:             p.options.WorkerId,
-		MaxTasks:             p.options.MaxTasks,
-		UsePriority:          p.options.UsePriority,
-		AsyncResponseTimeout: asyncResponseTimeout,
-		Topics:               topics,
-	}, handler)
+	p.subscribe(topics, handler, maxParallelTasks)
 }
 
-func (p *Processor) startPuller(query camundaclientgo.QueryFetchAndLock, handler Handler) {
-	var tasksChan = make(chan *camundaclientgo.ResLockedExternalTask)
+func (p *Processor) runWorker(sub *subscription) {
+	defer p.workerGroup.Done()
+	for fetched := range sub.tasks {
+		if p.ctx.Err() != nil && p.unlockQueued() {
+			p.unlock(sub, fetched.task)
+			continue
+		}
 
-	maxParallelTaskPerHandler := p.options.MaxParallelTaskPerHandler
-	if maxParallelTaskPerHandler < 1 {
-		maxParallelTaskPerHandler = 1
-	}
+		p.mu.Lock()
+		p.running++
+		p.mu.Unlock()
 
-	// create worker pool
-	for i := 0; i < maxParallelTaskPerHandler; i++ {
-		p.workerGroup.Add(1)
-		go p.runWorker(handler, tasksChan)
-	}
+		ctx, end := p.tracer.StartTask(p.taskCtx, fetched.fetchCtx, fetched.task)
+		abandoned := p.handle(&Context{
+			Task:     fetched.task,
+			client:   p.client.WithContext(valueContext{ctx}),
+			ctx:      ctx,
+			observer: p.observer,
+			started:  time.Now(),
+		}, sub.handler, end)
 
-	go func() {
-		retries := 0
-		for {
-			select {
-			case <-p.ctx.Done():
-				close(tasksChan)
-				return
-			default:
-				tasks, err := p.client.ExternalTask.FetchAndLock(query)
-				if err != nil {
-					if retries < 60 {
-						retries += 1
-					}
-					p.logger(fmt.Errorf("failed pull: %w, sleeping: %d seconds", err, retries))
-					time.Sleep(time.Duration(retries) * time.Second)
-					continue
-				}
-				retries = 0
-
-				for _, task := range tasks {
-					tasksChan <- task
-				}
-			}
+		p.mu.Lock()
+		p.running--
+		p.mu.Unlock()
+
+		var counter *int
+		if abandoned {
+			counter = &p.report.Abandoned
+		} else if p.ctx.Err() != nil {
+			counter = &p.report.Completed
 		}
-	}()
+		p.release(sub, counter)
+	}
 }
 
-func (p *Processor) runWorker(handler Handler, tasksChan chan *camundaclientgo.ResLockedExternalTask) {
-	defer p.workerGroup.Done()
-	for task := range tasksChan {
-		p.handle(&Context{
-			Task:   task,
-			client: p.client,
-		}, handler)
+// unlockQueued returns true if fetched tasks should be unlocked instead of processing after shutdown
+func (p *Processor) unlockQueued() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.options.UnlockOnShutdown || p.draining
+}
+
+// unlock make a fetched task available to other workers and release its slot
+func (p *Processor) unlock(sub *subscription, task *camundaclientgo.ResLockedExternalTask) {
+	if err := p.client.ExternalTask.Unlock(task.Id); err != nil {
+		p.logger(fmt.Errorf("error unlock task %s: %w", task.Id, err))
+		p.release(sub, &p.report.Abandoned)
+		return
 	}
+
+	p.release(sub, &p.report.Unlocked)
 }
 
-func (p *Processor) handle(ctx *Context, handler Handler) {
+// handle run the handler and report its error or panic as a failure of the task. It returns true if the handler
+// was cancelled by the shutdown deadline: the task is unlocked instead, so it does not lose a retry
+func (p *Processor) handle(ctx *Context, handler Handler, end func(err error)) (abandoned bool) {
 	defer func() {
 		if r := recover(); r != nil {
+			stack := debug.Stack()
+			p.observer.OnPanic(ctx.Task, r, stack)
+			end(fmt.Errorf("panic: %v", r))
+			if p.taskCtx.Err() != nil {
+				abandoned = true
+				p.abandon(ctx.Task)
+				return
+			}
+
 			errMessage := fmt.Sprintf("fatal error in task: %s", r)
-			errDetails := fmt.Sprintf("fatal error in task: %s\nStack trace: %s", r, string(debug.Stack()))
+			errDetails := fmt.Sprintf("fatal error in task: %s\nStack trace: %s", r, string(stack))
 			err := ctx.HandleFailure(QueryHandleFailure{
 				ErrorMessage: &errMessage,
 				ErrorDetails: &errDetails,
@@ -214,7 +467,14 @@ func (p *Processor) handle(ctx *Context, handler Handler) {
 		}
 	}()
 
+	p.observer.OnTaskStart(ctx.Task)
 	err := handler(ctx)
+	end(err)
+	if err != nil && p.taskCtx.Err() != nil {
+		p.abandon(ctx.Task)
+		return true
+	}
+
 	if err != nil {
 		errMessage := fmt.Sprintf("task error: %s", err)
 		err = ctx.HandleFailure(QueryHandleFailure{
@@ -227,4 +487,13 @@ func (p *Processor) handle(ctx *Context, handler Handler) {
 
 		p.logger(errors.New(errMessage))
 	}
+
+	return false
+}
+
+// abandon unlock a task of a handler cancelled by the shutdown deadline
+func (p *Processor) abandon(task *camundaclientgo.ResLockedExternalTask) {
+	if err := p.client.ExternalTask.Unlock(task.Id); err != nil {
+		p.logger(fmt.Errorf("error unlock task %s: %w", task.Id, err))
+	}
 }
@@ -5,33 +5,79 @@ package processor
 
 import (
 	"fmt"
-	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
-	"github.com/stretchr/testify/assert"
 	"os"
 	"testing"
 	"time"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+	"github.com/citilinkru/camunda-client-go/v3/camundatest/fixture"
+	"github.com/stretchr/testify/assert"
 )
 
+// fixtureFile a golden file of interactions of integration tests with the engine
+const fixtureFile = "testdata/fixtures/integration.json"
+
 var client *camundaclientgo.Client
-var logger func(err error)
+var recorder *fixture.Recorder
+
+func logger(err error) {
+	fmt.Println(err.Error())
+}
+
+func TestMain(m *testing.M) {
+	if err := setup(); err != nil {
+		fmt.Fprintf(os.Stderr, "setup integration tests: %s\n", err)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	if recorder != nil {
+		if err := recorder.Save(); err != nil {
+			fmt.Fprintf(os.Stderr, "save fixture: %s\n", err)
+			code = 1
+		}
+	}
+
+	os.Exit(code)
+}
+
+// setup creates the client of the engine at $CAMUNDA_ENDPOINT or localhost:8080 and deploys the test process.
+// CAMUNDA_FIXTURES is "record" to record interactions with the engine to fixtureFile and "replay" to serve them
+// from fixtureFile without the engine
+func setup() error {
+	endpoint := os.Getenv("CAMUNDA_ENDPOINT")
+	if endpoint == "" {
+		endpoint = "http://localhost:8080/engine-rest"
+	}
 
-func init() {
 	client = camundaclientgo.NewClient(camundaclientgo.ClientOptions{
-		EndpointUrl: "http://localhost:8080/engine-rest",
+		EndpointUrl: endpoint,
 		ApiUser:     "demo",
 		ApiPassword: "demo",
 		Timeout:     time.Second * 10,
 	})
 
-	logger = func(err error) {
-		fmt.Println(err.Error())
+	switch mode := os.Getenv("CAMUNDA_FIXTURES"); mode {
+	case "record":
+		recorder = fixture.NewRecorder(fixtureFile, nil, fixture.Options{})
+		client.SetCustomTransport(recorder)
+	case "replay":
+		replayer, err := fixture.NewReplayer(fixtureFile, fixture.Options{})
+		if err != nil {
+			return fmt.Errorf("load fixture: %w", err)
+		}
+		client.SetCustomTransport(replayer)
+	case "":
+	default:
+		return fmt.Errorf("unknown CAMUNDA_FIXTURES %q, must be record or replay", mode)
 	}
 
 	file, err := os.Open("../examples/deployment/HelloWorld.bpmn")
 	if err != nil {
-		fmt.Printf("Error read file: %s\n", err)
-		os.Exit(1)
+		return err
 	}
+	defer file.Close()
+
 	_, err = client.Deployment.Create(camundaclientgo.ReqDeploymentCreate{
 		DeploymentName: "HelloWorldProcessDemo",
 		Resources: map[string]interface{}{
@@ -39,9 +85,10 @@ func init() {
 		},
 	})
 	if err != nil {
-		fmt.Printf("Error deploy process: %s\n", err)
-		os.Exit(1)
+		return fmt.Errorf("deploy process: %w", err)
 	}
+
+	return nil
 }
 
 func TestComplete(t *testing.T) {
@@ -52,6 +99,7 @@ func TestComplete(t *testing.T) {
 		MaxParallelTaskPerHandler: 100,
 		LongPollingTimeout:        5 * time.Second,
 	}, logger)
+	defer proc.Shutdown()
 
 	processKey := "hello-world-process"
 
@@ -85,3 +133,52 @@ func TestComplete(t *testing.T) {
 		t.Error("Handler timeout")
 	}
 }
+
+func TestCompleteSingleFetchLoop(t *testing.T) {
+	proc := NewProcessor(client, &Options{
+		WorkerId:                  "hello-world-single-loop-worker",
+		LockDuration:              time.Second * 5,
+		MaxTasks:                  10,
+		MaxParallelTaskPerHandler: 10,
+		MaxParallelTasks:          15,
+		LongPollingTimeout:        5 * time.Second,
+		SingleFetchLoop:           true,
+	}, logger)
+	defer proc.Shutdown()
+
+	processKey := "hello-world-process"
+	variables := map[string]camundaclientgo.Variable{
+		"isWorld": {Value: true, Type: "boolean"},
+	}
+
+	_, err := client.ProcessDefinition.StartInstance(
+		camundaclientgo.QueryProcessDefinitionBy{Key: &processKey},
+		camundaclientgo.ReqStartInstance{Variables: &variables},
+	)
+	assert.NoError(t, err)
+
+	done := make(chan string, 10)
+	handler := func(ctx *Context) error {
+		err := ctx.Complete(QueryComplete{})
+		assert.NoError(t, err)
+		done <- ctx.Task.TopicName
+		return err
+	}
+	proc.AddHandler([]*camundaclientgo.QueryFetchAndLockTopic{{TopicName: "PrintHello"}}, handler)
+	proc.AddHandlerWithOptions(
+		[]*camundaclientgo.QueryFetchAndLockTopic{{TopicName: "PrintWorld"}},
+		handler,
+		HandlerOptions{MaxParallelTasks: 1},
+	)
+
+	for {
+		select {
+		case topic := <-done:
+			if topic == "PrintWorld" {
+				return
+			}
+		case <-time.After(time.Second * 10):
+			t.Fatal("Handler timeout")
+		}
+	}
+}
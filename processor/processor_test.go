@@ -0,0 +1,150 @@
+package processor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShutdownContext(t *testing.T) {
+	var fetches, unlocks, failures int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/fetchAndLock"):
+			if atomic.AddInt32(&fetches, 1) > 1 {
+				// long polling without new tasks
+				<-r.Context().Done()
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode([]camundaclientgo.ResLockedExternalTask{
+				{Id: "1", TopicName: "PrintHello"},
+				{Id: "2", TopicName: "PrintHello"},
+				{Id: "3", TopicName: "PrintHello"},
+			})
+		case strings.HasSuffix(r.URL.Path, "/unlock"):
+			atomic.AddInt32(&unlocks, 1)
+			w.WriteHeader(http.StatusNoContent)
+		case strings.HasSuffix(r.URL.Path, "/failure"):
+			atomic.AddInt32(&failures, 1)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := camundaclientgo.NewClient(camundaclientgo.ClientOptions{EndpointUrl: server.URL})
+	proc := NewProcessor(client, &Options{
+		MaxTasks:                  3,
+		MaxParallelTaskPerHandler: 1,
+		LongPollingTimeout:        time.Minute,
+		SingleFetchLoop:           true,
+	}, func(err error) {})
+
+	started := make(chan struct{})
+	var once sync.Once
+	proc.AddHandler([]*camundaclientgo.QueryFetchAndLockTopic{{TopicName: "PrintHello"}}, func(ctx *Context) error {
+		once.Do(func() { close(started) })
+		<-ctx.Context().Done()
+		return ctx.Context().Err()
+	})
+
+	select {
+	case <-started:
+	case <-time.After(time.Second * 5):
+		t.Fatal("Handler timeout")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*200)
+	defer cancel()
+	report, err := proc.ShutdownContext(ctx)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, &ShutdownReport{Unlocked: 2, Abandoned: 1}, report)
+
+	// the cancelled handler does not report a failure, its task is unlocked without changing the returned report
+	proc.Shutdown()
+	assert.Equal(t, int32(3), atomic.LoadInt32(&unlocks))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&failures))
+	assert.Equal(t, ShutdownReport{Unlocked: 2}, proc.report)
+}
+
+type recordingObserver struct {
+	NopObserver
+	mu     sync.Mutex
+	events []string
+}
+
+func (o *recordingObserver) record(event string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = append(o.events, event)
+}
+
+func (o *recordingObserver) OnFetch(count int, _ time.Duration) {
+	if count > 0 {
+		o.record("fetch")
+	}
+}
+
+func (o *recordingObserver) OnTaskStart(task *camundaclientgo.ResLockedExternalTask) {
+	o.record("start " + task.Id)
+}
+
+func (o *recordingObserver) OnTaskFailure(task *camundaclientgo.ResLockedExternalTask, err error, _ time.Duration) {
+	o.record("failure " + task.Id + ": " + err.Error())
+}
+
+func (o *recordingObserver) OnPanic(task *camundaclientgo.ResLockedExternalTask, recovered interface{}, _ []byte) {
+	o.record("panic " + task.Id)
+}
+
+func TestObserver(t *testing.T) {
+	var fetches int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/fetchAndLock") {
+			w.Header().Set("Content-Type", "application/json")
+			tasks := []camundaclientgo.ResLockedExternalTask{}
+			if atomic.AddInt32(&fetches, 1) == 1 {
+				tasks = append(tasks, camundaclientgo.ResLockedExternalTask{Id: "1", TopicName: "PrintHello"})
+			}
+			_ = json.NewEncoder(w).Encode(tasks)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	observer := &recordingObserver{}
+	client := camundaclientgo.NewClient(camundaclientgo.ClientOptions{EndpointUrl: server.URL})
+	proc := NewProcessor(client, &Options{MaxTasks: 1, Observer: observer}, func(err error) {})
+
+	done := make(chan struct{})
+	proc.AddHandler([]*camundaclientgo.QueryFetchAndLockTopic{{TopicName: "PrintHello"}}, func(ctx *Context) error {
+		defer close(done)
+		panic("boom")
+	})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 5):
+		t.Fatal("Handler timeout")
+	}
+	proc.Shutdown()
+
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+	assert.Equal(t, []string{"fetch", "start 1", "panic 1", "failure 1: fatal error in task: boom"}, observer.events[:4])
+}
@@ -0,0 +1,51 @@
+package processor
+
+import (
+	"context"
+	"time"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+)
+
+// Tracer starts spans of a Processor, e.g. with OpenTelemetry.
+// Methods are called concurrently from workers and must not block
+type Tracer interface {
+	// StartFetch is called before a fetch and lock request. The returned context is used for the request,
+	// end is called with the number of fetched tasks or an error of the request
+	StartFetch(ctx context.Context) (fetchCtx context.Context, end func(count int, err error))
+	// StartTask is called before a handler is started. fetchCtx is the context returned by StartFetch
+	// for the request which fetched the task. The returned context is available with Context.Context()
+	// and is used for requests of the task, end is called with an error of the handler
+	StartTask(ctx, fetchCtx context.Context, task *camundaclientgo.ResLockedExternalTask) (taskCtx context.Context, end func(err error))
+}
+
+// NopTracer a Tracer which does not start any spans
+type NopTracer struct{}
+
+// StartFetch returns ctx as is
+func (NopTracer) StartFetch(ctx context.Context) (context.Context, func(count int, err error)) {
+	return ctx, func(int, error) {}
+}
+
+// StartTask returns ctx as is
+func (NopTracer) StartTask(ctx, _ context.Context, _ *camundaclientgo.ResLockedExternalTask) (context.Context, func(err error)) {
+	return ctx, func(error) {}
+}
+
+// valueContext keeps values of a context, e.g. a span, without its cancellation,
+// so results of a task are reported even after the task context was cancelled
+type valueContext struct {
+	context.Context
+}
+
+func (valueContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (valueContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (valueContext) Err() error {
+	return nil
+}
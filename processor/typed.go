@@ -0,0 +1,79 @@
+package processor
+
+import (
+	"errors"
+	"fmt"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+)
+
+// TypedHandler a handler of external tasks with variables decoded into In, variables of Out complete the task
+type TypedHandler[In, Out any] func(ctx *Context, in In) (Out, error)
+
+// BPMNError an error of a TypedHandler which is reported as a BPMN error instead of a failure
+type BPMNError struct {
+	// An error code that indicates the predefined error. Is used to identify the BPMN error handler
+	Code string
+	// An error message that describes the error
+	Message string
+	// Variables which will be passed to the execution
+	Variables map[string]camundaclientgo.Variable
+}
+
+// Error error message
+func (e *BPMNError) Error() string {
+	return fmt.Sprintf("bpmn error %s: %s", e.Code, e.Message)
+}
+
+// AddTypedHandler register a handler with variables of In and Out structs, see camundaclientgo.DecodeVariables.
+// Topics without a list of variables fetch only variables of In. The task is completed with variables of Out
+// when the handler returns no error, a *BPMNError is reported as a BPMN error, other errors as a failure
+func AddTypedHandler[In, Out any](p *Processor, topics []*camundaclientgo.QueryFetchAndLockTopic, handler TypedHandler[In, Out]) {
+	AddTypedHandlerWithOptions(p, topics, handler, HandlerOptions{})
+}
+
+// AddTypedHandlerWithOptions register a typed handler with its own options, see AddTypedHandler
+func AddTypedHandlerWithOptions[In, Out any](p *Processor, topics []*camundaclientgo.QueryFetchAndLockTopic, handler TypedHandler[In, Out], options HandlerOptions) {
+	variables := camundaclientgo.VariableNames(new(In))
+	if variables == nil {
+		// In has no camunda fields, so no variables are needed, while nil would fetch them all
+		variables = []string{}
+	}
+	for _, topic := range topics {
+		if topic.Variables == nil {
+			topic.Variables = variables
+		}
+	}
+
+	p.AddHandlerWithOptions(topics, func(ctx *Context) error {
+		var in In
+		if err := ctx.DecodeVariables(&in); err != nil {
+			return fmt.Errorf("decode variables: %w", err)
+		}
+
+		out, err := handler(ctx, in)
+		var bpmnErr *BPMNError
+		if errors.As(err, &bpmnErr) {
+			query := QueryHandleBPMNError{
+				ErrorCode:    &bpmnErr.Code,
+				ErrorMessage: &bpmnErr.Message,
+			}
+			if bpmnErr.Variables != nil {
+				query.Variables = &bpmnErr.Variables
+			}
+
+			return ctx.HandleBPMNError(query)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		outVariables, err := camundaclientgo.EncodeVariables(&out)
+		if err != nil {
+			return fmt.Errorf("encode variables: %w", err)
+		}
+
+		return ctx.Complete(QueryComplete{Variables: &outVariables})
+	}, options)
+}
@@ -0,0 +1,132 @@
+package processor
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+type greetingIn struct {
+	Name string `camunda:"name"`
+}
+
+type greetingOut struct {
+	Greeting string `camunda:"greeting"`
+}
+
+func TestAddTypedHandler(t *testing.T) {
+	var mu sync.Mutex
+	var fetched camundaclientgo.QueryFetchAndLock
+	results := map[string]map[string]interface{}{}
+	done := make(chan struct{}, 2)
+	var fetches int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/fetchAndLock") {
+			if atomic.AddInt32(&fetches, 1) > 1 {
+				<-r.Context().Done()
+				return
+			}
+
+			mu.Lock()
+			_ = json.NewDecoder(r.Body).Decode(&fetched)
+			mu.Unlock()
+			_ = json.NewEncoder(w).Encode([]camundaclientgo.ResLockedExternalTask{
+				{Id: "1", TopicName: "Greet", Variables: map[string]camundaclientgo.Variable{
+					"name": {Value: "John", Type: camundaclientgo.VariableTypeString},
+				}},
+				{Id: "2", TopicName: "Greet", Variables: map[string]camundaclientgo.Variable{
+					"name": {Value: "", Type: camundaclientgo.VariableTypeString},
+				}},
+			})
+			return
+		}
+
+		body := map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		results[strings.TrimPrefix(r.URL.Path, "/external-task/")] = body
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+		done <- struct{}{}
+	}))
+	defer server.Close()
+
+	client := camundaclientgo.NewClient(camundaclientgo.ClientOptions{EndpointUrl: server.URL})
+	proc := NewProcessor(client, &Options{
+		MaxTasks:           2,
+		LongPollingTimeout: time.Minute,
+	}, func(err error) {})
+
+	AddTypedHandler(proc, []*camundaclientgo.QueryFetchAndLockTopic{{TopicName: "Greet"}}, func(ctx *Context, in greetingIn) (greetingOut, error) {
+		if in.Name == "" {
+			return greetingOut{}, &BPMNError{Code: "NoName", Message: "name is empty"}
+		}
+
+		return greetingOut{Greeting: "Hello, " + in.Name}, nil
+	})
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-done:
+		case <-time.After(time.Second * 5):
+			t.Fatal("Handler timeout")
+		}
+	}
+	proc.Shutdown()
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"name"}, fetched.Topics[0].Variables)
+	assert.Equal(t, map[string]interface{}{
+		"greeting": map[string]interface{}{
+			"value":     "Hello, John",
+			"type":      "String",
+			"valueInfo": map[string]interface{}{"objectTypeName": nil, "serializationDataFormat": nil},
+		},
+	}, results["1/complete"]["variables"])
+	assert.Equal(t, "NoName", results["2/bpmnError"]["errorCode"])
+}
+
+func TestAddTypedHandlerWithoutVariables(t *testing.T) {
+	fetched := make(chan map[string]interface{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		select {
+		case fetched <- body:
+		default:
+		}
+
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := camundaclientgo.NewClient(camundaclientgo.ClientOptions{EndpointUrl: server.URL})
+	proc := NewProcessor(client, &Options{
+		LongPollingTimeout: time.Minute,
+	}, func(err error) {})
+	defer proc.Shutdown()
+
+	topics := []*camundaclientgo.QueryFetchAndLockTopic{{TopicName: "Ping"}}
+	AddTypedHandler(proc, topics, func(ctx *Context, in struct{}) (struct{}, error) {
+		return struct{}{}, nil
+	})
+	assert.Equal(t, []string{}, topics[0].Variables)
+
+	select {
+	case body := <-fetched:
+		topic := body["topics"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, []interface{}{}, topic["variables"])
+	case <-time.After(time.Second * 5):
+		t.Fatal("fetchAndLock timeout")
+	}
+}
@@ -0,0 +1,114 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+	"github.com/citilinkru/camunda-client-go/v3/bpmn"
+)
+
+// ValidationReport a result of Validate
+type ValidationReport struct {
+	// topics of external tasks without a handler with keys of process definitions which use them
+	MissingHandlers map[string][]string
+	// sorted topics of handlers which no external task of the process definitions uses
+	OrphanedHandlers []string
+}
+
+// Valid returns true if every topic has a handler and every handler has a topic
+func (r *ValidationReport) Valid() bool {
+	return len(r.MissingHandlers) == 0 && len(r.OrphanedHandlers) == 0
+}
+
+// ValidationError an error of Validate with Options.StrictValidation, or passed to the logger without it
+type ValidationError struct {
+	Report *ValidationReport
+}
+
+// Error returns missing and orphaned topics
+func (e *ValidationError) Error() string {
+	var problems []string
+	if len(e.Report.MissingHandlers) > 0 {
+		topics := make([]string, 0, len(e.Report.MissingHandlers))
+		for topic, keys := range e.Report.MissingHandlers {
+			topics = append(topics, fmt.Sprintf("%s (%s)", topic, strings.Join(keys, ", ")))
+		}
+		sort.Strings(topics)
+		problems = append(problems, "no handlers of topics "+strings.Join(topics, ", "))
+	}
+
+	if len(e.Report.OrphanedHandlers) > 0 {
+		problems = append(problems, "no external tasks of topics "+strings.Join(e.Report.OrphanedHandlers, ", "))
+	}
+
+	return "processor validation failed: " + strings.Join(problems, "; ")
+}
+
+// Validate compares topics of external tasks in the latest versions of the process definitions with topics
+// of registered handlers. Call it after AddHandler and before tasks pile up: the engine keeps tasks of topics
+// without a handler until somebody fetches them. Problems are passed to the logger as a *ValidationError,
+// with Options.StrictValidation it is returned instead, so startup can fail
+func (p *Processor) Validate(ctx context.Context, processDefinitionKeys []string) (*ValidationReport, error) {
+	client := p.client.WithContext(ctx)
+	used := map[string]bool{}
+	report := &ValidationReport{MissingHandlers: map[string][]string{}}
+	handled := p.topics()
+	for _, key := range processDefinitionKeys {
+		key := key
+		res, err := client.ProcessDefinition.GetXML(camundaclientgo.QueryProcessDefinitionBy{Key: &key})
+		if err != nil {
+			return nil, fmt.Errorf("get xml of process definition %s: %w", key, err)
+		}
+
+		definitions, err := bpmn.ParseString(res.Bpmn20Xml)
+		if err != nil {
+			return nil, fmt.Errorf("parse process definition %s: %w", key, err)
+		}
+
+		for _, topic := range definitions.Topics() {
+			used[topic] = true
+			if !handled[topic] {
+				report.MissingHandlers[topic] = append(report.MissingHandlers[topic], key)
+			}
+		}
+	}
+
+	for topic := range handled {
+		if !used[topic] {
+			report.OrphanedHandlers = append(report.OrphanedHandlers, topic)
+		}
+	}
+	sort.Strings(report.OrphanedHandlers)
+
+	if report.Valid() {
+		return report, nil
+	}
+
+	err := &ValidationError{Report: report}
+	if p.options.StrictValidation {
+		return report, err
+	}
+
+	p.logger(err)
+	return report, nil
+}
+
+// topics returns topics of all registered handlers
+func (p *Processor) topics() map[string]bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	topics := map[string]bool{}
+	for _, loop := range p.loops {
+		for _, sub := range loop.subscriptions {
+			for _, topic := range sub.topics {
+				topics[topic.TopicName] = true
+			}
+		}
+	}
+
+	return topics
+}
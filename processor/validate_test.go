@@ -0,0 +1,62 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+	"github.com/citilinkru/camunda-client-go/v3/camundatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	server := camundatest.NewServer()
+	defer server.Close()
+
+	client := server.Client()
+	bpmn, err := os.ReadFile("../examples/deployment/HelloWorld.bpmn")
+	require.NoError(t, err)
+	_, err = client.Deployment.Create(camundaclientgo.ReqDeploymentCreate{
+		DeploymentName: "HelloWorld",
+		Resources:      map[string]interface{}{"HelloWorld.bpmn": strings.NewReader(string(bpmn))},
+	})
+	require.NoError(t, err)
+
+	var logged []error
+	options := &Options{LongPollingTimeout: time.Second}
+	proc := NewProcessor(client, options, func(err error) { logged = append(logged, err) })
+	defer proc.Shutdown()
+	handler := func(ctx *Context) error { return nil }
+	proc.AddHandler([]*camundaclientgo.QueryFetchAndLockTopic{{TopicName: "PrintHello"}}, handler)
+	proc.AddHandler([]*camundaclientgo.QueryFetchAndLockTopic{{TopicName: "PrintBye"}}, handler)
+
+	expected := &ValidationReport{
+		MissingHandlers:  map[string][]string{"PrintWorld": {"hello-world-process"}},
+		OrphanedHandlers: []string{"PrintBye"},
+	}
+	report, err := proc.Validate(context.Background(), []string{"hello-world-process"})
+	require.NoError(t, err)
+	assert.Equal(t, expected, report)
+	require.Len(t, logged, 1)
+	assert.EqualError(t, logged[0], "processor validation failed: no handlers of topics PrintWorld (hello-world-process); no external tasks of topics PrintBye")
+
+	options.StrictValidation = true
+	report, err = proc.Validate(context.Background(), []string{"hello-world-process"})
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, expected, validationErr.Report)
+	assert.False(t, report.Valid())
+
+	proc.AddHandler([]*camundaclientgo.QueryFetchAndLockTopic{{TopicName: "PrintWorld"}}, handler)
+	report, err = proc.Validate(context.Background(), []string{"hello-world-process"})
+	require.Error(t, err)
+	assert.Empty(t, report.MissingHandlers)
+
+	_, err = proc.Validate(context.Background(), []string{"unknown"})
+	assert.True(t, errors.Is(err, camundaclientgo.ErrorNotFound))
+}
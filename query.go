@@ -0,0 +1,112 @@
+package camunda_client_go
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sort orders of list queries
+const (
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+)
+
+// QueryPagination a pagination of a list query
+type QueryPagination struct {
+	// Pagination of results. Specifies the index of the first result to return
+	FirstResult int `query:"firstResult"`
+	// Pagination of results. Specifies the maximum number of results to return.
+	// Will return less results if there are no more results left
+	MaxResults int `query:"maxResults"`
+}
+
+// QuerySorting a sorting of a list query
+type QuerySorting struct {
+	// Sort the results lexicographically by a given criterion. Must be used in conjunction with the SortOrder
+	SortBy string `query:"sortBy"`
+	// Sort the results in a given order. Values may be SortOrderAsc or SortOrderDesc.
+	// Must be used in conjunction with the SortBy
+	SortOrder string `query:"sortOrder"`
+}
+
+var (
+	queryVariablesType = reflect.TypeOf([]VariableFilterExpression(nil))
+	queryStringsType   = reflect.TypeOf([]string(nil))
+)
+
+// encodeQuery returns query parameters from fields of a struct with the `query:"name"` tag.
+// Zero values are omitted except of pointers, so *bool and *int are used when false or 0 is meaningful.
+// Dates are formatted with toCamundaTime, slices are joined with commas and variable filter expressions
+// are formatted as `name_operator_value`. Fields of embedded structs without a tag are included
+func encodeQuery(q interface{}) map[string]string {
+	params := map[string]string{}
+	v := reflect.ValueOf(q)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return params
+		}
+		v = v.Elem()
+	}
+
+	encodeQueryStruct(v, params)
+	return params
+}
+
+func encodeQueryStruct(v reflect.Value, params map[string]string) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, ok := f.Tag.Lookup("query")
+		if !ok {
+			if f.Anonymous && f.Type.Kind() == reflect.Struct {
+				encodeQueryStruct(v.Field(i), params)
+			}
+			continue
+		}
+
+		if value, ok := encodeQueryValue(v.Field(i)); ok {
+			params[name] = value
+		}
+	}
+}
+
+func encodeQueryValue(v reflect.Value) (string, bool) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", false
+		}
+
+		value, _ := encodeQueryValue(v.Elem())
+		return value, true
+	}
+
+	switch v.Type() {
+	case timeType:
+		value := toCamundaTime(v.Interface().(time.Time))
+		return value, value != ""
+	case camundaTime:
+		value := toCamundaTime(v.Interface().(Time).Time)
+		return value, value != ""
+	case queryStringsType:
+		return strings.Join(v.Interface().([]string), ","), v.Len() > 0
+	case queryVariablesType:
+		expressions := make([]string, 0, v.Len())
+		for _, e := range v.Interface().([]VariableFilterExpression) {
+			expressions = append(expressions, e.Name+"_"+string(e.Operator)+"_"+e.Value)
+		}
+		return strings.Join(expressions, ","), v.Len() > 0
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), v.Len() > 0
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), v.Int() != 0
+	}
+
+	return "", false
+}
@@ -0,0 +1,85 @@
+package camunda_client_go
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryParams(t *testing.T) {
+	version := 0
+	deserialize := false
+	tests := []struct {
+		query  interface{ Params() map[string]string }
+		params map[string]string
+	}{
+		{
+			query:  &QueryProcessDefinitionList{},
+			params: map[string]string{},
+		},
+		{
+			query: &QueryProcessDefinitionList{
+				QuerySorting:    QuerySorting{SortBy: "version", SortOrder: SortOrderDesc},
+				QueryPagination: QueryPagination{MaxResults: 10},
+				KeysIn:          []string{"a", "b"},
+				Version:         &version,
+				LatestVersion:   true,
+				Suspended:       false,
+			},
+			params: map[string]string{
+				"sortBy":        "version",
+				"sortOrder":     "desc",
+				"maxResults":    "10",
+				"keysIn":        "a,b",
+				"version":       "0",
+				"latestVersion": "true",
+			},
+		},
+		{
+			query: &QueryProcessInstanceList{
+				Variables: []VariableFilterExpression{
+					{Name: "amount", Operator: VariableFilterExpressionOperatorGreaterThan, Value: "100"},
+					{Name: "status", Operator: VariableFilterExpressionOperatorEqual, Value: "new"},
+				},
+			},
+			params: map[string]string{"variables": "amount_gt_100,status_eq_new"},
+		},
+		{
+			query: &QueryDeploymentList{
+				After: time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC),
+			},
+			params: map[string]string{"after": "2021-01-02T03:04:05.000+0000"},
+		},
+		{
+			query:  &QueryHistoryVariableInstanceList{DeserializeValues: &deserialize},
+			params: map[string]string{"deserializeValues": "false"},
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.params, test.query.Params())
+	}
+}
+
+func TestQueryParamsRequest(t *testing.T) {
+	var rawQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawQuery = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"count": 1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOptions{EndpointUrl: server.URL})
+	query := QueryHistoryTaskList{
+		StartedAfter: time.Date(2021, 1, 2, 3, 4, 5, 0, time.FixedZone("", 3*60*60)),
+		Finished:     true,
+	}
+	count, err := client.History.GetTaskCount(query.Params())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "finished=true&startedAfter=2021-01-02T03%3A04%3A05.000%2B0300", rawQuery)
+}
@@ -0,0 +1,24 @@
+module github.com/citilinkru/camunda-client-go/v3/tracing
+
+go 1.20
+
+require (
+	github.com/citilinkru/camunda-client-go/v3 v3.0.1-0.20261019050459-f8d5b4f1241f
+	github.com/stretchr/testify v1.8.4
+	go.opentelemetry.io/otel v1.24.0
+	go.opentelemetry.io/otel/sdk v1.24.0
+	go.opentelemetry.io/otel/trace v1.24.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/go-logr/logr v1.4.1 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.opentelemetry.io/otel/metric v1.24.0 // indirect
+	golang.org/x/sys v0.17.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// builds against the client in this repository during development, consumers of the module get the required version
+replace github.com/citilinkru/camunda-client-go/v3 => ../
@@ -0,0 +1,66 @@
+package tracing
+
+import (
+	"context"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+	"github.com/citilinkru/camunda-client-go/v3/processor"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// processorTracer a processor.Tracer on OpenTelemetry
+type processorTracer struct {
+	tracer trace.Tracer
+}
+
+// NewProcessorTracer returns a processor.Tracer which starts a span for each fetch and lock request and
+// a span for each handler. A handler span continues the trace stored in process variables (see InjectVariables)
+// and is linked to the span of the fetch request. If topics restrict fetched variables,
+// add VariableTraceParent and VariableTraceState to them
+func NewProcessorTracer(options Options) processor.Tracer {
+	return &processorTracer{tracer: options.tracer()}
+}
+
+// StartFetch implements processor.Tracer
+func (t *processorTracer) StartFetch(ctx context.Context) (context.Context, func(count int, err error)) {
+	ctx, span := t.tracer.Start(ctx, "Camunda fetchAndLock", trace.WithSpanKind(trace.SpanKindConsumer))
+
+	return ctx, func(count int, err error) {
+		span.SetAttributes(attribute.Int("camunda.tasks_count", count))
+		endSpan(span, err)
+	}
+}
+
+// StartTask implements processor.Tracer
+func (t *processorTracer) StartTask(ctx, fetchCtx context.Context, task *camundaclientgo.ResLockedExternalTask) (context.Context, func(err error)) {
+	parent := ExtractVariables(ctx, task.Variables)
+	ctx, span := t.tracer.Start(
+		parent,
+		"Camunda task "+task.TopicName,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithLinks(trace.LinkFromContext(fetchCtx)),
+		trace.WithAttributes(
+			attribute.String("camunda.topic", task.TopicName),
+			attribute.String("camunda.external_task_id", task.Id),
+			attribute.String("camunda.activity_id", task.ActivityId),
+			attribute.String("camunda.process_instance_id", task.ProcessInstanceId),
+			attribute.String("camunda.process_definition_id", task.ProcessDefinitionId),
+			attribute.String("camunda.process_definition_key", task.ProcessDefinitionKey),
+			attribute.String("camunda.worker_id", task.WorkerId),
+		),
+	)
+
+	return ctx, func(err error) {
+		endSpan(span, err)
+	}
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
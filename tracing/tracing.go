@@ -0,0 +1,73 @@
+// Package tracing provides OpenTelemetry tracing of Camunda REST calls and external task handlers.
+// A W3C trace context is propagated through process variables, so a trace started by a process instance start
+// continues in workers of its external tasks
+package tracing
+
+import (
+	"context"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/citilinkru/camunda-client-go/v3/tracing"
+
+// VariableTraceParent a name of the process variable with a W3C traceparent
+const VariableTraceParent = "traceparent"
+
+// VariableTraceState a name of the process variable with a W3C tracestate
+const VariableTraceState = "tracestate"
+
+// Options options for tracing
+type Options struct {
+	// provider of tracers (default: otel.GetTracerProvider())
+	TracerProvider trace.TracerProvider
+}
+
+func (o Options) tracer() trace.Tracer {
+	provider := o.TracerProvider
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+
+	return provider.Tracer(instrumentationName)
+}
+
+// variablesCarrier a propagation.TextMapCarrier on process variables
+type variablesCarrier map[string]camundaclientgo.Variable
+
+func (c variablesCarrier) Get(key string) string {
+	v, ok := c[key]
+	if !ok {
+		return ""
+	}
+
+	s, _ := v.Value.(string)
+	return s
+}
+
+func (c variablesCarrier) Set(key, value string) {
+	c[key] = camundaclientgo.Variable{Value: value, Type: "String"}
+}
+
+func (c variablesCarrier) Keys() []string {
+	return []string{VariableTraceParent, VariableTraceState}
+}
+
+// InjectVariables add the W3C trace context of ctx to process variables, e.g. of ReqStartInstance.
+// Nothing is added if ctx has no valid span
+func InjectVariables(ctx context.Context, variables map[string]camundaclientgo.Variable) {
+	if variables == nil || !trace.SpanContextFromContext(ctx).IsValid() {
+		return
+	}
+
+	propagation.TraceContext{}.Inject(ctx, variablesCarrier(variables))
+}
+
+// ExtractVariables returns a copy of ctx with the W3C trace context stored in process variables.
+// If there is no trace context in variables, ctx is returned as is
+func ExtractVariables(ctx context.Context, variables map[string]camundaclientgo.Variable) context.Context {
+	return propagation.TraceContext{}.Extract(ctx, variablesCarrier(variables))
+}
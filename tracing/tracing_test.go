@@ -0,0 +1,173 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	camundaclientgo "github.com/citilinkru/camunda-client-go/v3"
+	"github.com/citilinkru/camunda-client-go/v3/processor"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestRoute(t *testing.T) {
+	tests := []struct {
+		path  string
+		route string
+		attrs []attribute.KeyValue
+	}{
+		{
+			path:  "/engine-rest/process-definition/key/HelloWorld/start",
+			route: "/process-definition/key/{key}/start",
+			attrs: []attribute.KeyValue{attribute.String("camunda.process_definition_key", "HelloWorld")},
+		},
+		{
+			path:  "/engine-rest/process-instance/42/variables/amount",
+			route: "/process-instance/{id}/variables/{varName}",
+			attrs: []attribute.KeyValue{attribute.String("camunda.process_instance_id", "42")},
+		},
+		{
+			path:  "/engine-rest/external-task/fetchAndLock",
+			route: "/external-task/fetchAndLock",
+		},
+		{
+			path:  "/engine-rest/history/process-instance/42",
+			route: "/history/process-instance/{id}",
+			attrs: []attribute.KeyValue{attribute.String("camunda.process_instance_id", "42")},
+		},
+		{
+			path:  "/engine-rest/deployment/7/resources/8/data",
+			route: "/deployment/{id}/resources/{resourceId}/data",
+			attrs: []attribute.KeyValue{attribute.String("camunda.deployment_id", "7")},
+		},
+		{
+			path:  "/unknown",
+			route: "/unknown",
+		},
+	}
+
+	for _, test := range tests {
+		route, attrs := route(test.path)
+		assert.Equal(t, test.route, route, test.path)
+		assert.Equal(t, test.attrs, attrs, test.path)
+	}
+}
+
+func TestInjectExtractVariables(t *testing.T) {
+	provider := sdktrace.NewTracerProvider()
+	ctx, span := provider.Tracer("test").Start(context.Background(), "test")
+	defer span.End()
+
+	variables := map[string]camundaclientgo.Variable{}
+	InjectVariables(context.Background(), variables)
+	assert.Empty(t, variables)
+
+	InjectVariables(ctx, variables)
+	assert.Equal(t, "String", variables[VariableTraceParent].Type)
+
+	extracted := ExtractVariables(context.Background(), variables)
+	assert.Equal(t, span.SpanContext().TraceID(), trace.SpanContextFromContext(extracted).TraceID())
+}
+
+func TestTracing(t *testing.T) {
+	var mu sync.Mutex
+	var started map[string]camundaclientgo.Variable
+	var fetches int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/start"):
+			req := camundaclientgo.ReqStartInstance{}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			mu.Lock()
+			started = *req.Variables
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"id":"42"}`))
+		case strings.HasSuffix(r.URL.Path, "/fetchAndLock"):
+			if atomic.AddInt32(&fetches, 1) > 1 {
+				<-r.Context().Done()
+				return
+			}
+
+			mu.Lock()
+			variables := started
+			mu.Unlock()
+			_ = json.NewEncoder(w).Encode([]camundaclientgo.ResLockedExternalTask{
+				{Id: "1", TopicName: "PrintHello", ProcessInstanceId: "42", Variables: variables},
+			})
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	options := Options{TracerProvider: provider}
+
+	client := camundaclientgo.NewClient(camundaclientgo.ClientOptions{EndpointUrl: server.URL + "/engine-rest"})
+	client.SetCustomTransport(NewTransport(nil, options))
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "api")
+	variables := map[string]camundaclientgo.Variable{}
+	InjectVariables(ctx, variables)
+	key := "HelloWorld"
+	_, err := client.WithContext(ctx).ProcessDefinition.StartInstance(
+		camundaclientgo.QueryProcessDefinitionBy{Key: &key},
+		camundaclientgo.ReqStartInstance{Variables: &variables},
+	)
+	assert.NoError(t, err)
+	span.End()
+
+	proc := processor.NewProcessor(client, &processor.Options{
+		LongPollingTimeout: time.Minute,
+		Tracer:             NewProcessorTracer(options),
+	}, func(err error) {})
+
+	done := make(chan struct{})
+	proc.AddHandler([]*camundaclientgo.QueryFetchAndLockTopic{{TopicName: "PrintHello"}}, func(ctx *processor.Context) error {
+		defer close(done)
+		return ctx.Complete(processor.QueryComplete{})
+	})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 5):
+		t.Fatal("Handler timeout")
+	}
+	proc.Shutdown()
+
+	spans := map[string]tracetest.SpanStub{}
+	for _, s := range exporter.GetSpans() {
+		if _, ok := spans[s.Name]; !ok {
+			spans[s.Name] = s
+		}
+	}
+
+	start := spans["Camunda POST /process-definition/key/{key}/start"]
+	assert.Equal(t, span.SpanContext().SpanID(), start.Parent.SpanID())
+	assert.Contains(t, start.Attributes, attribute.Int("http.response.status_code", http.StatusOK))
+
+	fetch := spans["Camunda fetchAndLock"]
+	fetchRequest := spans["Camunda POST /external-task/fetchAndLock"]
+	assert.Equal(t, fetch.SpanContext.SpanID(), fetchRequest.Parent.SpanID())
+
+	task := spans["Camunda task PrintHello"]
+	assert.Equal(t, span.SpanContext().TraceID(), task.SpanContext.TraceID())
+	assert.Equal(t, fetch.SpanContext.SpanID(), task.Links[0].SpanContext.SpanID())
+	assert.Contains(t, task.Attributes, attribute.String("camunda.process_instance_id", "42"))
+
+	complete := spans["Camunda POST /external-task/{id}/complete"]
+	assert.Equal(t, task.SpanContext.SpanID(), complete.Parent.SpanID())
+	assert.Contains(t, complete.Attributes, attribute.String("camunda.external_task_id", "1"))
+}
@@ -0,0 +1,141 @@
+package tracing
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// resources a root segments of engine paths
+var resources = map[string]string{
+	"deployment":         "camunda.deployment_id",
+	"execution":          "camunda.execution_id",
+	"external-task":      "camunda.external_task_id",
+	"history":            "",
+	"message":            "",
+	"process-definition": "camunda.process_definition_id",
+	"process-instance":   "camunda.process_instance_id",
+	"task":               "camunda.task_id",
+	"tenant":             "camunda.tenant_id",
+}
+
+// actions a segments of engine paths which are never ids
+var actions = map[string]bool{
+	"activity-instances": true, "bpmnError": true, "complete": true, "count": true, "create": true, "data": true,
+	"delete": true, "deployed-start-form": true, "diagram": true, "extendLock": true, "failure": true,
+	"fetchAndLock": true, "form-variables": true, "history-time-to-live": true, "job-retries": true, "key": true,
+	"modification": true, "modification-async": true, "priority": true, "redeploy": true, "rendered-form": true,
+	"report": true, "resources": true, "restart": true, "restart-async": true, "retries": true, "retries-async": true,
+	"start": true, "startForm": true, "statistics": true, "submit-form": true, "suspended": true,
+	"suspended-async": true, "tenant-id": true, "unlock": true, "variables": true, "localVariables": true,
+	"variables-async": true, "xml": true,
+}
+
+// route an engine path with ids replaced by placeholders and the ids as span attributes
+func route(path string) (string, []attribute.KeyValue) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	start := len(segments)
+	for i, segment := range segments {
+		if _, ok := resources[segment]; ok {
+			start = i
+			break
+		}
+	}
+	if start == len(segments) {
+		return path, nil
+	}
+
+	var attrs []attribute.KeyValue
+	resource := ""
+	for i := start; i < len(segments); i++ {
+		segment := segments[i]
+		prev := ""
+		if i > start {
+			prev = segments[i-1]
+		}
+
+		switch {
+		case i == start:
+			resource = segment
+		case prev == "history" && i == start+1:
+			// a resource of the history api, e.g. /history/process-instance
+			resource = segment
+		case prev == "key" && resource == "process-definition":
+			attrs = append(attrs, attribute.String("camunda.process_definition_key", segment))
+			segments[i] = "{key}"
+		case prev == "tenant-id":
+			attrs = append(attrs, attribute.String("camunda.tenant_id", segment))
+			segments[i] = "{tenantId}"
+		case prev == "variables" || prev == "localVariables":
+			segments[i] = "{varName}"
+		case prev == "resources":
+			segments[i] = "{resourceId}"
+		case prev == resource && !actions[segment]:
+			if key := resources[resource]; key != "" {
+				attrs = append(attrs, attribute.String(key, segment))
+			}
+			segments[i] = "{id}"
+		}
+	}
+
+	return "/" + strings.Join(segments[start:], "/"), attrs
+}
+
+// transport an http.RoundTripper which starts a span for each request
+type transport struct {
+	base   http.RoundTripper
+	tracer trace.Tracer
+}
+
+// NewTransport returns an http.RoundTripper which starts a span for each request to the engine.
+// Spans are children of the client context, see Client.WithContext.
+// Use it with Client.SetCustomTransport, base is http.DefaultTransport if nil
+func NewTransport(base http.RoundTripper, options Options) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &transport{
+		base:   base,
+		tracer: options.tracer(),
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	path, attrs := route(req.URL.Path)
+	ctx, span := t.tracer.Start(
+		req.Context(),
+		fmt.Sprintf("Camunda %s %s", req.Method, path),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("camunda.path", req.URL.Path),
+			attribute.String("camunda.route", path),
+		),
+		trace.WithAttributes(attrs...),
+	)
+	defer span.End()
+
+	req = req.Clone(ctx)
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	res, err := t.base.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", res.StatusCode))
+	if res.StatusCode >= 400 {
+		span.SetStatus(codes.Error, res.Status)
+	}
+
+	return res, nil
+}
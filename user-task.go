@@ -1,6 +1,7 @@
 package camunda_client_go
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -450,6 +451,27 @@ func (t *userTaskApi) GetList(query *UserTaskGetListQuery) ([]UserTask, error) {
 	return resp, nil
 }
 
+// GetListPager returns a Pager over tasks, FirstResult and MaxResults of the query are ignored.
+// Results are sorted by id unless SortBy is set in the query
+func (t *userTaskApi) GetListPager(query *UserTaskGetListQuery, pageSize int) *Pager[UserTask] {
+	q := UserTaskGetListQuery{}
+	if query != nil {
+		q = *query
+	}
+
+	if q.SortBy == "" {
+		q.SortBy = "id"
+		q.SortOrder = "asc"
+	}
+
+	return NewPager(func(ctx context.Context, firstResult, maxResults int) ([]UserTask, error) {
+		page := q
+		page.FirstResult = int64(firstResult)
+		page.MaxResults = int64(maxResults)
+		return t.client.WithContext(ctx).UserTask.GetList(&page)
+	}, pageSize)
+}
+
 // GetListCount retrieves task list count
 func (t *userTaskApi) GetListCount(query *UserTaskGetListQuery) (int64, error) {
 	if query == nil {
@@ -533,3 +555,48 @@ func (t *userTaskApi) DeleteIdentityLink(id string, query ReqIdentityLink) error
 
 	return nil
 }
+
+// DownloadVariableData retrieves the content of a Bytes or File variable visible from the task without buffering it.
+// The returned data must be closed
+func (t *userTaskApi) DownloadVariableData(id, variableName string) (*ResVariableData, error) {
+	return t.client.downloadVariableData("/task/" + id + "/variables/" + variableName + "/data")
+}
+
+// UploadVariableData sets the content of a Bytes or File variable visible from the task,
+// the content is streamed as multipart form data
+func (t *userTaskApi) UploadVariableData(id, variableName string, req ReqBinaryVariable) error {
+	return t.client.uploadVariableData("/task/"+id+"/variables/"+variableName+"/data", variableName, req)
+}
+
+// DownloadLocalVariableData retrieves the content of a Bytes or File local variable of the task without buffering it.
+// The returned data must be closed
+func (t *userTaskApi) DownloadLocalVariableData(id, variableName string) (*ResVariableData, error) {
+	return t.client.downloadVariableData("/task/" + id + "/localVariables/" + variableName + "/data")
+}
+
+// UploadLocalVariableData sets the content of a Bytes or File local variable of the task,
+// the content is streamed as multipart form data
+func (t *userTaskApi) UploadLocalVariableData(id, variableName string, req ReqBinaryVariable) error {
+	return t.client.uploadVariableData("/task/"+id+"/localVariables/"+variableName+"/data", variableName, req)
+}
+
+// ResTaskCandidateGroupCount a number of open tasks of a candidate group
+type ResTaskCandidateGroupCount struct {
+	// The name of the candidate group, empty for tasks without a candidate group
+	GroupName string `json:"groupName"`
+	// The number of tasks which have the group as candidate group
+	TaskCount int `json:"taskCount"`
+}
+
+// GetCandidateGroupCountReport retrieves the number of open tasks for each candidate group,
+// it is a report of runtime data, completed tasks are not counted
+// https://docs.camunda.org/manual/latest/reference/rest/task/report/get-candidate-group-count/
+func (t *userTaskApi) GetCandidateGroupCountReport() (reports []*ResTaskCandidateGroupCount, err error) {
+	res, err := t.client.doGet("/task/report/candidate-group-count", nil)
+	if err != nil {
+		return
+	}
+
+	err = t.client.readJsonResponse(res, &reports)
+	return
+}
@@ -0,0 +1,276 @@
+package camunda_client_go
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strings"
+)
+
+// ReqBinaryVariable a Bytes or File variable with a streamed content
+type ReqBinaryVariable struct {
+	// Mandatory. The content of the variable, it is closed after the request if it implements io.Closer
+	Content io.Reader
+	// The type of the variable: Bytes or File (default: File)
+	Type string
+	// The name of the file. This is not the variable name but the name that will be used when downloading
+	// the file again (default: the variable name)
+	FileName string
+	// The MIME type of the file
+	MimeType string
+	// The encoding of the file
+	Encoding string
+}
+
+func (r ReqBinaryVariable) valueType() string {
+	if r.Type == "" {
+		return VariableTypeFile
+	}
+
+	return r.Type
+}
+
+func (r ReqBinaryVariable) close() {
+	if c, ok := r.Content.(io.Closer); ok {
+		c.Close()
+	}
+}
+
+// ResVariableData a streamed content of a Bytes or File variable. It must be closed after reading
+type ResVariableData struct {
+	io.ReadCloser
+	// The name of the file
+	FileName string
+	// The MIME type of the file
+	MimeType string
+	// The encoding of the file
+	Encoding string
+	// The size of the content or -1 if unknown
+	Size int64
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// downloadVariableData returns the body of a binary variable request without reading it
+func (c *Client) downloadVariableData(path string) (*ResVariableData, error) {
+	res, err := c.doGet(path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &ResVariableData{
+		ReadCloser: res.Body,
+		Size:       res.ContentLength,
+	}
+
+	if mediaType, params, err := mime.ParseMediaType(res.Header.Get("Content-Type")); err == nil {
+		data.MimeType = mediaType
+		data.Encoding = params["charset"]
+	}
+
+	if _, params, err := mime.ParseMediaType(res.Header.Get("Content-Disposition")); err == nil {
+		data.FileName = params["filename"]
+	}
+
+	return data, nil
+}
+
+// uploadVariableData post a binary variable as multipart form data streaming its content
+func (c *Client) uploadVariableData(path, name string, req ReqBinaryVariable) error {
+	defer req.close()
+
+	if req.Content == nil {
+		return fmt.Errorf("upload variable %s: content is nil", name)
+	}
+
+	boundary := multipart.NewWriter(io.Discard).Boundary()
+	return c.doPostStream(path, nil, "multipart/form-data; boundary="+boundary, func(w io.Writer) error {
+		mw := multipart.NewWriter(w)
+		if err := mw.SetBoundary(boundary); err != nil {
+			return err
+		}
+
+		fileName := req.FileName
+		if fileName == "" {
+			fileName = name
+		}
+
+		contentType := req.MimeType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		if req.Encoding != "" {
+			contentType += "; charset=" + req.Encoding
+		}
+
+		h := make(textproto.MIMEHeader)
+		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="data"; filename="%s"`, quoteEscaper.Replace(fileName)))
+		h.Set("Content-Type", contentType)
+		part, err := mw.CreatePart(h)
+		if err != nil {
+			return err
+		}
+
+		if _, err = io.Copy(part, req.Content); err != nil {
+			return err
+		}
+
+		if err = mw.WriteField("valueType", req.valueType()); err != nil {
+			return err
+		}
+
+		return mw.Close()
+	})
+}
+
+// doPostStream post a body written by write without buffering it
+func (c *Client) doPostStream(path string, query map[string]string, contentType string, write func(w io.Writer) error) error {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(write(pw))
+	}()
+
+	res, err := c.do(http.MethodPost, path, query, pr, contentType)
+	// unblock the writer if the request was finished before the whole body was sent
+	pr.Close()
+	if err != nil {
+		return err
+	}
+
+	res.Body.Close()
+	return nil
+}
+
+// writeCompleteJson write a JSON body of the external task completion with binary variables encoded in base64
+func writeCompleteJson(w io.Writer, query QueryComplete, variables, localVariables map[string]ReqBinaryVariable) error {
+	if _, err := io.WriteString(w, "{"); err != nil {
+		return err
+	}
+
+	if query.WorkerId != nil {
+		workerId, err := json.Marshal(*query.WorkerId)
+		if err != nil {
+			return err
+		}
+
+		if _, err = fmt.Fprintf(w, `"workerId":%s,`, workerId); err != nil {
+			return err
+		}
+	}
+
+	if _, err := io.WriteString(w, `"variables":`); err != nil {
+		return err
+	}
+	if err := writeVariablesJson(w, query.Variables, variables); err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, `,"localVariables":`); err != nil {
+		return err
+	}
+	if err := writeVariablesJson(w, query.LocalVariables, localVariables); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "}")
+	return err
+}
+
+func writeVariablesJson(w io.Writer, variables *map[string]Variable, binary map[string]ReqBinaryVariable) error {
+	if _, err := io.WriteString(w, "{"); err != nil {
+		return err
+	}
+
+	first := true
+	separator := func() error {
+		if first {
+			first = false
+			return nil
+		}
+
+		_, err := io.WriteString(w, ",")
+		return err
+	}
+
+	if variables != nil {
+		for name, variable := range *variables {
+			if _, ok := binary[name]; ok {
+				continue
+			}
+
+			key, err := json.Marshal(name)
+			if err != nil {
+				return err
+			}
+			value, err := json.Marshal(variable)
+			if err != nil {
+				return err
+			}
+
+			if err = separator(); err != nil {
+				return err
+			}
+			if _, err = fmt.Fprintf(w, "%s:%s", key, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	for name, req := range binary {
+		if req.Content == nil {
+			return fmt.Errorf("variable %s: content is nil", name)
+		}
+
+		fileName := req.FileName
+		if fileName == "" && req.valueType() == VariableTypeFile {
+			fileName = name
+		}
+
+		key, err := json.Marshal(name)
+		if err != nil {
+			return err
+		}
+		head, err := json.Marshal(struct {
+			Type      string    `json:"type"`
+			ValueInfo ValueInfo `json:"valueInfo"`
+		}{
+			Type: req.valueType(),
+			ValueInfo: ValueInfo{
+				FileName: fileName,
+				MimeType: req.MimeType,
+				Encoding: req.Encoding,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		if err = separator(); err != nil {
+			return err
+		}
+		// open the object and append the value, which is streamed in base64
+		if _, err = fmt.Fprintf(w, `%s:%s,"value":"`, key, head[:len(head)-1]); err != nil {
+			return err
+		}
+
+		encoder := base64.NewEncoder(base64.StdEncoding, w)
+		if _, err = io.Copy(encoder, req.Content); err != nil {
+			return err
+		}
+		if err = encoder.Close(); err != nil {
+			return err
+		}
+
+		if _, err = io.WriteString(w, `"}`); err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(w, "}")
+	return err
+}
@@ -0,0 +1,133 @@
+package camunda_client_go
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadVariableData(t *testing.T) {
+	var fileName, contentType, valueType, data string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/process-instance/1/variables/doc/data", r.URL.Path)
+		reader, err := r.MultipartReader()
+		assert.NoError(t, err)
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			assert.NoError(t, err)
+
+			content, _ := io.ReadAll(part)
+			switch part.FormName() {
+			case "data":
+				fileName = part.FileName()
+				contentType = part.Header.Get("Content-Type")
+				data = string(content)
+			case "valueType":
+				valueType = string(content)
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOptions{EndpointUrl: server.URL})
+	id, name := "1", "doc"
+	err := client.ProcessInstance.UploadProcessVariableData(QueryProcessInstanceVariableBy{Id: &id, VariableName: &name}, ReqBinaryVariable{
+		Content:  io.NopCloser(strings.NewReader("hello")),
+		FileName: "hello.txt",
+		MimeType: "text/plain",
+		Encoding: "UTF-8",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "hello.txt", fileName)
+	assert.Equal(t, "text/plain; charset=UTF-8", contentType)
+	assert.Equal(t, VariableTypeFile, valueType)
+	assert.Equal(t, "hello", data)
+}
+
+func TestUploadVariableDataError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOptions{EndpointUrl: server.URL})
+	// the request fails before the whole content is sent
+	err := client.Execution.UploadLocalVariableData("1", "doc", ReqBinaryVariable{
+		Content: io.LimitReader(zeroReader{}, 64<<20),
+	})
+	assert.Error(t, err)
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+func TestDownloadVariableData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/task/1/localVariables/doc/data", r.URL.Path)
+		w.Header().Set("Content-Type", "text/plain; charset=UTF-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="hello.txt"`)
+		_, _ = w.Write([]byte("hello"))
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOptions{EndpointUrl: server.URL})
+	data, err := client.UserTask.DownloadLocalVariableData("1", "doc")
+	assert.NoError(t, err)
+	defer data.Close()
+
+	content, err := io.ReadAll(data)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(content))
+	assert.Equal(t, "hello.txt", data.FileName)
+	assert.Equal(t, "text/plain", data.MimeType)
+	assert.Equal(t, "UTF-8", data.Encoding)
+	assert.Equal(t, int64(5), data.Size)
+}
+
+func TestCompleteWithBinaryVariables(t *testing.T) {
+	var body struct {
+		WorkerId       string              `json:"workerId"`
+		Variables      map[string]Variable `json:"variables"`
+		LocalVariables map[string]Variable `json:"localVariables"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/external-task/1/complete", r.URL.Path)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(ClientOptions{EndpointUrl: server.URL})
+	workerId := "worker"
+	err := client.ExternalTask.CompleteWithBinaryVariables("1", QueryComplete{
+		WorkerId:  &workerId,
+		Variables: &map[string]Variable{"status": {Value: "done", Type: VariableTypeString}},
+	}, map[string]ReqBinaryVariable{
+		"report": {Content: strings.NewReader("hello"), MimeType: "text/plain"},
+	}, map[string]ReqBinaryVariable{
+		"raw": {Content: strings.NewReader("raw"), Type: VariableTypeBytes},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "worker", body.WorkerId)
+	assert.Equal(t, "done", body.Variables["status"].Value)
+	assert.Equal(t, "aGVsbG8=", body.Variables["report"].Value)
+	assert.Equal(t, VariableTypeFile, body.Variables["report"].Type)
+	assert.Equal(t, "report", body.Variables["report"].ValueInfo.FileName)
+	assert.Equal(t, "text/plain", body.Variables["report"].ValueInfo.MimeType)
+	assert.Equal(t, Variable{Value: "cmF3", Type: VariableTypeBytes}, body.LocalVariables["raw"])
+}
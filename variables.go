@@ -0,0 +1,577 @@
+package camunda_client_go
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Camunda types of variables
+const (
+	VariableTypeString  = "String"
+	VariableTypeBoolean = "Boolean"
+	VariableTypeShort   = "Short"
+	VariableTypeInteger = "Integer"
+	VariableTypeLong    = "Long"
+	VariableTypeDouble  = "Double"
+	VariableTypeDate    = "Date"
+	VariableTypeJson    = "Json"
+	VariableTypeObject  = "Object"
+	VariableTypeNull    = "Null"
+	VariableTypeBytes   = "Bytes"
+	VariableTypeFile    = "File"
+)
+
+// SerializationDataFormatJson a serialization data format of Object variables in JSON
+const SerializationDataFormatJson = "application/json"
+
+var (
+	variableType  = reflect.TypeOf(Variable{})
+	timeType      = reflect.TypeOf(time.Time{})
+	camundaTime   = reflect.TypeOf(Time{})
+	byteSliceType = reflect.TypeOf([]byte(nil))
+)
+
+// VariableError an error of encoding or decoding of a single variable
+type VariableError struct {
+	// The name of the variable
+	Variable string
+	// The name of the struct field
+	Field string
+	// The cause
+	Err error
+}
+
+// Error error message
+func (e *VariableError) Error() string {
+	return fmt.Sprintf("variable %q (field %s): %s", e.Variable, e.Field, e.Err)
+}
+
+// Unwrap returns the cause
+func (e *VariableError) Unwrap() error {
+	return e.Err
+}
+
+// VariablesError errors of all variables which failed encoding or decoding
+type VariablesError []*VariableError
+
+// Error error message
+func (e VariablesError) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// variableField a struct field mapped to a variable by the `camunda` tag
+type variableField struct {
+	name           string
+	field          string
+	index          []int
+	typ            string
+	objectTypeName string
+	omitEmpty      bool
+}
+
+// variableFields returns fields of a struct with the `camunda:"name[,Type][,omitempty]"` tag.
+// Type is one of Camunda variable types, Object accepts a java type name: `camunda:"order,Object=com.example.Order"`.
+// Fields of embedded structs without a tag are included
+func variableFields(t reflect.Type, index []int) []variableField {
+	var fields []variableField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, ok := f.Tag.Lookup("camunda")
+		fieldIndex := append(append([]int(nil), index...), i)
+
+		if !ok {
+			if f.Anonymous && f.Type.Kind() == reflect.Struct {
+				fields = append(fields, variableFields(f.Type, fieldIndex)...)
+			}
+			continue
+		}
+
+		if tag == "-" || f.PkgPath != "" {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		field := variableField{
+			name:  parts[0],
+			field: f.Name,
+			index: fieldIndex,
+		}
+		if field.name == "" {
+			field.name = f.Name
+		}
+
+		for _, option := range parts[1:] {
+			switch {
+			case option == "omitempty":
+				field.omitEmpty = true
+			case strings.HasPrefix(option, VariableTypeObject+"="):
+				field.typ = VariableTypeObject
+				field.objectTypeName = strings.TrimPrefix(option, VariableTypeObject+"=")
+			default:
+				field.typ = option
+			}
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+// VariableNames returns names of variables of a struct with the `camunda` tag, v is the struct or a pointer to it.
+// Use it to fetch only the variables needed to decode the struct
+func VariableNames(v interface{}) []string {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var names []string
+	for _, field := range variableFields(t, nil) {
+		names = append(names, field.name)
+	}
+
+	return names
+}
+
+// EncodeVariables returns variables from fields of a struct with the `camunda:"name[,Type][,omitempty]"` tag.
+// Types are derived from Go types unless Type is set: strings are String, bools are Boolean, int32 and smaller
+// integers are Integer, other integers are Long, floats are Double, time.Time and Time are Date, []byte is Bytes,
+// other structs, maps and slices are Json, nil pointers are Null. Fields without the tag are ignored
+func EncodeVariables(src interface{}) (map[string]Variable, error) {
+	v := reflect.ValueOf(src)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, errors.New("encode variables: nil source")
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("encode variables: source must be a struct, got %s", v.Type())
+	}
+
+	variables := make(map[string]Variable)
+	var errs VariablesError
+	for _, field := range variableFields(v.Type(), nil) {
+		fv := v.FieldByIndex(field.index)
+		if field.omitEmpty && fv.IsZero() {
+			continue
+		}
+
+		variable, err := encodeVariable(fv, field)
+		if err != nil {
+			errs = append(errs, &VariableError{Variable: field.name, Field: field.field, Err: err})
+			continue
+		}
+
+		variables[field.name] = variable
+	}
+
+	if len(errs) > 0 {
+		return variables, errs
+	}
+
+	return variables, nil
+}
+
+func encodeVariable(v reflect.Value, field variableField) (Variable, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return Variable{Type: VariableTypeNull}, nil
+		}
+		v = v.Elem()
+	}
+
+	if v.Type() == variableType {
+		return v.Interface().(Variable), nil
+	}
+
+	switch field.typ {
+	case VariableTypeJson:
+		data, err := json.Marshal(v.Interface())
+		if err != nil {
+			return Variable{}, err
+		}
+
+		return Variable{Value: string(data), Type: VariableTypeJson}, nil
+	case VariableTypeObject:
+		data, err := json.Marshal(v.Interface())
+		if err != nil {
+			return Variable{}, err
+		}
+
+		objectTypeName := field.objectTypeName
+		serializationDataFormat := SerializationDataFormatJson
+		return Variable{
+			Value: string(data),
+			Type:  VariableTypeObject,
+			ValueInfo: ValueInfo{
+				ObjectTypeName:          &objectTypeName,
+				SerializationDataFormat: &serializationDataFormat,
+			},
+		}, nil
+	case VariableTypeFile:
+		if v.Type() != byteSliceType {
+			return Variable{}, fmt.Errorf("cannot encode %s as %s", v.Type(), VariableTypeFile)
+		}
+
+		return Variable{
+			Value:     base64.StdEncoding.EncodeToString(v.Bytes()),
+			Type:      VariableTypeFile,
+			ValueInfo: ValueInfo{FileName: field.name},
+		}, nil
+	}
+
+	variable, err := encodeValue(v)
+	if err != nil {
+		return Variable{}, err
+	}
+
+	if field.typ == "" || field.typ == variable.Type {
+		return variable, nil
+	}
+
+	if isNumberType(field.typ) && isNumberType(variable.Type) {
+		return convertNumber(variable, field.typ)
+	}
+
+	return Variable{}, fmt.Errorf("cannot encode %s as %s", v.Type(), field.typ)
+}
+
+// encodeValue encodes a value with a type derived from the Go type
+func encodeValue(v reflect.Value) (Variable, error) {
+	switch v.Type() {
+	case timeType:
+		return Variable{Value: v.Interface().(time.Time).Format(DefaultDateTimeFormat), Type: VariableTypeDate}, nil
+	case camundaTime:
+		return Variable{Value: v.Interface().(Time).Format(DefaultDateTimeFormat), Type: VariableTypeDate}, nil
+	case byteSliceType:
+		return Variable{Value: base64.StdEncoding.EncodeToString(v.Bytes()), Type: VariableTypeBytes}, nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return Variable{Value: v.String(), Type: VariableTypeString}, nil
+	case reflect.Bool:
+		return Variable{Value: v.Bool(), Type: VariableTypeBoolean}, nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return Variable{Value: v.Convert(reflect.TypeOf(int64(0))).Int(), Type: VariableTypeInteger}, nil
+	case reflect.Int, reflect.Int64:
+		return Variable{Value: v.Int(), Type: VariableTypeLong}, nil
+	case reflect.Uint, reflect.Uint32, reflect.Uint64:
+		if v.Uint() > math.MaxInt64 {
+			return Variable{}, fmt.Errorf("value %d overflows %s", v.Uint(), VariableTypeLong)
+		}
+
+		return Variable{Value: int64(v.Uint()), Type: VariableTypeLong}, nil
+	case reflect.Float32, reflect.Float64:
+		return Variable{Value: v.Float(), Type: VariableTypeDouble}, nil
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		data, err := json.Marshal(v.Interface())
+		if err != nil {
+			return Variable{}, err
+		}
+
+		return Variable{Value: string(data), Type: VariableTypeJson}, nil
+	}
+
+	return Variable{}, fmt.Errorf("unsupported type %s", v.Type())
+}
+
+func isNumberType(typ string) bool {
+	switch typ {
+	case VariableTypeShort, VariableTypeInteger, VariableTypeLong, VariableTypeDouble:
+		return true
+	}
+
+	return false
+}
+
+// convertNumber change a type of a number variable checking the range of the new type
+func convertNumber(variable Variable, typ string) (Variable, error) {
+	var f float64
+	switch value := variable.Value.(type) {
+	case int64:
+		f = float64(value)
+	case float64:
+		f = value
+	}
+
+	if typ == VariableTypeDouble {
+		return Variable{Value: f, Type: typ}, nil
+	}
+
+	if f != math.Trunc(f) {
+		return Variable{}, fmt.Errorf("cannot encode %v as %s", variable.Value, typ)
+	}
+
+	limits := map[string]float64{
+		VariableTypeShort:   math.MaxInt16,
+		VariableTypeInteger: math.MaxInt32,
+		VariableTypeLong:    math.MaxInt64,
+	}
+	if f > limits[typ] || f < -limits[typ]-1 {
+		return Variable{}, fmt.Errorf("value %v overflows %s", variable.Value, typ)
+	}
+
+	if i, ok := variable.Value.(int64); ok {
+		return Variable{Value: i, Type: typ}, nil
+	}
+
+	return Variable{Value: int64(f), Type: typ}, nil
+}
+
+// DecodeVariables stores variables in fields of a struct with the `camunda:"name"` tag, dst must be a pointer
+// to the struct. Missing variables leave fields as is, Null variables reset fields to zero values.
+// Json and Object variables are unmarshalled into structs, maps and slices, Bytes and File variables into []byte.
+// A field of Variable type receives the variable as is. All fields are decoded, errors are returned as VariablesError
+func DecodeVariables(variables map[string]Variable, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("decode variables: destination must be a non-nil pointer to a struct, got %T", dst)
+	}
+
+	v = v.Elem()
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("decode variables: destination must be a pointer to a struct, got %T", dst)
+	}
+
+	var errs VariablesError
+	for _, field := range variableFields(v.Type(), nil) {
+		variable, ok := variables[field.name]
+		if !ok {
+			continue
+		}
+
+		if err := decodeVariable(variable, v.FieldByIndex(field.index)); err != nil {
+			errs = append(errs, &VariableError{Variable: field.name, Field: field.field, Err: err})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func decodeVariable(variable Variable, v reflect.Value) error {
+	if v.Type() == variableType {
+		v.Set(reflect.ValueOf(variable))
+		return nil
+	}
+
+	if variable.Value == nil || variable.Type == VariableTypeNull {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	switch v.Type() {
+	case timeType, camundaTime:
+		s, ok := variable.Value.(string)
+		if !ok {
+			return decodeError(variable, v)
+		}
+
+		t, err := time.Parse(DefaultDateTimeFormat, s)
+		if err != nil {
+			return fmt.Errorf("cannot decode %s: %w", variable.Type, err)
+		}
+
+		if v.Type() == camundaTime {
+			v.Set(reflect.ValueOf(Time{Time: t}))
+		} else {
+			v.Set(reflect.ValueOf(t))
+		}
+		return nil
+	case byteSliceType:
+		s, ok := variable.Value.(string)
+		if !ok {
+			return decodeError(variable, v)
+		}
+
+		data, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return fmt.Errorf("cannot decode %s: %w", variable.Type, err)
+		}
+
+		v.SetBytes(data)
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(v.Type().Elem())
+		if err := decodeVariable(variable, elem.Elem()); err != nil {
+			return err
+		}
+
+		v.Set(elem)
+		return nil
+	case reflect.Interface:
+		if isSerialized(variable.Type) {
+			return decodeSerialized(variable, v)
+		}
+
+		value := reflect.ValueOf(variable.Value)
+		if !value.Type().AssignableTo(v.Type()) {
+			return decodeError(variable, v)
+		}
+
+		v.Set(value)
+		return nil
+	case reflect.String:
+		s, ok := variable.Value.(string)
+		if !ok {
+			return decodeError(variable, v)
+		}
+
+		v.SetString(s)
+		return nil
+	case reflect.Bool:
+		b, ok := variable.Value.(bool)
+		if !ok {
+			return decodeError(variable, v)
+		}
+
+		v.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, ok := intValue(variable.Value)
+		if !ok {
+			return decodeError(variable, v)
+		}
+
+		if v.OverflowInt(i) {
+			return fmt.Errorf("value %d overflows %s", i, v.Type())
+		}
+
+		v.SetInt(i)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, ok := intValue(variable.Value)
+		if !ok {
+			return decodeError(variable, v)
+		}
+
+		if i < 0 || v.OverflowUint(uint64(i)) {
+			return fmt.Errorf("value %d overflows %s", i, v.Type())
+		}
+
+		v.SetUint(uint64(i))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, ok := floatValue(variable.Value)
+		if !ok {
+			return decodeError(variable, v)
+		}
+
+		v.SetFloat(f)
+		return nil
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		return decodeSerialized(variable, v)
+	}
+
+	return decodeError(variable, v)
+}
+
+func isSerialized(typ string) bool {
+	return typ == VariableTypeJson || typ == VariableTypeObject
+}
+
+// decodeSerialized unmarshal a Json or Object variable, which value is a JSON string or already deserialized value
+func decodeSerialized(variable Variable, v reflect.Value) error {
+	if variable.Type == VariableTypeObject && variable.ValueInfo.SerializationDataFormat != nil &&
+		*variable.ValueInfo.SerializationDataFormat != SerializationDataFormatJson {
+		return fmt.Errorf("cannot decode Object serialized as %s", *variable.ValueInfo.SerializationDataFormat)
+	}
+
+	var data []byte
+	if s, ok := variable.Value.(string); ok && isSerialized(variable.Type) {
+		data = []byte(s)
+	} else if _, ok := variable.Value.(string); ok {
+		return decodeError(variable, v)
+	} else {
+		var err error
+		if data, err = json.Marshal(variable.Value); err != nil {
+			return err
+		}
+	}
+
+	target := reflect.New(v.Type())
+	if err := json.Unmarshal(data, target.Interface()); err != nil {
+		return fmt.Errorf("cannot decode %s into %s: %w", variable.Type, v.Type(), err)
+	}
+
+	v.Set(target.Elem())
+	return nil
+}
+
+func decodeError(variable Variable, v reflect.Value) error {
+	return fmt.Errorf("cannot decode %s value %v (%T) into %s", variable.Type, variable.Value, variable.Value, v.Type())
+}
+
+// intValue returns an integer from a number decoded from JSON or set by a caller
+func intValue(value interface{}) (int64, bool) {
+	switch n := value.(type) {
+	case json.Number:
+		i, err := n.Int64()
+		return i, err == nil
+	case float64:
+		if n != math.Trunc(n) || n > math.MaxInt64 || n < math.MinInt64 {
+			return 0, false
+		}
+		return int64(n), true
+	case float32:
+		return intValue(float64(n))
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(v.Uint()), true
+	}
+
+	return 0, false
+}
+
+// floatValue returns a float from a number decoded from JSON or set by a caller
+func floatValue(value interface{}) (float64, bool) {
+	if n, ok := value.(json.Number); ok {
+		f, err := n.Float64()
+		return f, err == nil
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,150 @@
+package camunda_client_go
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testAddress struct {
+	City string `json:"city"`
+}
+
+type testBase struct {
+	Id string `camunda:"id"`
+}
+
+type testVariables struct {
+	testBase
+	Name      string            `camunda:"name"`
+	Active    bool              `camunda:"active"`
+	Count     int32             `camunda:"count"`
+	Amount    int64             `camunda:"amount"`
+	Small     int               `camunda:"small,Integer"`
+	Price     float64           `camunda:"price"`
+	Created   time.Time         `camunda:"created"`
+	Address   testAddress       `camunda:"address"`
+	Order     map[string]string `camunda:"order,Object=java.util.HashMap"`
+	Data      []byte            `camunda:"data"`
+	Report    []byte            `camunda:"report,File"`
+	Comment   *string           `camunda:"comment"`
+	Skipped   string            `camunda:"skipped,omitempty"`
+	Raw       Variable          `camunda:"raw"`
+	Untagged  string
+	Any       interface{} `camunda:"any"`
+	Forgotten int         `camunda:"-"`
+}
+
+func TestEncodeDecodeVariables(t *testing.T) {
+	created := time.Date(2021, 3, 4, 5, 6, 7, 0, time.FixedZone("", 3*60*60))
+	src := testVariables{
+		testBase: testBase{Id: "42"},
+		Name:     "John",
+		Active:   true,
+		Count:    3,
+		Amount:   1 << 40,
+		Small:    7,
+		Price:    9.99,
+		Created:  created,
+		Address:  testAddress{City: "Moscow"},
+		Order:    map[string]string{"item": "book"},
+		Data:     []byte("data"),
+		Report:   []byte("report"),
+		Raw:      Variable{Value: "raw", Type: VariableTypeString},
+		Untagged: "untagged",
+		Any:      "any",
+	}
+
+	variables, err := EncodeVariables(&src)
+	assert.NoError(t, err)
+	assert.Equal(t, Variable{Value: "42", Type: VariableTypeString}, variables["id"])
+	assert.Equal(t, Variable{Value: int64(3), Type: VariableTypeInteger}, variables["count"])
+	assert.Equal(t, Variable{Value: int64(1 << 40), Type: VariableTypeLong}, variables["amount"])
+	assert.Equal(t, Variable{Value: int64(7), Type: VariableTypeInteger}, variables["small"])
+	assert.Equal(t, Variable{Value: "2021-03-04T05:06:07.000+0300", Type: VariableTypeDate}, variables["created"])
+	assert.Equal(t, Variable{Value: `{"city":"Moscow"}`, Type: VariableTypeJson}, variables["address"])
+	assert.Equal(t, "java.util.HashMap", *variables["order"].ValueInfo.ObjectTypeName)
+	assert.Equal(t, SerializationDataFormatJson, *variables["order"].ValueInfo.SerializationDataFormat)
+	assert.Equal(t, Variable{Value: "ZGF0YQ==", Type: VariableTypeBytes}, variables["data"])
+	assert.Equal(t, "report", variables["report"].ValueInfo.FileName)
+	assert.Equal(t, Variable{Type: VariableTypeNull}, variables["comment"])
+	assert.NotContains(t, variables, "skipped")
+	assert.NotContains(t, variables, "Untagged")
+	assert.NotContains(t, variables, "Forgotten")
+	assert.Len(t, variables, 15)
+
+	// simulate a round trip through the engine, numbers become float64
+	data, err := json.Marshal(variables)
+	assert.NoError(t, err)
+	decodedVariables := map[string]Variable{}
+	assert.NoError(t, json.Unmarshal(data, &decodedVariables))
+
+	comment := "comment"
+	dst := testVariables{Comment: &comment, Untagged: "kept"}
+	assert.NoError(t, DecodeVariables(decodedVariables, &dst))
+	assert.True(t, dst.Created.Equal(created))
+	dst.Created = src.Created
+	dst.Raw.ValueInfo = ValueInfo{}
+	src.Untagged = "kept"
+	assert.Equal(t, src, dst)
+}
+
+func TestDecodeVariablesErrors(t *testing.T) {
+	var dst struct {
+		Count   int32     `camunda:"count"`
+		Price   float64   `camunda:"price"`
+		Created time.Time `camunda:"created"`
+		Address struct {
+			City string `json:"city"`
+		} `camunda:"address"`
+		Name string `camunda:"name"`
+	}
+
+	err := DecodeVariables(map[string]Variable{
+		"count":   {Value: float64(1 << 40), Type: VariableTypeLong},
+		"price":   {Value: "9.99", Type: VariableTypeString},
+		"created": {Value: "yesterday", Type: VariableTypeDate},
+		"address": {Value: map[string]interface{}{"city": "Moscow"}, Type: VariableTypeJson},
+		"name":    {Value: "John", Type: VariableTypeString},
+	}, &dst)
+
+	var errs VariablesError
+	assert.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 3)
+	assert.Equal(t, "count", errs[0].Variable)
+	assert.Equal(t, "Price", errs[1].Field)
+	assert.Contains(t, errs[1].Error(), `variable "price" (field Price): cannot decode String value 9.99 (string) into float64`)
+	assert.Equal(t, "created", errs[2].Variable)
+	assert.Equal(t, "Moscow", dst.Address.City)
+	assert.Equal(t, "John", dst.Name)
+
+	assert.Error(t, DecodeVariables(nil, dst))
+}
+
+func TestEncodeVariablesErrors(t *testing.T) {
+	_, err := EncodeVariables(struct {
+		Count int64  `camunda:"count,Integer"`
+		Name  string `camunda:"name,Date"`
+	}{Count: 1 << 40})
+
+	var errs VariablesError
+	assert.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 2)
+
+	_, err = EncodeVariables("string")
+	assert.Error(t, err)
+}
+
+func TestVariableNames(t *testing.T) {
+	assert.Equal(t, []string{"id", "name", "active"}, VariableNames(&struct {
+		testBase
+		Name   string `camunda:"name"`
+		Active bool   `camunda:"active"`
+		Other  string
+	}{}))
+	assert.Nil(t, VariableNames(42))
+	assert.Nil(t, VariableNames(nil))
+}